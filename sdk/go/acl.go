@@ -0,0 +1,76 @@
+package codex
+
+// Metadata keys holding per-record access control lists. A record with
+// neither key is public; otherwise an actor must appear in the user list
+// or share a group with the group list to see it.
+const (
+	MetaACLUsers  = "acl_users"
+	MetaACLGroups = "acl_groups"
+)
+
+// Actor identifies who a retrieval is performed on behalf of, for ACL
+// enforcement against record metadata.
+type Actor struct {
+	Subject string   `json:"subject"`
+	Groups  []string `json:"groups,omitempty"`
+}
+
+// WithActor attributes the request to a subject and their groups. The
+// server filters retrieval results against record ACL metadata; calls
+// that retrieve client-side (RAGAnswerMulti) enforce the same rules
+// locally, so documents an actor cannot see never reach the model.
+func WithActor(subject string, groups ...string) RAGOption {
+	return func(o *RAGOptions) { o.Actor = &Actor{Subject: subject, Groups: groups} }
+}
+
+// Allowed reports whether the actor may see rec under its ACL metadata.
+func (a Actor) Allowed(rec VectorRecord) bool {
+	users, hasUsers := aclList(rec.Metadata[MetaACLUsers])
+	groups, hasGroups := aclList(rec.Metadata[MetaACLGroups])
+	if !hasUsers && !hasGroups {
+		return true
+	}
+	for _, u := range users {
+		if u == a.Subject {
+			return true
+		}
+	}
+	for _, g := range groups {
+		for _, mine := range a.Groups {
+			if g == mine {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// FilterMatches returns the matches the actor is allowed to see,
+// preserving order.
+func (a Actor) FilterMatches(matches []Match) []Match {
+	out := matches[:0:0]
+	for _, m := range matches {
+		if a.Allowed(m.VectorRecord) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// aclList widens an ACL metadata value: []string as written by callers,
+// or []any as produced by JSON decoding.
+func aclList(v any) ([]string, bool) {
+	switch list := v.(type) {
+	case []string:
+		return list, true
+	case []any:
+		out := make([]string, 0, len(list))
+		for _, item := range list {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out, true
+	}
+	return nil, false
+}
@@ -0,0 +1,38 @@
+package codex
+
+import "testing"
+
+func TestActorAllowed(t *testing.T) {
+	actor := Actor{Subject: "alice", Groups: []string{"eng"}}
+	cases := []struct {
+		name string
+		meta map[string]any
+		want bool
+	}{
+		{"public record", nil, true},
+		{"listed user", map[string]any{MetaACLUsers: []string{"alice"}}, true},
+		{"other user only", map[string]any{MetaACLUsers: []string{"bob"}}, false},
+		{"shared group", map[string]any{MetaACLGroups: []string{"eng", "ops"}}, true},
+		{"foreign group", map[string]any{MetaACLGroups: []string{"ops"}}, false},
+		{"json-decoded list", map[string]any{MetaACLUsers: []any{"alice"}}, true},
+		{"empty lists deny", map[string]any{MetaACLUsers: []string{}}, false},
+	}
+	for _, tc := range cases {
+		if got := actor.Allowed(VectorRecord{Metadata: tc.meta}); got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestFilterMatches(t *testing.T) {
+	actor := Actor{Subject: "alice"}
+	matches := []Match{
+		{VectorRecord: VectorRecord{ID: "public"}},
+		{VectorRecord: VectorRecord{ID: "private", Metadata: map[string]any{MetaACLUsers: []string{"bob"}}}},
+		{VectorRecord: VectorRecord{ID: "mine", Metadata: map[string]any{MetaACLUsers: []string{"alice"}}}},
+	}
+	got := actor.FilterMatches(matches)
+	if len(got) != 2 || got[0].ID != "public" || got[1].ID != "mine" {
+		t.Fatalf("bad filter result: %+v", got)
+	}
+}
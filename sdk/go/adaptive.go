@@ -0,0 +1,204 @@
+package codex
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// AdaptiveBatcher splits large embed and upsert workloads into
+// sub-batches whose size adapts AIMD-style to what the server can take:
+// additive increase while requests come back fast, multiplicative
+// decrease on rate limits and slow responses. It finds the deployment's
+// sweet spot on its own, so batch sizes need no hand-tuning; one
+// batcher should be reused across calls so the learned size carries
+// over.
+type AdaptiveBatcher struct {
+	Client Codex
+
+	// MinBatch and MaxBatch bound the sub-batch size. Default 8 and 512.
+	MinBatch int
+	MaxBatch int
+	// InitialBatch is the starting size. Defaults to 64.
+	InitialBatch int
+	// Step is the additive increase after a fast sub-batch. Defaults to 8.
+	Step int
+	// TargetLatency is the per-request latency above which the batch
+	// size is halved even without errors. Defaults to 2s.
+	TargetLatency time.Duration
+	// Parallelism is how many sub-batches are in flight at once.
+	// Defaults to 1.
+	Parallelism int
+
+	mu   sync.Mutex
+	size int
+}
+
+func (b *AdaptiveBatcher) defaults() (min, max, step, par int, target time.Duration) {
+	min, max, step, par, target = b.MinBatch, b.MaxBatch, b.Step, b.Parallelism, b.TargetLatency
+	if min <= 0 {
+		min = 8
+	}
+	if max <= 0 {
+		max = 512
+	}
+	if step <= 0 {
+		step = 8
+	}
+	if par <= 0 {
+		par = 1
+	}
+	if target <= 0 {
+		target = 2 * time.Second
+	}
+	return
+}
+
+// batchSize returns the current sub-batch size, initialising it on
+// first use.
+func (b *AdaptiveBatcher) batchSize() int {
+	min, max, _, _, _ := b.defaults()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.size == 0 {
+		b.size = b.InitialBatch
+		if b.size <= 0 {
+			b.size = 64
+		}
+	}
+	if b.size < min {
+		b.size = min
+	}
+	if b.size > max {
+		b.size = max
+	}
+	return b.size
+}
+
+// adjust applies the AIMD rule after one sub-batch.
+func (b *AdaptiveBatcher) adjust(latency time.Duration, backoff bool) {
+	min, max, step, _, target := b.defaults()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch {
+	case backoff || latency > target:
+		b.size /= 2
+		if b.size < min {
+			b.size = min
+		}
+	default:
+		b.size += step
+		if b.size > max {
+			b.size = max
+		}
+	}
+}
+
+// transient reports whether err signals pressure worth backing off from
+// rather than a permanent failure.
+func transient(err error) bool {
+	var ae *APIError
+	return errors.As(err, &ae) && ae.Temporary()
+}
+
+// run processes n items in adaptively sized sub-batches, calling do for
+// each claimed range. Transiently failing ranges are halved and retried;
+// a range that still fails at the minimum size aborts the run.
+func (b *AdaptiveBatcher) run(ctx context.Context, n int, do func(start, end int) error) error {
+	min, _, _, par, _ := b.defaults()
+	var (
+		cursor  int
+		claimMu sync.Mutex
+	)
+	claim := func() (int, int, bool) {
+		size := b.batchSize()
+		claimMu.Lock()
+		defer claimMu.Unlock()
+		if cursor >= n {
+			return 0, 0, false
+		}
+		start := cursor
+		end := start + size
+		if end > n {
+			end = n
+		}
+		cursor = end
+		return start, end, true
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	errs := make(chan error, par)
+	var wg sync.WaitGroup
+	for w := 0; w < par; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if ctx.Err() != nil {
+					return
+				}
+				start, end, ok := claim()
+				if !ok {
+					return
+				}
+				// Retry this range at shrinking sizes until it goes
+				// through or stops being transient.
+				for lo := start; lo < end; {
+					size := b.batchSize()
+					hi := lo + size
+					if hi > end {
+						hi = end
+					}
+					began := time.Now()
+					err := do(lo, hi)
+					if err == nil {
+						b.adjust(time.Since(began), false)
+						lo = hi
+						continue
+					}
+					if !transient(err) || size <= min {
+						errs <- err
+						cancel()
+						return
+					}
+					b.adjust(time.Since(began), true)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// EmbedBatch embeds texts through adaptively sized sub-batches,
+// returning one vector per text in order.
+func (b *AdaptiveBatcher) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, len(texts))
+	err := b.run(ctx, len(texts), func(start, end int) error {
+		vecs, err := b.Client.EmbedBatch(ctx, texts[start:end])
+		if err != nil {
+			return err
+		}
+		copy(out[start:end], vecs)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpsertBatch writes recs through adaptively sized sub-batches.
+func (b *AdaptiveBatcher) UpsertBatch(ctx context.Context, collection string, recs []VectorRecord) error {
+	return b.run(ctx, len(recs), func(start, end int) error {
+		return b.Client.UpsertBatch(ctx, collection, recs[start:end])
+	})
+}
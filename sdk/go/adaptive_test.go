@@ -0,0 +1,88 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// pressureHandler accepts embed batches up to capacity and 429s larger
+// ones, recording every accepted batch size.
+func pressureHandler(t *testing.T, capacity int) (http.Handler, *[]int) {
+	var mu sync.Mutex
+	var sizes []int
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req embedRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Inputs) > capacity {
+			w.WriteHeader(http.StatusTooManyRequests)
+			json.NewEncoder(w).Encode(APIError{Message: "slow down"})
+			return
+		}
+		mu.Lock()
+		sizes = append(sizes, len(req.Inputs))
+		mu.Unlock()
+		vecs := make([][]float32, len(req.Inputs))
+		for i := range vecs {
+			vecs[i] = []float32{1}
+		}
+		json.NewEncoder(w).Encode(embedWire{Vectors: vecs})
+	})
+	return h, &sizes
+}
+
+func TestAdaptiveBatcherShrinksUnderPressure(t *testing.T) {
+	h, sizes := pressureHandler(t, 20)
+	c := newTestClient(t, h, WithMaxRetries(0))
+	b := &AdaptiveBatcher{Client: c, InitialBatch: 64, MinBatch: 4}
+	texts := make([]string, 100)
+	for i := range texts {
+		texts[i] = "t"
+	}
+	vecs, err := b.EmbedBatch(context.Background(), texts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vecs) != 100 || vecs[99] == nil {
+		t.Fatalf("vectors: %d", len(vecs))
+	}
+	for _, s := range *sizes {
+		if s > 20 {
+			t.Fatalf("accepted batch of %d despite capacity 20", s)
+		}
+	}
+	if got := b.batchSize(); got >= 64 {
+		t.Fatalf("learned size = %d, expected it to shrink from 64", got)
+	}
+}
+
+func TestAdaptiveBatcherGrowsWhenHealthy(t *testing.T) {
+	h, sizes := pressureHandler(t, 1000)
+	c := newTestClient(t, h, WithMaxRetries(0))
+	b := &AdaptiveBatcher{Client: c, InitialBatch: 8, Step: 8}
+	texts := make([]string, 80)
+	for i := range texts {
+		texts[i] = "t"
+	}
+	if _, err := b.EmbedBatch(context.Background(), texts); err != nil {
+		t.Fatal(err)
+	}
+	last := (*sizes)[len(*sizes)-1]
+	if last <= 8 {
+		t.Fatalf("batch size never grew: %v", *sizes)
+	}
+}
+
+func TestAdaptiveBatcherSurfacesPermanentErrors(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(APIError{Message: "bad vector"})
+	}), WithMaxRetries(0))
+	b := &AdaptiveBatcher{Client: c}
+	err := b.UpsertBatch(context.Background(), "kb", make([]VectorRecord, 10))
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
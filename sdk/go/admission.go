@@ -0,0 +1,102 @@
+package codex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrClientOverloaded matches errors returned when the client-side
+// admission controller rejects a request because the endpoint's in-flight
+// limit and queue are both full: errors.Is(err, codex.ErrClientOverloaded).
+var ErrClientOverloaded = errors.New("codex: client overloaded")
+
+// OverloadedError reports which endpoint rejected the request and how
+// saturated it was, so load shedding can be logged with enough context
+// to tune the limits.
+type OverloadedError struct {
+	Endpoint string
+	InFlight int
+	Queued   int
+}
+
+func (e *OverloadedError) Error() string {
+	return fmt.Sprintf("codex: client overloaded: %s (%d in flight, %d queued)", e.Endpoint, e.InFlight, e.Queued)
+}
+
+func (e *OverloadedError) Is(target error) bool { return target == ErrClientOverloaded }
+
+// admission bounds work per endpoint: at most maxInFlight requests run
+// concurrently, at most maxQueued wait behind them (for up to timeout
+// each), and everything beyond that is rejected immediately. This keeps
+// a load spike from piling up unbounded goroutines inside the client.
+type admission struct {
+	maxInFlight int
+	maxQueued   int
+	timeout     time.Duration
+
+	mu    sync.Mutex
+	slots map[string]*admissionSlot
+}
+
+// admissionSlot is the per-endpoint state: a token channel sized to the
+// in-flight limit plus a count of waiters against the queue bound.
+type admissionSlot struct {
+	tokens chan struct{}
+
+	mu     sync.Mutex
+	queued int
+}
+
+func (a *admission) slot(endpoint string) *admissionSlot {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.slots == nil {
+		a.slots = make(map[string]*admissionSlot)
+	}
+	s, ok := a.slots[endpoint]
+	if !ok {
+		s = &admissionSlot{tokens: make(chan struct{}, a.maxInFlight)}
+		a.slots[endpoint] = s
+	}
+	return s
+}
+
+// acquire admits a request to endpoint, returning the release to defer,
+// or an *OverloadedError when the endpoint is saturated. Context
+// cancellation while queued surfaces as the context's error.
+func (a *admission) acquire(ctx context.Context, endpoint string) (func(), error) {
+	s := a.slot(endpoint)
+	select {
+	case s.tokens <- struct{}{}:
+		return func() { <-s.tokens }, nil
+	default:
+	}
+
+	s.mu.Lock()
+	if s.queued >= a.maxQueued {
+		queued := s.queued
+		s.mu.Unlock()
+		return nil, &OverloadedError{Endpoint: endpoint, InFlight: a.maxInFlight, Queued: queued}
+	}
+	s.queued++
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.queued--
+		s.mu.Unlock()
+	}()
+
+	t := time.NewTimer(a.timeout)
+	defer t.Stop()
+	select {
+	case s.tokens <- struct{}{}:
+		return func() { <-s.tokens }, nil
+	case <-t.C:
+		return nil, &OverloadedError{Endpoint: endpoint, InFlight: a.maxInFlight, Queued: a.maxQueued}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,120 @@
+package codex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAdmissionRejectsWhenSaturated(t *testing.T) {
+	arrived := make(chan struct{})
+	hold := make(chan struct{})
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-arrived:
+		default:
+			close(arrived)
+			<-hold
+		}
+		w.Write([]byte(`{}`))
+	}), WithAdmissionControl(1, 0, time.Second))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.ListCollections(context.Background())
+		done <- err
+	}()
+	<-arrived
+
+	_, err := c.ListCollections(context.Background())
+	if !errors.Is(err, ErrClientOverloaded) {
+		t.Fatalf("err = %v, want ErrClientOverloaded", err)
+	}
+	var oe *OverloadedError
+	if !errors.As(err, &oe) || oe.Endpoint != "/v1/collections" {
+		t.Fatalf("overload detail: %+v", oe)
+	}
+
+	close(hold)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	// With the slot free again the next request is admitted.
+	if _, err := c.ListCollections(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAdmissionQueueTimesOut(t *testing.T) {
+	arrived := make(chan struct{})
+	hold := make(chan struct{})
+	defer close(hold)
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(arrived)
+		<-hold
+		w.Write([]byte(`{}`))
+	}), WithAdmissionControl(1, 1, 30*time.Millisecond))
+
+	go c.ListCollections(context.Background())
+	<-arrived
+
+	start := time.Now()
+	_, err := c.ListCollections(context.Background())
+	if !errors.Is(err, ErrClientOverloaded) {
+		t.Fatalf("err = %v, want ErrClientOverloaded", err)
+	}
+	if waited := time.Since(start); waited < 30*time.Millisecond {
+		t.Errorf("rejected after %s, before the queue timeout", waited)
+	}
+}
+
+func TestAdmissionIsPerEndpoint(t *testing.T) {
+	arrived := make(chan struct{})
+	hold := make(chan struct{})
+	defer close(hold)
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/collections" {
+			close(arrived)
+			<-hold
+		}
+		w.Write([]byte(`{}`))
+	}), WithAdmissionControl(1, 0, time.Second))
+
+	go c.ListCollections(context.Background())
+	<-arrived
+
+	// A different endpoint has its own slot and is not affected.
+	if _, err := c.ListTiers(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAdmissionQueuedRequestProceeds(t *testing.T) {
+	hold := make(chan struct{})
+	arrived := make(chan struct{})
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-arrived:
+		default:
+			close(arrived)
+			<-hold
+		}
+		w.Write([]byte(`{}`))
+	}), WithAdmissionControl(1, 1, time.Second))
+
+	go c.ListCollections(context.Background())
+	<-arrived
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.ListCollections(context.Background())
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(hold)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,33 @@
+package codex
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type aggregateRequest struct {
+	Facets []string `json:"facets"`
+	Filter Filter   `json:"filter,omitempty"`
+}
+
+type aggregateWire struct {
+	Facets map[string]map[string]int64 `json:"facets"`
+}
+
+// Aggregate counts records per metadata value for each requested facet,
+// optionally restricted by filter (may be nil). The result maps facet
+// name to value to count, e.g.
+//
+//	facets["lang"]["en"] == 1200
+//
+// for corpus dashboards and filter UIs. Facets over unindexed metadata
+// keys return empty maps rather than errors.
+func (c *Client) Aggregate(ctx context.Context, collection string, facets []string, filter Filter) (map[string]map[string]int64, error) {
+	path := "/v1/collections/" + url.PathEscape(c.collection(collection)) + "/aggregate"
+	var resp aggregateWire
+	if err := c.do(ctx, http.MethodPost, path, aggregateRequest{Facets: facets, Filter: filter}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Facets, nil
+}
@@ -0,0 +1,32 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestAggregate(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/collections/kb/aggregate" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		var req aggregateRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Facets) != 2 || req.Facets[0] != "source" {
+			t.Errorf("facets: %v", req.Facets)
+		}
+		json.NewEncoder(w).Encode(aggregateWire{Facets: map[string]map[string]int64{
+			"source": {"wiki": 120, "docs": 40},
+			"lang":   {"en": 150, "de": 10},
+		}})
+	}))
+	facets, err := c.Aggregate(context.Background(), "kb", []string{"source", "lang"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if facets["source"]["wiki"] != 120 || facets["lang"]["de"] != 10 {
+		t.Fatalf("facets: %+v", facets)
+	}
+}
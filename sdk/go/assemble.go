@@ -0,0 +1,112 @@
+package codex
+
+import (
+	"sort"
+	"strings"
+)
+
+// ContextAssembler packs retrieved chunks into prompt context under a
+// token budget, greedily by score, so overflowing retrievals degrade
+// predictably instead of silently truncating mid-chunk.
+type ContextAssembler struct {
+	// Budget is the token budget for the assembled context.
+	// Defaults to 2048.
+	Budget int
+	// MergeAdjacent joins chunks that are consecutive within the same
+	// document (per the doc_id and ordinal metadata written by the
+	// ingestion pipeline) into one block, restoring reading order.
+	MergeAdjacent bool
+	// Separator goes between blocks in the assembled text.
+	// Defaults to "\n\n".
+	Separator string
+}
+
+// AssembledContext is the outcome of packing: the context text, its
+// estimated token count, and which chunks made the cut.
+type AssembledContext struct {
+	Text     string
+	Tokens   int
+	Included []Match
+	Dropped  []Match
+}
+
+// Assemble packs matches into a context under the assembler's budget.
+// Chunks are admitted highest score first until the next one would
+// overflow; the assembled text keeps admitted chunks in their original
+// document order so merged neighbours read coherently.
+func (a *ContextAssembler) Assemble(matches []Match) *AssembledContext {
+	budget := a.Budget
+	if budget <= 0 {
+		budget = 2048
+	}
+	sep := a.Separator
+	if sep == "" {
+		sep = "\n\n"
+	}
+
+	byScore := make([]Match, len(matches))
+	copy(byScore, matches)
+	sort.SliceStable(byScore, func(i, j int) bool { return byScore[i].Score > byScore[j].Score })
+
+	out := &AssembledContext{}
+	sepTokens := EstimateTokens(sep)
+	for _, m := range byScore {
+		cost := EstimateTokens(m.Document)
+		if len(out.Included) > 0 {
+			cost += sepTokens
+		}
+		if out.Tokens+cost > budget {
+			out.Dropped = append(out.Dropped, m)
+			continue
+		}
+		out.Included = append(out.Included, m)
+		out.Tokens += cost
+	}
+
+	ordered := make([]Match, len(out.Included))
+	copy(ordered, out.Included)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		di, dj := chunkKey(ordered[i]), chunkKey(ordered[j])
+		if di.doc != dj.doc {
+			return di.doc < dj.doc
+		}
+		return di.ordinal < dj.ordinal
+	})
+
+	blocks := make([]string, 0, len(ordered))
+	for i, m := range ordered {
+		if a.MergeAdjacent && i > 0 && adjacent(ordered[i-1], m) {
+			blocks[len(blocks)-1] += "\n" + m.Document
+			continue
+		}
+		blocks = append(blocks, m.Document)
+	}
+	out.Text = strings.Join(blocks, sep)
+	return out
+}
+
+type chunkID struct {
+	doc     string
+	ordinal int
+}
+
+// chunkKey reads the doc_id and ordinal metadata the ingestion pipeline
+// writes on each chunk. Chunks without them sort under their record ID.
+func chunkKey(m Match) chunkID {
+	key := chunkID{doc: m.ID, ordinal: -1}
+	if doc, ok := m.Metadata["doc_id"].(string); ok {
+		key.doc = doc
+	}
+	switch ord := m.Metadata["ordinal"].(type) {
+	case int:
+		key.ordinal = ord
+	case float64:
+		key.ordinal = int(ord)
+	}
+	return key
+}
+
+func adjacent(a, b Match) bool {
+	ka, kb := chunkKey(a), chunkKey(b)
+	return ka.doc == kb.doc && ka.ordinal >= 0 && kb.ordinal == ka.ordinal+1
+}
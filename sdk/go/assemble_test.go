@@ -0,0 +1,62 @@
+package codex
+
+import (
+	"strings"
+	"testing"
+)
+
+func chunkMatch(doc string, ordinal int, text string, score float32) Match {
+	return Match{
+		VectorRecord: VectorRecord{
+			ID:       doc + "#" + string(rune('0'+ordinal)),
+			Document: text,
+			Metadata: map[string]any{"doc_id": doc, "ordinal": ordinal},
+		},
+		Score: score,
+	}
+}
+
+func TestAssembleRespectsBudget(t *testing.T) {
+	a := &ContextAssembler{Budget: 30}
+	ctx := a.Assemble([]Match{
+		chunkMatch("a", 0, strings.Repeat("alpha ", 15), 0.9), // ~23 tokens
+		chunkMatch("b", 0, strings.Repeat("beta ", 15), 0.8),  // would overflow
+		chunkMatch("c", 0, "short tail", 0.7),                 // still fits
+	})
+	if len(ctx.Included) != 2 || len(ctx.Dropped) != 1 {
+		t.Fatalf("included=%d dropped=%d", len(ctx.Included), len(ctx.Dropped))
+	}
+	if ctx.Dropped[0].Metadata["doc_id"] != "b" {
+		t.Fatalf("dropped: %+v", ctx.Dropped)
+	}
+	if ctx.Tokens > 30 {
+		t.Fatalf("tokens = %d", ctx.Tokens)
+	}
+}
+
+func TestAssembleMergesAdjacentChunks(t *testing.T) {
+	a := &ContextAssembler{Budget: 100, MergeAdjacent: true, Separator: "\n---\n"}
+	ctx := a.Assemble([]Match{
+		chunkMatch("doc", 2, "third part", 0.95),
+		chunkMatch("other", 0, "unrelated", 0.9),
+		chunkMatch("doc", 1, "second part", 0.85),
+	})
+	if len(ctx.Included) != 3 {
+		t.Fatalf("included: %+v", ctx.Included)
+	}
+	want := "second part\nthird part\n---\nunrelated"
+	if ctx.Text != want {
+		t.Fatalf("text = %q", ctx.Text)
+	}
+}
+
+func TestAssembleKeepsDocumentOrder(t *testing.T) {
+	a := &ContextAssembler{Budget: 100}
+	ctx := a.Assemble([]Match{
+		chunkMatch("doc", 3, "later", 0.7),
+		chunkMatch("doc", 1, "earlier", 0.9),
+	})
+	if ctx.Text != "earlier\n\nlater" {
+		t.Fatalf("text = %q", ctx.Text)
+	}
+}
@@ -0,0 +1,80 @@
+package codex
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type authKey struct{}
+
+type tenantKey struct{}
+
+type onBehalfKey struct{}
+
+// ContextWithAuth attaches a caller-specific bearer token to ctx. Calls
+// made with the returned context authenticate as that caller instead of
+// the client's configured API key, so a service handling many end users
+// can share one client while the server attributes and meters per user.
+func ContextWithAuth(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, authKey{}, token)
+}
+
+// AuthFromContext returns the token attached with ContextWithAuth, if any.
+func AuthFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(authKey{}).(string)
+	return token, ok
+}
+
+// ContextWithTenant attaches a tenant identifier to ctx, overriding the
+// client's configured tenant for calls made with the returned context.
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant attached with ContextWithTenant,
+// if any.
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenant, ok := ctx.Value(tenantKey{}).(string)
+	return tenant, ok
+}
+
+// ContextWithOnBehalfOf attaches an end-user identifier to ctx,
+// overriding the client's configured WithOnBehalfOf for calls made with
+// the returned context — the natural fit for request handlers, where the
+// acting user changes per request.
+func ContextWithOnBehalfOf(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, onBehalfKey{}, userID)
+}
+
+// OnBehalfOfFromContext returns the user identifier attached with
+// ContextWithOnBehalfOf, if any.
+func OnBehalfOfFromContext(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(onBehalfKey{}).(string)
+	return userID, ok
+}
+
+// ExtractAuth is net/http middleware that copies caller identity from an
+// incoming request onto its context: the Authorization bearer token, the
+// X-Codex-Tenant and X-Codex-On-Behalf-Of headers, and the X-Request-ID
+// header (for end-to-end correlation). Handlers then pass r.Context() to Codex calls and the
+// identity flows through unchanged. Frameworks like gin and echo mount
+// standard net/http middleware via their respective wrappers.
+func ExtractAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+			ctx = ContextWithAuth(ctx, strings.TrimPrefix(auth, "Bearer "))
+		}
+		if tenant := r.Header.Get("X-Codex-Tenant"); tenant != "" {
+			ctx = ContextWithTenant(ctx, tenant)
+		}
+		if user := r.Header.Get("X-Codex-On-Behalf-Of"); user != "" {
+			ctx = ContextWithOnBehalfOf(ctx, user)
+		}
+		if id := r.Header.Get("X-Request-ID"); id != "" {
+			ctx = ContextWithRequestID(ctx, id)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
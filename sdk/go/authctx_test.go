@@ -0,0 +1,81 @@
+package codex
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestContextAuthOverridesClientKey(t *testing.T) {
+	var gotAuth, gotTenant string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotTenant = r.Header.Get("X-Codex-Tenant")
+		w.Write([]byte(`{}`))
+	}), WithAPIKey("client-key"), WithTenant("default-tenant"))
+
+	ctx := ContextWithTenant(ContextWithAuth(context.Background(), "user-token"), "acme")
+	if _, err := c.ListCollections(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if gotAuth != "Bearer user-token" || gotTenant != "acme" {
+		t.Fatalf("context identity not propagated: %q %q", gotAuth, gotTenant)
+	}
+
+	if _, err := c.ListCollections(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if gotAuth != "Bearer client-key" || gotTenant != "default-tenant" {
+		t.Fatalf("client defaults not used: %q %q", gotAuth, gotTenant)
+	}
+}
+
+func TestOnBehalfOfHeader(t *testing.T) {
+	var got string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("X-Codex-On-Behalf-Of")
+		w.Write([]byte(`{}`))
+	}), WithOnBehalfOf("user-1"))
+
+	if _, err := c.ListCollections(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got != "user-1" {
+		t.Fatalf("on-behalf-of = %q", got)
+	}
+
+	ctx := ContextWithOnBehalfOf(context.Background(), "user-2")
+	if _, err := c.ListCollections(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got != "user-2" {
+		t.Fatalf("context on-behalf-of not propagated: %q", got)
+	}
+}
+
+func TestExtractAuthMiddleware(t *testing.T) {
+	var ctx context.Context
+	h := ExtractAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer abc")
+	req.Header.Set("X-Codex-Tenant", "acme")
+	req.Header.Set("X-Codex-On-Behalf-Of", "user-9")
+	req.Header.Set("X-Request-ID", "req_upstream")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	if token, ok := AuthFromContext(ctx); !ok || token != "abc" {
+		t.Fatalf("auth: %q %v", token, ok)
+	}
+	if tenant, ok := TenantFromContext(ctx); !ok || tenant != "acme" {
+		t.Fatalf("tenant: %q %v", tenant, ok)
+	}
+	if user, ok := OnBehalfOfFromContext(ctx); !ok || user != "user-9" {
+		t.Fatalf("on-behalf-of: %q %v", user, ok)
+	}
+	if id, ok := RequestIDFromContext(ctx); !ok || id != "req_upstream" {
+		t.Fatalf("request id: %q %v", id, ok)
+	}
+}
@@ -0,0 +1,119 @@
+package codex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ItemError is one failed item within a batch operation.
+type ItemError struct {
+	// Index of the item in the submitted batch.
+	Index int
+	// ID of the record, for operations keyed by ID (upserts).
+	ID string
+	// Err is the cause, an *APIError when the server reported it.
+	Err error
+	// Retryable marks failures worth resubmitting (rate limits,
+	// transient server errors) as opposed to permanent ones (validation).
+	Retryable bool
+}
+
+// BatchError aggregates the per-item failures of a batch operation whose
+// other items succeeded. Callers can resubmit just the retryable subset:
+//
+//	var be *codex.BatchError
+//	if errors.As(err, &be) {
+//		retry := be.RetryableIndexes()
+//		...
+//	}
+type BatchError struct {
+	// Op names the operation: "embed", "upsert", or "query".
+	Op    string
+	Items []ItemError
+}
+
+func (e *BatchError) Error() string {
+	retryable := len(e.RetryableIndexes())
+	return fmt.Sprintf("codex: %s: %d items failed (%d retryable)", e.Op, len(e.Items), retryable)
+}
+
+// Unwrap exposes the item causes to errors.Is and errors.As.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Items))
+	for i, item := range e.Items {
+		errs[i] = item.Err
+	}
+	return errs
+}
+
+// RetryableIndexes returns the batch indexes of items that failed
+// transiently, ready to slice out of the original input for a retry.
+func (e *BatchError) RetryableIndexes() []int {
+	var out []int
+	for _, item := range e.Items {
+		if item.Retryable {
+			out = append(out, item.Index)
+		}
+	}
+	return out
+}
+
+// wireItemFailure is how batch endpoints report individual failures.
+type wireItemFailure struct {
+	Index   int    `json:"index"`
+	ID      string `json:"id,omitempty"`
+	Status  int    `json:"status,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// batchError converts wire failures into a *BatchError, or nil when the
+// whole batch succeeded.
+func batchError(op string, failures []wireItemFailure) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	be := &BatchError{Op: op, Items: make([]ItemError, len(failures))}
+	for i, f := range failures {
+		cause := &APIError{StatusCode: f.Status, Code: f.Code, Message: f.Message}
+		be.Items[i] = ItemError{Index: f.Index, ID: f.ID, Err: cause, Retryable: cause.Temporary()}
+	}
+	return be
+}
+
+// BatchQuery is one query within a QueryBatch call.
+type BatchQuery struct {
+	Vector []float32 `json:"vector"`
+	TopK   int       `json:"top_k"`
+	Filter Filter    `json:"filter,omitempty"`
+}
+
+type queryBatchRequest struct {
+	Queries []BatchQuery `json:"queries"`
+}
+
+type queryBatchWire struct {
+	Results  [][]Match         `json:"results"`
+	Failures []wireItemFailure `json:"failures,omitempty"`
+}
+
+// QueryBatch runs several similarity queries against collection in one
+// request, returning one match list per query in order. Queries that
+// fail individually leave a nil slot and are reported together in a
+// *BatchError alongside the successful results.
+func (c *Client) QueryBatch(ctx context.Context, collection string, queries []BatchQuery) ([][]Match, error) {
+	if len(queries) == 0 {
+		return nil, nil
+	}
+	path := "/v1/collections/" + url.PathEscape(c.collection(collection)) + "/query-batch"
+	var resp queryBatchWire
+	if err := c.do(ctx, http.MethodPost, path, queryBatchRequest{Queries: queries}, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Results) != len(queries) {
+		return nil, fmt.Errorf("codex: query batch returned %d results for %d queries", len(resp.Results), len(queries))
+	}
+	return resp.Results, batchError("query", resp.Failures)
+}
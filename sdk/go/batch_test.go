@@ -0,0 +1,74 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestUpsertBatchPartialFailures(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"failures": []wireItemFailure{
+				{Index: 1, ID: "b", Status: 429, Code: "rate_limited", Message: "slow down"},
+				{Index: 2, ID: "c", Status: 400, Code: "invalid", Message: "bad vector"},
+			},
+		})
+	}))
+	err := c.UpsertBatch(context.Background(), "kb", []VectorRecord{
+		{ID: "a"}, {ID: "b"}, {ID: "c"},
+	})
+	var be *BatchError
+	if !errors.As(err, &be) {
+		t.Fatalf("expected *BatchError, got %v", err)
+	}
+	if be.Op != "upsert" || len(be.Items) != 2 {
+		t.Fatalf("bad batch error: %+v", be)
+	}
+	if !be.Items[0].Retryable || be.Items[1].Retryable {
+		t.Fatalf("retryable flags wrong: %+v", be.Items)
+	}
+	if got := be.RetryableIndexes(); len(got) != 1 || got[0] != 1 {
+		t.Fatalf("retryable indexes: %v", got)
+	}
+	if !IsRateLimited(err) {
+		t.Fatal("unwrapping should surface the rate-limit cause")
+	}
+}
+
+func TestUpsertBatchEmptyBodyStillSucceeds(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	if err := c.UpsertBatch(context.Background(), "kb", []VectorRecord{{ID: "a"}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueryBatch(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req queryBatchRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Queries) != 2 {
+			t.Errorf("got %d queries", len(req.Queries))
+		}
+		json.NewEncoder(w).Encode(queryBatchWire{
+			Results: [][]Match{
+				{{VectorRecord: VectorRecord{ID: "a"}}},
+				nil,
+			},
+			Failures: []wireItemFailure{{Index: 1, Status: 503, Message: "shard down"}},
+		})
+	}))
+	results, err := c.QueryBatch(context.Background(), "kb", []BatchQuery{
+		{Vector: []float32{1}, TopK: 1},
+		{Vector: []float32{2}, TopK: 1},
+	})
+	if len(results) != 2 || results[0][0].ID != "a" || results[1] != nil {
+		t.Fatalf("bad results: %+v", results)
+	}
+	var be *BatchError
+	if !errors.As(err, &be) || be.Op != "query" || !be.Items[0].Retryable {
+		t.Fatalf("expected retryable query failure, got %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+// Package bench drives configurable load mixes against a codex-extended
+// server to measure client and deployment throughput.
+package bench
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// Mix sets the relative weight of each operation in the generated load.
+// Weights are proportions, not percentages; {Chat: 1, Query: 3} issues
+// three queries per chat call.
+type Mix struct {
+	Chat  int
+	Embed int
+	Query int
+}
+
+func (m Mix) total() int { return m.Chat + m.Embed + m.Query }
+
+// Options configures a load run.
+type Options struct {
+	// Concurrency is the number of parallel workers. Defaults to 8.
+	Concurrency int
+	// Duration is how long to generate load. Defaults to 30s.
+	Duration time.Duration
+	// Mix selects the operation blend. Defaults to an even mix.
+	Mix Mix
+	// Collection is the collection queried by query operations.
+	Collection string
+	// Dimensions is the width of random query vectors. Defaults to 768.
+	Dimensions int
+	// Prompt is the chat prompt sent by chat operations.
+	Prompt string
+}
+
+// OpReport summarizes one operation type within a run.
+type OpReport struct {
+	Requests int
+	Errors   int
+	P50      time.Duration
+	P90      time.Duration
+	P99      time.Duration
+	Max      time.Duration
+}
+
+// Report summarizes a completed run.
+type Report struct {
+	Elapsed    time.Duration
+	Requests   int
+	Errors     int
+	Throughput float64 // requests per second
+	Ops        map[string]OpReport
+}
+
+// Run generates load against client until opts.Duration elapses or ctx is
+// cancelled, and returns the aggregated report.
+func Run(ctx context.Context, client codex.Codex, opts Options) (*Report, error) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 8
+	}
+	if opts.Duration <= 0 {
+		opts.Duration = 30 * time.Second
+	}
+	if opts.Mix.total() == 0 {
+		opts.Mix = Mix{Chat: 1, Embed: 1, Query: 1}
+	}
+	if opts.Dimensions <= 0 {
+		opts.Dimensions = 768
+	}
+	if opts.Prompt == "" {
+		opts.Prompt = "Summarize the purpose of a load test in one sentence."
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Duration)
+	defer cancel()
+
+	var mu sync.Mutex
+	samples := map[string][]time.Duration{}
+	errCounts := map[string]int{}
+
+	started := time.Now()
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Concurrency; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(seed))
+			for ctx.Err() == nil {
+				op := pickOp(rng, opts.Mix)
+				opStart := time.Now()
+				err := runOp(ctx, client, op, rng, opts)
+				elapsed := time.Since(opStart)
+				if ctx.Err() != nil && err != nil {
+					return // don't count the cancelled tail request
+				}
+				mu.Lock()
+				samples[op] = append(samples[op], elapsed)
+				if err != nil {
+					errCounts[op]++
+				}
+				mu.Unlock()
+			}
+		}(int64(w) + time.Now().UnixNano())
+	}
+	wg.Wait()
+
+	report := &Report{Elapsed: time.Since(started), Ops: map[string]OpReport{}}
+	for op, durs := range samples {
+		report.Requests += len(durs)
+		report.Errors += errCounts[op]
+		report.Ops[op] = summarize(durs, errCounts[op])
+	}
+	if report.Elapsed > 0 {
+		report.Throughput = float64(report.Requests) / report.Elapsed.Seconds()
+	}
+	return report, nil
+}
+
+func pickOp(rng *rand.Rand, mix Mix) string {
+	n := rng.Intn(mix.total())
+	if n < mix.Chat {
+		return "chat"
+	}
+	if n < mix.Chat+mix.Embed {
+		return "embed"
+	}
+	return "query"
+}
+
+func runOp(ctx context.Context, client codex.Codex, op string, rng *rand.Rand, opts Options) error {
+	switch op {
+	case "chat":
+		_, err := client.Chat(ctx, []codex.Message{{Role: codex.RoleUser, Content: opts.Prompt}})
+		return err
+	case "embed":
+		_, err := client.Embed(ctx, fmt.Sprintf("benchmark input %d", rng.Int63()))
+		return err
+	default:
+		vec := make([]float32, opts.Dimensions)
+		for i := range vec {
+			vec[i] = rng.Float32()
+		}
+		_, err := client.Query(ctx, opts.Collection, vec, 10, nil)
+		return err
+	}
+}
+
+func summarize(durs []time.Duration, errs int) OpReport {
+	sort.Slice(durs, func(i, j int) bool { return durs[i] < durs[j] })
+	rep := OpReport{Requests: len(durs), Errors: errs}
+	if len(durs) == 0 {
+		return rep
+	}
+	rep.P50 = percentile(durs, 0.50)
+	rep.P90 = percentile(durs, 0.90)
+	rep.P99 = percentile(durs, 0.99)
+	rep.Max = durs[len(durs)-1]
+	return rep
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	idx := int(p * float64(len(sorted)-1))
+	return sorted[idx]
+}
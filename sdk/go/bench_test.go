@@ -0,0 +1,55 @@
+package codex
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// benchRecords builds an upsert payload around 4 MB of vector data.
+func benchRecords() []VectorRecord {
+	recs := make([]VectorRecord, 1024)
+	for i := range recs {
+		vec := make([]float32, 1024)
+		for j := range vec {
+			vec[j] = float32(i*j) / 1000
+		}
+		recs[i] = VectorRecord{
+			ID:       fmt.Sprintf("rec-%d", i),
+			Vector:   vec,
+			Document: "benchmark document body",
+		}
+	}
+	return recs
+}
+
+func benchUpsert(b *testing.B, opts ...Option) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		io.WriteString(w, `{}`)
+	}))
+	defer srv.Close()
+	c, err := New(append([]Option{WithBaseURL(srv.URL)}, opts...)...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	recs := benchRecords()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := c.UpsertBatch(context.Background(), "bench", recs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUpsertBatch(b *testing.B) {
+	benchUpsert(b)
+}
+
+func BenchmarkUpsertBatchBufferPool(b *testing.B) {
+	benchUpsert(b, WithBufferPool(&BufferPool{}))
+}
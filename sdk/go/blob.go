@@ -0,0 +1,92 @@
+package codex
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// BlobInfo describes a document in the server's content-addressable
+// blob store.
+type BlobInfo struct {
+	// Hash is the blob's address, "sha256:<hex>".
+	Hash string `json:"hash"`
+	Size int64  `json:"size"`
+	// Metadata is whatever was attached at upload time.
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// BlobHashKey is the metadata key chunks use to reference the blob
+// holding their full original document.
+const BlobHashKey = "blob_hash"
+
+// PutDocument stores a full original document in the server's blob
+// store under its content hash and returns the blob's info. Chunks
+// derived from the document should carry the returned hash under
+// BlobHashKey so answers can link back to the original. Uploading the
+// same content twice is a cheap no-op server-side.
+func (c *Client) PutDocument(ctx context.Context, r io.Reader, meta map[string]any) (*BlobInfo, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("codex: read document: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	hash := "sha256:" + hex.EncodeToString(sum[:])
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.cfg.baseURL+"/v1/blobs/"+url.PathEscape(hash), bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("codex: build request: %w", err)
+	}
+	c.setHeaders(req)
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if len(meta) > 0 {
+		js, err := json.Marshal(meta)
+		if err != nil {
+			return nil, fmt.Errorf("codex: encode blob metadata: %w", err)
+		}
+		req.Header.Set("X-Codex-Blob-Meta", string(js))
+	}
+	resp, err := c.httpc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("codex: PUT /v1/blobs (request %s): %w", hash, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, errorFromResponse(resp)
+	}
+	io.Copy(io.Discard, resp.Body)
+	return &BlobInfo{Hash: hash, Size: int64(len(data)), Metadata: meta}, nil
+}
+
+// GetDocument streams the blob stored under hash. The caller must close
+// the reader. The returned info carries the metadata attached at upload
+// time.
+func (c *Client) GetDocument(ctx context.Context, hash string) (io.ReadCloser, *BlobInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.baseURL+"/v1/blobs/"+url.PathEscape(hash), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("codex: build request: %w", err)
+	}
+	c.setHeaders(req)
+	resp, err := c.httpc.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("codex: GET /v1/blobs/%s: %w", hash, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		return nil, nil, errorFromResponse(resp)
+	}
+	info := &BlobInfo{Hash: hash, Size: resp.ContentLength}
+	if raw := resp.Header.Get("X-Codex-Blob-Meta"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &info.Metadata); err != nil {
+			resp.Body.Close()
+			return nil, nil, fmt.Errorf("codex: decode blob metadata: %w", err)
+		}
+	}
+	return resp.Body, info, nil
+}
@@ -0,0 +1,70 @@
+package codex
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPutDocumentAddressesByContent(t *testing.T) {
+	content := "the full original document"
+	sum := sha256.Sum256([]byte(content))
+	wantHash := "sha256:" + hex.EncodeToString(sum[:])
+
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/v1/blobs/"+wantHash {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != content {
+			t.Errorf("body = %q", body)
+		}
+		if !strings.Contains(r.Header.Get("X-Codex-Blob-Meta"), `"source"`) {
+			t.Errorf("meta header = %q", r.Header.Get("X-Codex-Blob-Meta"))
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	info, err := c.PutDocument(context.Background(), strings.NewReader(content), map[string]any{"source": "wiki"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Hash != wantHash || info.Size != int64(len(content)) {
+		t.Fatalf("info: %+v", info)
+	}
+}
+
+func TestGetDocument(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/blobs/sha256:abc" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		w.Header().Set("X-Codex-Blob-Meta", `{"source":"wiki"}`)
+		io.WriteString(w, "blob body")
+	}))
+	rc, info, err := c.GetDocument(context.Background(), "sha256:abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	body, _ := io.ReadAll(rc)
+	if string(body) != "blob body" {
+		t.Fatalf("body = %q", body)
+	}
+	if info.Metadata["source"] != "wiki" {
+		t.Fatalf("info: %+v", info)
+	}
+}
+
+func TestGetDocumentNotFound(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no such blob", http.StatusNotFound)
+	}))
+	_, _, err := c.GetDocument(context.Background(), "sha256:missing")
+	if !IsNotFound(err) {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+// Package budget enforces per-key token budgets client-side. A Budget
+// wraps a client, attributes usage to a key (user, team, tenant), and
+// rejects or downgrades requests that would blow the key's limit before
+// they reach the server.
+package budget
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// ErrExceeded matches (with errors.Is) every budget rejection.
+var ErrExceeded = errors.New("budget: token budget exceeded")
+
+// ExceededError reports a request rejected for exceeding a budget.
+type ExceededError struct {
+	Key       string
+	Limit     int
+	Used      int
+	Estimated int
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("budget: key %q would exceed its %d-token budget (%d used, ~%d requested)",
+		e.Key, e.Limit, e.Used, e.Estimated)
+}
+
+func (e *ExceededError) Is(target error) bool { return target == ErrExceeded }
+
+// Store accumulates token usage per key. Implementations must be safe
+// for concurrent use; back it with your datastore to share budgets
+// across processes.
+type Store interface {
+	// Add records tokens against key and returns the new total.
+	Add(ctx context.Context, key string, tokens int) (int, error)
+	// Total returns the tokens recorded against key so far.
+	Total(ctx context.Context, key string) (int, error)
+}
+
+// MemStore is an in-process Store, suitable for single-instance
+// services and tests.
+type MemStore struct {
+	mu     sync.Mutex
+	totals map[string]int
+}
+
+func (s *MemStore) Add(_ context.Context, key string, tokens int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.totals == nil {
+		s.totals = map[string]int{}
+	}
+	s.totals[key] += tokens
+	return s.totals[key], nil
+}
+
+func (s *MemStore) Total(_ context.Context, key string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.totals[key], nil
+}
+
+// Limit is a per-key policy. A zero Tokens means unlimited. If
+// DowngradeTier is set, requests that would exceed the limit are sent on
+// that (cheaper) tier instead of being rejected.
+type Limit struct {
+	Tokens        int
+	DowngradeTier string
+}
+
+// Budget wraps a client with token budget enforcement. Usage reported
+// by the server is recorded against the request's key; requests whose
+// estimated prompt would exceed the key's limit are rejected with an
+// ExceededError (or downgraded, per the limit).
+type Budget struct {
+	codex.Codex
+	Store Store
+	// Limits maps keys to their policies; Default applies to keys
+	// without an entry.
+	Limits  map[string]Limit
+	Default Limit
+	// Key derives the budget key from the call context. Defaults to the
+	// context tenant (see codex.ContextWithTenant), else "default".
+	Key func(context.Context) string
+}
+
+var _ codex.Codex = (*Budget)(nil)
+
+func (b *Budget) key(ctx context.Context) string {
+	if b.Key != nil {
+		return b.Key(ctx)
+	}
+	if tenant, ok := codex.TenantFromContext(ctx); ok {
+		return tenant
+	}
+	return "default"
+}
+
+func (b *Budget) limit(key string) Limit {
+	if l, ok := b.Limits[key]; ok {
+		return l
+	}
+	return b.Default
+}
+
+// check admits or rejects an estimated spend, returning a downgrade
+// tier when the policy prefers that over rejection.
+func (b *Budget) check(ctx context.Context, key string, estimated int) (string, error) {
+	limit := b.limit(key)
+	if limit.Tokens <= 0 {
+		return "", nil
+	}
+	used, err := b.Store.Total(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	if used+estimated <= limit.Tokens {
+		return "", nil
+	}
+	if limit.DowngradeTier != "" {
+		return limit.DowngradeTier, nil
+	}
+	return "", &ExceededError{Key: key, Limit: limit.Tokens, Used: used, Estimated: estimated}
+}
+
+func (b *Budget) record(ctx context.Context, key string, usage codex.Usage) {
+	if usage.TotalTokens > 0 {
+		b.Store.Add(ctx, key, usage.TotalTokens) //nolint:errcheck // best-effort accounting
+	}
+}
+
+func (b *Budget) ChatCompletion(ctx context.Context, messages []codex.Message, opts ...codex.ChatOption) (*codex.ChatResponse, error) {
+	key := b.key(ctx)
+	downgrade, err := b.check(ctx, key, codex.EstimateMessageTokens(messages))
+	if err != nil {
+		return nil, err
+	}
+	if downgrade != "" {
+		opts = append(opts, codex.WithTier(downgrade))
+	}
+	resp, err := b.Codex.ChatCompletion(ctx, messages, opts...)
+	if resp != nil {
+		b.record(ctx, key, resp.Usage)
+	}
+	return resp, err
+}
+
+func (b *Budget) Chat(ctx context.Context, messages []codex.Message, opts ...codex.ChatOption) (string, error) {
+	resp, err := b.ChatCompletion(ctx, messages, opts...)
+	if resp == nil {
+		return "", err
+	}
+	return resp.Reply, err
+}
+
+func (b *Budget) RAGAnswer(ctx context.Context, question string, opts ...codex.RAGOption) (*codex.Result, error) {
+	key := b.key(ctx)
+	if _, err := b.check(ctx, key, codex.EstimateTokens(question)); err != nil {
+		return nil, err
+	}
+	result, err := b.Codex.RAGAnswer(ctx, question, opts...)
+	if result != nil {
+		b.record(ctx, key, result.Usage)
+	}
+	return result, err
+}
+
+func (b *Budget) Embed(ctx context.Context, text string) ([]float32, error) {
+	key := b.key(ctx)
+	estimated := codex.EstimateTokens(text)
+	if _, err := b.check(ctx, key, estimated); err != nil {
+		return nil, err
+	}
+	vec, err := b.Codex.Embed(ctx, text)
+	if err == nil {
+		b.record(ctx, key, codex.Usage{TotalTokens: estimated})
+	}
+	return vec, err
+}
+
+func (b *Budget) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	key := b.key(ctx)
+	var estimated int
+	for _, t := range texts {
+		estimated += codex.EstimateTokens(t)
+	}
+	if _, err := b.check(ctx, key, estimated); err != nil {
+		return nil, err
+	}
+	vecs, err := b.Codex.EmbedBatch(ctx, texts)
+	if err == nil {
+		b.record(ctx, key, codex.Usage{TotalTokens: estimated})
+	}
+	return vecs, err
+}
@@ -0,0 +1,101 @@
+package budget
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// stubCodex answers every chat with a fixed usage; unused methods panic
+// via the embedded nil interface.
+type stubCodex struct {
+	codex.Codex
+	usage   codex.Usage
+	gotTier string
+	calls   int
+}
+
+func (s *stubCodex) ChatCompletion(ctx context.Context, messages []codex.Message, opts ...codex.ChatOption) (*codex.ChatResponse, error) {
+	s.calls++
+	var o codex.ChatOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	s.gotTier = o.Tier
+	return &codex.ChatResponse{Reply: "ok", Usage: s.usage}, nil
+}
+
+func TestBudgetRecordsAndRejects(t *testing.T) {
+	stub := &stubCodex{usage: codex.Usage{TotalTokens: 98}}
+	b := &Budget{
+		Codex:   stub,
+		Store:   &MemStore{},
+		Default: Limit{Tokens: 100},
+	}
+	ctx := context.Background()
+	msgs := []codex.Message{{Role: codex.RoleUser, Content: "hi"}}
+
+	if _, err := b.ChatCompletion(ctx, msgs, nil...); err != nil {
+		t.Fatal(err)
+	}
+	// 98 of 100 tokens spent; the next request cannot fit.
+	_, err := b.ChatCompletion(ctx, msgs)
+	if !errors.Is(err, ErrExceeded) {
+		t.Fatalf("expected ErrExceeded, got %v", err)
+	}
+	var ee *ExceededError
+	if !errors.As(err, &ee) || ee.Used != 98 || ee.Limit != 100 {
+		t.Fatalf("bad error detail: %+v", ee)
+	}
+	if stub.calls != 1 {
+		t.Fatalf("rejected request reached the server (%d calls)", stub.calls)
+	}
+}
+
+func TestBudgetDowngradesInsteadOfRejecting(t *testing.T) {
+	stub := &stubCodex{usage: codex.Usage{TotalTokens: 98}}
+	b := &Budget{
+		Codex:   stub,
+		Store:   &MemStore{},
+		Default: Limit{Tokens: 100, DowngradeTier: "cheap"},
+	}
+	ctx := context.Background()
+	msgs := []codex.Message{{Role: codex.RoleUser, Content: "hi"}}
+
+	if _, err := b.ChatCompletion(ctx, msgs); err != nil {
+		t.Fatal(err)
+	}
+	if stub.gotTier != "" {
+		t.Fatalf("first request should keep its tier, got %q", stub.gotTier)
+	}
+	if _, err := b.ChatCompletion(ctx, msgs); err != nil {
+		t.Fatal(err)
+	}
+	if stub.gotTier != "cheap" {
+		t.Fatalf("over-budget request should downgrade, got tier %q", stub.gotTier)
+	}
+}
+
+func TestBudgetKeysAreIndependent(t *testing.T) {
+	stub := &stubCodex{usage: codex.Usage{TotalTokens: 100}}
+	b := &Budget{
+		Codex:   stub,
+		Store:   &MemStore{},
+		Default: Limit{Tokens: 100},
+	}
+	msgs := []codex.Message{{Role: codex.RoleUser, Content: "hi"}}
+
+	alice := codex.ContextWithTenant(context.Background(), "alice")
+	bob := codex.ContextWithTenant(context.Background(), "bob")
+	if _, err := b.ChatCompletion(alice, msgs); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.ChatCompletion(alice, msgs); !errors.Is(err, ErrExceeded) {
+		t.Fatalf("alice should be out of budget, got %v", err)
+	}
+	if _, err := b.ChatCompletion(bob, msgs); err != nil {
+		t.Fatalf("bob has their own budget: %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package codex
+
+import (
+	"bytes"
+	"sync"
+)
+
+// BufferPool recycles request-encoding buffers between calls. Large
+// upsert payloads otherwise allocate a fresh multi-megabyte buffer per
+// request; with a pool the buffer is reused across calls that share the
+// pool. Buffers above maxPooledBuffer are dropped rather than pooled so
+// one huge payload doesn't pin memory forever.
+type BufferPool struct {
+	pool sync.Pool
+}
+
+const maxPooledBuffer = 16 << 20
+
+// Get returns an empty buffer from the pool.
+func (p *BufferPool) Get() *bytes.Buffer {
+	if b, ok := p.pool.Get().(*bytes.Buffer); ok {
+		b.Reset()
+		return b
+	}
+	return &bytes.Buffer{}
+}
+
+// Put returns a buffer to the pool.
+func (p *BufferPool) Put(b *bytes.Buffer) {
+	if b == nil || b.Cap() > maxPooledBuffer {
+		return
+	}
+	p.pool.Put(b)
+}
+
+// WithBufferPool makes the client encode request bodies into pooled
+// buffers instead of allocating per call.
+func WithBufferPool(p *BufferPool) Option {
+	return func(c *clientConfig) { c.bufferPool = p }
+}
@@ -0,0 +1,164 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ChatOptions tunes a single chat request. The zero value uses server
+// defaults and the client's default tier.
+type ChatOptions struct {
+	Tier        string  `json:"tier,omitempty"`
+	Temperature float64 `json:"temperature,omitempty"`
+	MaxTokens   int     `json:"max_tokens,omitempty"`
+	// Stop lists sequences that end the generation when emitted.
+	Stop []string `json:"stop,omitempty"`
+}
+
+// ChatOption mutates ChatOptions.
+type ChatOption func(*ChatOptions)
+
+// WithTier selects the model tier for this call.
+func WithTier(tier string) ChatOption {
+	return func(o *ChatOptions) { o.Tier = tier }
+}
+
+// WithTemperature sets the sampling temperature.
+func WithTemperature(t float64) ChatOption {
+	return func(o *ChatOptions) { o.Temperature = t }
+}
+
+// WithMaxTokens caps the completion length.
+func WithMaxTokens(n int) ChatOption {
+	return func(o *ChatOptions) { o.MaxTokens = n }
+}
+
+// WithStop sets stop sequences that end the generation when emitted.
+func WithStop(sequences ...string) ChatOption {
+	return func(o *ChatOptions) { o.Stop = sequences }
+}
+
+type chatRequest struct {
+	Messages []Message `json:"messages"`
+	ChatOptions
+}
+
+// ChatResponse is the full result of a chat request. FinishReason is
+// "stop" for natural completion, "length" when the generation hit the
+// token limit (i.e. the reply is truncated), or "content_filter" when the
+// server cut it off; Model and Tier identify what actually served the
+// request.
+type ChatResponse struct {
+	Reply        string `json:"reply"`
+	FinishReason string `json:"finish_reason,omitempty"`
+	Model        string `json:"model,omitempty"`
+	Tier         string `json:"tier,omitempty"`
+	Usage        Usage  `json:"usage"`
+	RequestID    string `json:"request_id,omitempty"`
+	// Extra holds response fields this client version does not know
+	// about, preserved in lenient decoding mode.
+	Extra json.RawMessage `json:"-"`
+}
+
+func (c *Client) chatOptions(opts []ChatOption) ChatOptions {
+	o := ChatOptions{Tier: c.cfg.defaultTier}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// resolveTier replaces TierAuto with the latency router's pick when a
+// budget is set, else the complexity router's. Without either the value
+// passes through for the server to resolve.
+func (c *Client) resolveTier(ctx context.Context, o *ChatOptions, messages []Message) {
+	if o.Tier != TierAuto {
+		return
+	}
+	if c.cfg.latencyBudget > 0 {
+		if tier := c.routeByLatency(ctx); tier != "" {
+			o.Tier = tier
+			return
+		}
+	}
+	if c.cfg.router != nil {
+		o.Tier = c.cfg.router.Route(messages)
+	}
+}
+
+// ChatCompletion sends a conversation to the server and returns the full
+// response, including finish reason, serving model, and usage. When the
+// generation stops for length, the truncated response is returned along
+// with a *TruncatedError (matching ErrTruncated), so truncation is never
+// silent but the partial output remains usable.
+func (c *Client) ChatCompletion(ctx context.Context, messages []Message, opts ...ChatOption) (*ChatResponse, error) {
+	req := chatRequest{Messages: messages, ChatOptions: c.chatOptions(opts)}
+	c.resolveTier(ctx, &req.ChatOptions, messages)
+	var resp ChatResponse
+	start := time.Now()
+	if err := c.do(ctx, http.MethodPost, "/v1/chat", req, &resp); err != nil {
+		return nil, err
+	}
+	if c.cfg.latencyBudget > 0 {
+		tier := resp.Tier
+		if tier == "" {
+			tier = req.Tier
+		}
+		c.latency.observe(tier, time.Since(start))
+	}
+	if resp.FinishReason == "length" {
+		return &resp, &TruncatedError{Response: &resp}
+	}
+	return &resp, nil
+}
+
+// ChatComplete behaves like ChatCompletion but, when the generation is
+// truncated for length, automatically issues continuation requests —
+// resending the conversation with the partial reply as an assistant
+// prefix — and stitches the pieces together. maxContinuations bounds the
+// number of follow-up requests; if the budget runs out while still
+// truncated, the stitched response is returned with a *TruncatedError.
+func (c *Client) ChatComplete(ctx context.Context, messages []Message, maxContinuations int, opts ...ChatOption) (*ChatResponse, error) {
+	resp, err := c.ChatCompletion(ctx, messages, opts...)
+	if err != nil && !errors.Is(err, ErrTruncated) {
+		return resp, err
+	}
+	stitched := *resp
+	for i := 0; i < maxContinuations && stitched.FinishReason == "length"; i++ {
+		cont := make([]Message, 0, len(messages)+1)
+		cont = append(cont, messages...)
+		cont = append(cont, Message{Role: RoleAssistant, Content: stitched.Reply})
+		next, err := c.ChatCompletion(ctx, cont, opts...)
+		if err != nil && !errors.Is(err, ErrTruncated) {
+			return &stitched, err
+		}
+		stitched.Reply += next.Reply
+		stitched.FinishReason = next.FinishReason
+		stitched.Model = next.Model
+		stitched.Tier = next.Tier
+		stitched.Usage.PromptTokens += next.Usage.PromptTokens
+		stitched.Usage.CompletionTokens += next.Usage.CompletionTokens
+		stitched.Usage.TotalTokens += next.Usage.TotalTokens
+	}
+	if stitched.FinishReason == "length" {
+		return &stitched, &TruncatedError{Response: &stitched}
+	}
+	return &stitched, nil
+}
+
+// Chat is a thin wrapper over ChatCompletion returning just the reply
+// text. Callers that need to detect truncation or see which model served
+// the request should use ChatCompletion.
+func (c *Client) Chat(ctx context.Context, messages []Message, opts ...ChatOption) (string, error) {
+	resp, err := c.ChatCompletion(ctx, messages, opts...)
+	if err != nil {
+		if resp != nil {
+			return resp.Reply, err // truncated: partial text plus the error
+		}
+		return "", err
+	}
+	return resp.Reply, nil
+}
@@ -0,0 +1,108 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestChatCompletionTruncationReturnsPartialWithError(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ChatResponse{
+			Reply:        "the answer begins",
+			FinishReason: "length",
+			Usage:        Usage{CompletionTokens: 128},
+		})
+	}))
+
+	resp, err := c.ChatCompletion(context.Background(), []Message{{Role: RoleUser, Content: "hi"}})
+	if !errors.Is(err, ErrTruncated) {
+		t.Fatalf("err = %v, want ErrTruncated", err)
+	}
+	var te *TruncatedError
+	if !errors.As(err, &te) || te.Response.Usage.CompletionTokens != 128 {
+		t.Fatalf("truncated error: %+v", te)
+	}
+	// The partial output must remain usable alongside the error.
+	if resp == nil || resp.Reply != "the answer begins" {
+		t.Fatalf("resp = %+v", resp)
+	}
+
+	reply, err := c.Chat(context.Background(), []Message{{Role: RoleUser, Content: "hi"}})
+	if !errors.Is(err, ErrTruncated) || reply != "the answer begins" {
+		t.Fatalf("Chat: reply %q, err %v", reply, err)
+	}
+}
+
+func TestChatCompleteStitchesContinuations(t *testing.T) {
+	var calls int
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req chatRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		calls++
+		switch calls {
+		case 1:
+			if len(req.Messages) != 1 {
+				t.Errorf("first call messages: %+v", req.Messages)
+			}
+			json.NewEncoder(w).Encode(ChatResponse{
+				Reply: "one ", FinishReason: "length",
+				Usage: Usage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15},
+			})
+		case 2, 3:
+			// Continuations resend the conversation with the stitched
+			// partial reply as an assistant prefix.
+			last := req.Messages[len(req.Messages)-1]
+			if len(req.Messages) != 2 || last.Role != RoleAssistant {
+				t.Errorf("call %d messages: %+v", calls, req.Messages)
+			}
+			if calls == 2 && last.Content != "one " {
+				t.Errorf("call 2 prefix: %q", last.Content)
+			}
+			if calls == 3 && last.Content != "one two " {
+				t.Errorf("call 3 prefix: %q", last.Content)
+			}
+			reply, reason := "two ", "length"
+			if calls == 3 {
+				reply, reason = "three", "stop"
+			}
+			json.NewEncoder(w).Encode(ChatResponse{
+				Reply: reply, FinishReason: reason,
+				Usage: Usage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15},
+			})
+		default:
+			t.Errorf("unexpected call %d", calls)
+		}
+	}))
+
+	resp, err := c.ChatComplete(context.Background(), []Message{{Role: RoleUser, Content: "go"}}, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Reply != "one two three" || resp.FinishReason != "stop" {
+		t.Fatalf("stitched: %+v", resp)
+	}
+	if resp.Usage.TotalTokens != 45 || resp.Usage.CompletionTokens != 15 {
+		t.Fatalf("usage not accumulated: %+v", resp.Usage)
+	}
+	if calls != 3 {
+		t.Fatalf("calls = %d", calls)
+	}
+}
+
+func TestChatCompleteBudgetExhaustedStaysTruncated(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ChatResponse{Reply: "more ", FinishReason: "length"})
+	}))
+
+	resp, err := c.ChatComplete(context.Background(), []Message{{Role: RoleUser, Content: "go"}}, 2)
+	if !errors.Is(err, ErrTruncated) {
+		t.Fatalf("err = %v, want ErrTruncated", err)
+	}
+	// Initial call plus two continuations, all stitched.
+	if resp.Reply != "more more more " {
+		t.Fatalf("stitched: %q", resp.Reply)
+	}
+}
@@ -0,0 +1,111 @@
+package codex
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SourceURL returns the reference's source URL, falling back to the
+// "url" or "source_url" metadata keys when the server did not set the
+// field directly.
+func (r *Reference) SourceURL() string {
+	if r.URL != "" {
+		return r.URL
+	}
+	for _, key := range []string{"url", "source_url"} {
+		if u, ok := r.Metadata[key].(string); ok && u != "" {
+			return u
+		}
+	}
+	return ""
+}
+
+// SourceTitle returns the reference's title, falling back to the
+// "title" metadata key and finally the record ID.
+func (r *Reference) SourceTitle() string {
+	if r.Title != "" {
+		return r.Title
+	}
+	if t, ok := r.Metadata["title"].(string); ok && t != "" {
+		return t
+	}
+	return r.ID
+}
+
+// CitationStyle selects how RenderCitations formats sources.
+type CitationStyle string
+
+const (
+	// CiteBrackets appends a numbered "Sources:" list matching the [n]
+	// markers in the answer.
+	CiteBrackets CitationStyle = "brackets"
+	// CiteFootnotes rewrites [n] markers as [^n] and appends footnote
+	// definitions.
+	CiteFootnotes CitationStyle = "footnotes"
+	// CiteMarkdown turns each [n] marker into a markdown link to its
+	// source and appends the numbered list.
+	CiteMarkdown CitationStyle = "markdown"
+)
+
+var citationMarker = regexp.MustCompile(`\[(\d+)\]`)
+
+// RenderCitations formats the answer's [n] citation markers and its
+// references in the given style, ready for direct display. References
+// never cited in the answer text are still listed, so no source is
+// hidden. The zero style renders brackets.
+func (r *Result) RenderCitations(style CitationStyle) string {
+	if len(r.References) == 0 {
+		return r.Answer
+	}
+	answer := r.Answer
+	switch style {
+	case CiteFootnotes:
+		answer = citationMarker.ReplaceAllString(answer, "[^$1]")
+	case CiteMarkdown:
+		answer = citationMarker.ReplaceAllStringFunc(answer, func(marker string) string {
+			var n int
+			fmt.Sscanf(marker, "[%d]", &n)
+			if n < 1 || n > len(r.References) {
+				return marker
+			}
+			if u := r.References[n-1].SourceURL(); u != "" {
+				return fmt.Sprintf("[%s](%s)", marker, u)
+			}
+			return marker
+		})
+	}
+
+	var b strings.Builder
+	b.WriteString(answer)
+	b.WriteString("\n\n")
+	if style == CiteFootnotes {
+		for i := range r.References {
+			b.WriteString(fmt.Sprintf("[^%d]: %s\n", i+1, citationLine(&r.References[i])))
+		}
+	} else {
+		b.WriteString("Sources:\n")
+		for i := range r.References {
+			b.WriteString(fmt.Sprintf("%d. %s\n", i+1, citationLine(&r.References[i])))
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// citationLine renders one reference as "Title (url), lines 3-9" with
+// the parts it actually has.
+func citationLine(ref *Reference) string {
+	line := ref.SourceTitle()
+	if u := ref.SourceURL(); u != "" {
+		line += " (" + u + ")"
+	}
+	switch {
+	case ref.StartLine > 0 && ref.EndLine > ref.StartLine:
+		line += fmt.Sprintf(", lines %d-%d", ref.StartLine, ref.EndLine)
+	case ref.StartLine > 0:
+		line += fmt.Sprintf(", line %d", ref.StartLine)
+	case ref.EndByte > ref.StartByte:
+		line += fmt.Sprintf(", bytes %d-%d", ref.StartByte, ref.EndByte)
+	}
+	return line
+}
@@ -0,0 +1,47 @@
+package codex
+
+import (
+	"strings"
+	"testing"
+)
+
+func citedResult() *Result {
+	return &Result{
+		Answer: "Go ships generics [1] and iterators arrived later [2].",
+		References: []Reference{
+			{ID: "rel#0", Title: "Go 1.18 Release Notes", URL: "https://go.dev/doc/go1.18", StartLine: 10, EndLine: 14},
+			{ID: "blog#3", Metadata: map[string]any{"title": "Range Over Func", "source_url": "https://go.dev/blog/range"}},
+		},
+	}
+}
+
+func TestRenderCitationsBrackets(t *testing.T) {
+	out := citedResult().RenderCitations(CiteBrackets)
+	if !strings.Contains(out, "Sources:\n1. Go 1.18 Release Notes (https://go.dev/doc/go1.18), lines 10-14") {
+		t.Fatalf("output: %s", out)
+	}
+	if !strings.Contains(out, "2. Range Over Func (https://go.dev/blog/range)") {
+		t.Fatalf("metadata fallback missing: %s", out)
+	}
+}
+
+func TestRenderCitationsMarkdown(t *testing.T) {
+	out := citedResult().RenderCitations(CiteMarkdown)
+	if !strings.Contains(out, "[[1]](https://go.dev/doc/go1.18)") {
+		t.Fatalf("output: %s", out)
+	}
+}
+
+func TestRenderCitationsFootnotes(t *testing.T) {
+	out := citedResult().RenderCitations(CiteFootnotes)
+	if !strings.Contains(out, "generics [^1]") || !strings.Contains(out, "[^2]: Range Over Func") {
+		t.Fatalf("output: %s", out)
+	}
+}
+
+func TestRenderCitationsNoReferences(t *testing.T) {
+	r := &Result{Answer: "plain answer [1]"}
+	if out := r.RenderCitations(CiteMarkdown); out != "plain answer [1]" {
+		t.Fatalf("output: %s", out)
+	}
+}
@@ -0,0 +1,317 @@
+package codex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Version is the client library version, reported in the User-Agent header.
+const Version = "0.1.0"
+
+// Client talks to a codex-extended server. It is safe for concurrent use:
+// all configuration is immutable after construction, and the only
+// internal mutability (schema and tier caches) is synchronized. Derive a
+// differently-configured client with Clone rather than mutating shared
+// state.
+type Client struct {
+	cfg   clientConfig
+	httpc *http.Client
+
+	// schemas maps collection name to MetadataSchema. Seeded from
+	// WithMetadataSchema and extended by CreateCollectionWithSchema.
+	schemas sync.Map
+	// tiers caches ListTiers results for cost estimation and routing.
+	tiers tierCache
+	// qos holds lower-priority requests back while higher-priority ones
+	// share the client; see Priority.
+	qos qosGate
+	// latency feeds WithLatencyBudget routing from observed chat calls.
+	latency latencyTracker
+	// skew estimates the server/client clock offset from Date headers.
+	skew skewTracker
+}
+
+// New builds a Client from the given options.
+func New(opts ...Option) (*Client, error) {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return newClient(cfg)
+}
+
+func newClient(cfg clientConfig) (*Client, error) {
+	cfg.baseURL = strings.TrimRight(cfg.baseURL, "/")
+	if cfg.baseURL == "" {
+		return nil, errors.New("codex: base URL must not be empty")
+	}
+	if cfg.apiKey == "" && len(cfg.credentialHelper) > 0 {
+		key, err := resolveCredentialHelper(&cfg)
+		if err != nil {
+			return nil, err
+		}
+		cfg.apiKey = key
+	}
+	httpc := cfg.httpClient
+	if httpc == nil {
+		timeout := cfg.timeout
+		if timeout == 0 {
+			timeout = 120 * time.Second
+		}
+		httpc = &http.Client{Timeout: timeout}
+	}
+	c := &Client{cfg: cfg, httpc: httpc}
+	if c.cfg.apiKey == "" && c.cfg.storedSession {
+		c.pickUpStoredSession()
+	}
+	for name, schema := range cfg.schemas {
+		c.schemas.Store(name, schema)
+	}
+	return c, nil
+}
+
+// Clone returns a client derived from c with opts layered on top of its
+// configuration — e.g. the same server and transport but a different
+// default collection per request handler. The clone shares no mutable
+// state with c beyond deliberately shared resources (buffer and vector
+// pools, the HTTP client), so both remain safe for concurrent use.
+func (c *Client) Clone(opts ...Option) (*Client, error) {
+	cfg := c.cfg
+	if cfg.schemas != nil {
+		schemas := make(map[string]MetadataSchema, len(cfg.schemas))
+		for name, schema := range cfg.schemas {
+			schemas[name] = schema
+		}
+		cfg.schemas = schemas
+	}
+	cfg.encryptedFields = append([]string(nil), cfg.encryptedFields...)
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	clone, err := newClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	// Carry over schemas registered after construction too.
+	c.schemas.Range(func(name, schema any) bool {
+		if _, ok := clone.schemas.Load(name); !ok {
+			clone.schemas.Store(name, schema)
+		}
+		return true
+	})
+	return clone, nil
+}
+
+// BaseURL returns the server base URL the client was configured with.
+func (c *Client) BaseURL() string { return c.cfg.baseURL }
+
+// do issues one JSON request against path, retrying transient failures,
+// and decodes a 2xx response body into out (which may be nil).
+func (c *Client) do(ctx context.Context, method, path string, in, out any) error {
+	return c.doDecode(ctx, method, path, in, func(dec *json.Decoder) error {
+		if out == nil {
+			return nil
+		}
+		return c.decodeBody(dec, out)
+	})
+}
+
+// doDecode is do for callers that need streaming access to the response:
+// decode is called with a decoder positioned at the start of a 2xx body.
+// A decode error on a retryable status never occurs (errors are mapped
+// before decode runs), so decode is invoked at most once per attempt.
+func (c *Client) doDecode(ctx context.Context, method, path string, in any, decode func(*json.Decoder) error) error {
+	var body []byte
+	if in != nil {
+		if bp := c.cfg.bufferPool; bp != nil {
+			buf := bp.Get()
+			defer bp.Put(buf)
+			if err := json.NewEncoder(buf).Encode(in); err != nil {
+				return fmt.Errorf("codex: encode request: %w", err)
+			}
+			body = buf.Bytes()
+		} else {
+			var err error
+			body, err = json.Marshal(in)
+			if err != nil {
+				return fmt.Errorf("codex: encode request: %w", err)
+			}
+		}
+	}
+
+	if a := c.cfg.admission; a != nil {
+		release, err := a.acquire(ctx, path)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+
+	rank := c.requestPriority(ctx).rank()
+	if err := c.qos.acquire(ctx, rank); err != nil {
+		return err
+	}
+	defer c.qos.release(rank)
+
+	reqID := requestID(ctx)
+	var lastErr error
+	var attempts []AttemptInfo
+	// fail returns the final error, wrapped with the per-attempt audit
+	// trail when retries actually happened.
+	fail := func() error {
+		err := lastErr
+		if len(attempts) > 1 {
+			err = &RetryError{Attempts: attempts, Err: err}
+		}
+		return c.blameClockSkew(err)
+	}
+	for attempt := 0; ; attempt++ {
+		start := time.Now()
+		resp, err := c.send(ctx, method, path, body, reqID)
+		if err == nil {
+			c.skew.record(resp.Header.Get("Date"), time.Now())
+			err = decodeResponse(resp, decode)
+			if err == nil {
+				return nil
+			}
+		}
+		lastErr = err
+		attempts = append(attempts, attemptInfo(method, path, time.Since(start), err))
+		if attempt >= c.cfg.maxRetries || !retryable(err) {
+			return fail()
+		}
+		if err := sleepBackoff(ctx, c.cfg.retryBaseDelay, attempt); err != nil {
+			return fail()
+		}
+	}
+}
+
+// send issues a single HTTP request without retries.
+func (c *Client) send(ctx context.Context, method, path string, body []byte, reqID string) (*http.Response, error) {
+	var rd io.Reader
+	if body != nil {
+		rd = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.cfg.baseURL+path, rd)
+	if err != nil {
+		return nil, fmt.Errorf("codex: build request: %w", err)
+	}
+	c.setHeaders(req)
+	req.Header.Set("X-Request-ID", reqID)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	// Tell the server how long it has, so it can abort work it cannot
+	// finish before the caller gives up anyway.
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining > 0 {
+			req.Header.Set("X-Request-Deadline", strconv.FormatInt(remaining.Milliseconds(), 10)+"ms")
+		}
+	}
+	resp, err := c.httpc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("codex: %s %s (request %s): %w", method, path, reqID, err)
+	}
+	return resp, nil
+}
+
+func (c *Client) setHeaders(req *http.Request) {
+	req.Header.Set("User-Agent", c.cfg.userAgent)
+	// Context-carried identity (see ContextWithAuth) overrides the
+	// client-wide configuration.
+	if token, ok := AuthFromContext(req.Context()); ok {
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if c.cfg.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.apiKey)
+	}
+	if tenant, ok := TenantFromContext(req.Context()); ok {
+		req.Header.Set("X-Codex-Tenant", tenant)
+	} else if c.cfg.tenant != "" {
+		req.Header.Set("X-Codex-Tenant", c.cfg.tenant)
+	}
+	if user, ok := OnBehalfOfFromContext(req.Context()); ok {
+		req.Header.Set("X-Codex-On-Behalf-Of", user)
+	} else if c.cfg.onBehalfOf != "" {
+		req.Header.Set("X-Codex-On-Behalf-Of", c.cfg.onBehalfOf)
+	}
+	if p := c.requestPriority(req.Context()); p != "" {
+		req.Header.Set("X-Codex-Priority", string(p))
+	}
+}
+
+// decodeResponse consumes resp, converting non-2xx statuses into *APIError
+// and otherwise handing a streaming decoder to decode.
+func decodeResponse(resp *http.Response, decode func(*json.Decoder) error) error {
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errorFromResponse(resp)
+	}
+	if err := decode(json.NewDecoder(resp.Body)); err != nil {
+		return fmt.Errorf("codex: decode response: %w", err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+func errorFromResponse(resp *http.Response) error {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	var wire struct {
+		Error *APIError `json:"error"`
+	}
+	if err := json.Unmarshal(data, &wire); err == nil && wire.Error != nil {
+		apiErr.Code = wire.Error.Code
+		apiErr.Message = wire.Error.Message
+		apiErr.RequestID = wire.Error.RequestID
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = strings.TrimSpace(string(data))
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+	// Prefer the server's correlation ID; it appears in server logs.
+	if id := resp.Header.Get("X-Request-ID"); id != "" {
+		apiErr.RequestID = id
+	}
+	return apiErr
+}
+
+// retryable reports whether err is worth retrying: transient server errors
+// and transport-level failures, but not context cancellation.
+func retryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var ae *APIError
+	if errors.As(err, &ae) {
+		return ae.Temporary()
+	}
+	// Network-level errors (connection refused/reset, etc.) are retryable.
+	return true
+}
+
+// sleepBackoff waits for an exponentially growing, jittered delay or until
+// ctx is done.
+func sleepBackoff(ctx context.Context, base time.Duration, attempt int) error {
+	d := base << uint(attempt)
+	d += time.Duration(rand.Int63n(int64(d / 2)))
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
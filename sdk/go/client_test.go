@@ -0,0 +1,60 @@
+package codex
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.Handler, opts ...Option) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c, err := New(append([]Option{WithBaseURL(srv.URL), WithMaxRetries(0)}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestQueryWithVectorPool(t *testing.T) {
+	const body = `{"matches":[
+		{"id":"a","vector":[1,2,3],"document":"doc a","score":0.9,"metadata":{"k":"v"}},
+		{"id":"b","vector":[4,5,6],"score":0.5}
+	]}`
+	pool := &VectorPool{}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, body)
+	}), WithVectorPool(pool))
+
+	for round := 0; round < 2; round++ {
+		matches, err := c.Query(context.Background(), "kb", []float32{1}, 2, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(matches) != 2 {
+			t.Fatalf("got %d matches", len(matches))
+		}
+		m := matches[0]
+		if m.ID != "a" || m.Score != 0.9 || m.Document != "doc a" || m.Metadata["k"] != "v" {
+			t.Fatalf("bad match: %+v", m)
+		}
+		if len(m.Vector) != 3 || m.Vector[2] != 3 {
+			t.Fatalf("bad vector: %v", m.Vector)
+		}
+		pool.Put(matches[0].Vector, matches[1].Vector)
+	}
+}
+
+func TestAPIErrorDecoding(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"error":{"code":"not_found","message":"no such collection"}}`)
+	}))
+	_, err := c.Query(context.Background(), "missing", []float32{1}, 1, nil)
+	if !IsNotFound(err) {
+		t.Fatalf("expected not-found error, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package codex
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// clockSkewTolerance is how far the client clock may drift from the
+// server's before authentication failures are blamed on skew. Signed
+// requests and expiring tokens typically allow a window of this order.
+const clockSkewTolerance = 30 * time.Second
+
+// ErrClockSkew matches errors returned when an authentication failure
+// coincides with a large client/server clock offset:
+// errors.Is(err, codex.ErrClockSkew). Edge devices drift; without this
+// the resulting signature and token failures are opaque 401s.
+var ErrClockSkew = errors.New("codex: client clock out of sync with server")
+
+// ClockSkewError wraps an authentication failure that is likely caused
+// by clock drift, carrying the offset measured from the server's Date
+// headers. It unwraps to the underlying error, so errors.Is and
+// errors.As checks keep working.
+type ClockSkewError struct {
+	// Skew is the server clock minus the client clock.
+	Skew time.Duration
+	Err  error
+}
+
+func (e *ClockSkewError) Error() string {
+	return fmt.Sprintf("codex: client clock is %s off from server (fix the clock or use NTP): %v", e.Skew, e.Err)
+}
+
+func (e *ClockSkewError) Unwrap() error { return e.Err }
+
+func (e *ClockSkewError) Is(target error) bool { return target == ErrClockSkew }
+
+// skewTracker estimates the server/client clock offset from response
+// Date headers. The estimate is only as precise as the header's
+// one-second granularity plus network latency, which is ample for
+// spotting the minutes-scale drift that breaks authentication.
+type skewTracker struct {
+	mu     sync.Mutex
+	offset time.Duration
+	known  bool
+}
+
+func (t *skewTracker) record(dateHeader string, now time.Time) {
+	if dateHeader == "" {
+		return
+	}
+	serverTime, err := http.ParseTime(dateHeader)
+	if err != nil {
+		return
+	}
+	t.mu.Lock()
+	t.offset = serverTime.Sub(now)
+	t.known = true
+	t.mu.Unlock()
+}
+
+func (t *skewTracker) skew() (time.Duration, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.offset, t.known
+}
+
+// ClockSkew returns the most recently measured server/client clock
+// offset (server minus client), and whether any response has provided
+// one yet.
+func (c *Client) ClockSkew() (time.Duration, bool) {
+	return c.skew.skew()
+}
+
+// Now returns the current time as the server sees it, compensating for
+// measured clock skew. Use it when evaluating server-issued expirations
+// (scoped tokens, sessions) on machines whose clocks drift.
+func (c *Client) Now() time.Time {
+	offset, _ := c.skew.skew()
+	return time.Now().Add(offset)
+}
+
+// blameClockSkew wraps err in a *ClockSkewError when it is an
+// authentication failure and the measured skew exceeds the tolerance —
+// the combination where drift is the likely culprit.
+func (c *Client) blameClockSkew(err error) error {
+	var ae *APIError
+	if !errors.As(err, &ae) || ae.StatusCode != http.StatusUnauthorized {
+		return err
+	}
+	offset, known := c.skew.skew()
+	if !known || offset.Abs() <= clockSkewTolerance {
+		return err
+	}
+	return &ClockSkewError{Skew: offset, Err: err}
+}
@@ -0,0 +1,79 @@
+package codex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClockSkewMeasuredFromDateHeader(t *testing.T) {
+	drift := -5 * time.Minute
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(drift).UTC().Format(http.TimeFormat))
+		w.Write([]byte(`{}`))
+	}))
+
+	if _, ok := c.ClockSkew(); ok {
+		t.Fatal("skew known before any response")
+	}
+	if _, err := c.ListCollections(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	skew, ok := c.ClockSkew()
+	if !ok {
+		t.Fatal("skew not measured")
+	}
+	if (skew - drift).Abs() > 2*time.Second {
+		t.Fatalf("skew = %s, want about %s", skew, drift)
+	}
+	if d := time.Until(c.Now()) - drift; d.Abs() > 2*time.Second {
+		t.Fatalf("Now() not compensated: off by %s", d)
+	}
+}
+
+func TestAuthFailureWithLargeSkewSurfacesErrClockSkew(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(10*time.Minute).UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":{"code":"signature_invalid","message":"bad signature"}}`))
+	}), WithAPIKey("k"))
+
+	_, err := c.ListCollections(context.Background())
+	if !errors.Is(err, ErrClockSkew) {
+		t.Fatalf("err = %v, want ErrClockSkew", err)
+	}
+	var cse *ClockSkewError
+	if !errors.As(err, &cse) || cse.Skew.Abs() < clockSkewTolerance {
+		t.Fatalf("skew detail: %+v", cse)
+	}
+	// The original API error stays reachable behind the diagnosis.
+	var ae *APIError
+	if !errors.As(err, &ae) || ae.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("unwrapped: %v", err)
+	}
+}
+
+func TestAuthFailureWithoutSkewStaysPlain(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}), WithAPIKey("k"))
+
+	_, err := c.ListCollections(context.Background())
+	if err == nil || errors.Is(err, ErrClockSkew) {
+		t.Fatalf("err = %v, want plain auth failure", err)
+	}
+}
+
+func TestNonAuthFailureNotBlamedOnSkew(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(10*time.Minute).UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+
+	_, err := c.ListCollections(context.Background())
+	if err == nil || errors.Is(err, ErrClockSkew) {
+		t.Fatalf("err = %v, want plain API error", err)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/hackall360/codex-extended/sdk/go/bench"
+)
+
+func init() {
+	register(&command{
+		name:    "bench",
+		summary: "drive a configurable load mix against the server",
+		run:     runBench,
+	})
+}
+
+func runBench(ctx context.Context, rf *rootFlags, args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	rf.register(fs)
+	concurrency := fs.Int("concurrency", 8, "parallel workers")
+	duration := fs.Duration("duration", 30*time.Second, "how long to run")
+	chatW := fs.Int("chat", 1, "chat weight in the mix")
+	embedW := fs.Int("embed", 1, "embed weight in the mix")
+	queryW := fs.Int("query", 1, "query weight in the mix")
+	dims := fs.Int("dims", 768, "query vector dimensions")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := rf.client()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "benchmarking %s for %s with %d workers...\n", rf.server, *duration, *concurrency)
+	report, err := bench.Run(ctx, client, bench.Options{
+		Concurrency: *concurrency,
+		Duration:    *duration,
+		Mix:         bench.Mix{Chat: *chatW, Embed: *embedW, Query: *queryW},
+		Collection:  rf.collection,
+		Dimensions:  *dims,
+	})
+	if err != nil {
+		return err
+	}
+
+	return rf.emit(report, func(w io.Writer) {
+		fmt.Fprintf(w, "elapsed      %s\n", report.Elapsed.Round(time.Millisecond))
+		fmt.Fprintf(w, "requests     %d (%.1f/s)\n", report.Requests, report.Throughput)
+		fmt.Fprintf(w, "errors       %d (%.2f%%)\n", report.Errors, errorRate(report.Errors, report.Requests))
+		ops := make([]string, 0, len(report.Ops))
+		for op := range report.Ops {
+			ops = append(ops, op)
+		}
+		sort.Strings(ops)
+		for _, op := range ops {
+			r := report.Ops[op]
+			fmt.Fprintf(w, "%-8s n=%-7d err=%-5d p50=%-10s p90=%-10s p99=%-10s max=%s\n",
+				op, r.Requests, r.Errors,
+				r.P50.Round(time.Millisecond), r.P90.Round(time.Millisecond),
+				r.P99.Round(time.Millisecond), r.Max.Round(time.Millisecond))
+		}
+	})
+}
+
+func errorRate(errs, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(errs) / float64(total)
+}
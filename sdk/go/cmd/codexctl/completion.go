@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+func init() {
+	register(&command{
+		name:    "completion",
+		summary: "print a shell completion script (bash, zsh, or fish)",
+		run:     runCompletion,
+	})
+}
+
+func commandNames() []string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func runCompletion(ctx context.Context, rf *rootFlags, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: codexctl completion <bash|zsh|fish>")
+	}
+	names := strings.Join(commandNames(), " ")
+	switch args[0] {
+	case "bash":
+		fmt.Fprintf(os.Stdout, `_codexctl() {
+    local cur="${COMP_WORDS[COMP_CWORD]}"
+    if [ "$COMP_CWORD" -eq 1 ]; then
+        COMPREPLY=( $(compgen -W "%s" -- "$cur") )
+    fi
+}
+complete -F _codexctl codexctl
+`, names)
+	case "zsh":
+		fmt.Fprintf(os.Stdout, `#compdef codexctl
+_codexctl() {
+    if (( CURRENT == 2 )); then
+        compadd %s
+    fi
+}
+_codexctl
+`, names)
+	case "fish":
+		for _, name := range commandNames() {
+			fmt.Fprintf(os.Stdout, "complete -c codexctl -n '__fish_use_subcommand' -a %s -d '%s'\n",
+				name, commands[name].summary)
+		}
+	default:
+		return fmt.Errorf("unknown shell %q (want bash, zsh, or fish)", args[0])
+	}
+	return nil
+}
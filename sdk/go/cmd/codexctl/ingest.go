@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/hackall360/codex-extended/sdk/go/ingest"
+)
+
+func init() {
+	register(&command{
+		name:    "ingest",
+		summary: "ingest a directory, once or on a schedule",
+		run:     runIngest,
+	})
+}
+
+func runIngest(ctx context.Context, rf *rootFlags, args []string) error {
+	fs := flag.NewFlagSet("ingest", flag.ExitOnError)
+	rf.register(fs)
+	dir := fs.String("dir", "", "directory to ingest (required)")
+	schedule := fs.String("schedule", "", `cron spec for repeated syncs, e.g. "0 3 * * *"`)
+	interval := fs.Duration("interval", 0, "fixed interval for repeated syncs (alternative to -schedule)")
+	lock := fs.String("lock", "", "lock file preventing overlapping scheduled runs")
+	status := fs.String("status", "", "file to write JSON sync status to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dir == "" {
+		return fmt.Errorf("-dir is required")
+	}
+	if rf.collection == "" {
+		return fmt.Errorf("-collection is required")
+	}
+
+	c, err := rf.client()
+	if err != nil {
+		return err
+	}
+	ing := ingest.NewIngestor(c, c, rf.collection)
+	src := &ingest.DirSource{Root: *dir}
+
+	if *schedule == "" && *interval == 0 {
+		stats, err := ing.IngestSource(ctx, src)
+		if err != nil {
+			return err
+		}
+		return rf.emit(stats, func(w io.Writer) {
+			fmt.Fprintf(w, "ingested %d documents (%d chunks)\n", stats.Documents, stats.Chunks)
+		})
+	}
+
+	sched := &ingest.Scheduler{
+		Ingestor:   ing,
+		Interval:   *interval,
+		LockFile:   *lock,
+		StatusFile: *status,
+	}
+	if *schedule != "" {
+		spec, err := ingest.ParseCron(*schedule)
+		if err != nil {
+			return err
+		}
+		sched.Schedule = spec
+	}
+	sched.Add(src)
+	fmt.Fprintf(os.Stderr, "syncing %s every %s...\n", *dir, scheduleDesc(*schedule, *interval))
+	err = sched.Run(ctx)
+	if err == context.Canceled {
+		return nil
+	}
+	return err
+}
+
+func scheduleDesc(schedule string, interval time.Duration) string {
+	if schedule != "" {
+		return fmt.Sprintf("%q", schedule)
+	}
+	return interval.String()
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+func init() {
+	register(&command{
+		name:    "login",
+		summary: "sign in with a device code and store the session",
+		run:     runLogin,
+	})
+	register(&command{
+		name:    "logout",
+		summary: "discard the stored session",
+		run:     runLogout,
+	})
+}
+
+func runLogin(ctx context.Context, rf *rootFlags, args []string) error {
+	fs := flag.NewFlagSet("login", flag.ExitOnError)
+	rf.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	c, err := codex.New(codex.WithBaseURL(rf.server))
+	if err != nil {
+		return err
+	}
+	auth, err := c.StartDeviceLogin(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "To sign in, visit\n\n    %s\n\nand enter the code %s\n\n", auth.VerificationURI, auth.UserCode)
+	fmt.Fprintln(os.Stderr, "waiting for approval...")
+	sess, err := c.WaitDeviceLogin(ctx, auth)
+	if err != nil {
+		return err
+	}
+	path, err := codex.DefaultSessionPath()
+	if err != nil {
+		return err
+	}
+	if err := codex.SaveSession(path, sess); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "logged in to %s; session stored in %s\n", rf.server, path)
+	return nil
+}
+
+func runLogout(ctx context.Context, rf *rootFlags, args []string) error {
+	path, err := codex.DefaultSessionPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintln(os.Stderr, "not logged in")
+			return nil
+		}
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "removed %s\n", path)
+	return nil
+}
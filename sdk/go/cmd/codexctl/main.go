@@ -0,0 +1,94 @@
+// Command codexctl is the operations CLI for a codex-extended server:
+// load testing, migrations, ingestion runs, and day-to-day inspection.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// rootFlags are shared by every subcommand.
+type rootFlags struct {
+	server     string
+	apiKey     string
+	tier       string
+	collection string
+	output     string
+}
+
+func (rf *rootFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&rf.server, "server", envOr("CODEX_SERVER", "http://localhost:8080"), "server base URL")
+	fs.StringVar(&rf.apiKey, "api-key", os.Getenv("CODEX_API_KEY"), "API key (defaults to $CODEX_API_KEY)")
+	fs.StringVar(&rf.tier, "tier", os.Getenv("CODEX_TIER"), "default model tier")
+	fs.StringVar(&rf.collection, "collection", os.Getenv("CODEX_COLLECTION"), "default collection")
+	fs.StringVar(&rf.output, "output", "table", "output format: json, yaml, or table")
+}
+
+func (rf *rootFlags) client(extra ...codex.Option) (*codex.Client, error) {
+	opts := []codex.Option{
+		codex.WithStoredSession(),
+		codex.WithBaseURL(rf.server),
+		codex.WithAPIKey(rf.apiKey),
+		codex.WithDefaultTier(rf.tier),
+		codex.WithDefaultCollection(rf.collection),
+	}
+	return codex.New(append(opts, extra...)...)
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// command is one codexctl subcommand.
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, rf *rootFlags, args []string) error
+}
+
+var commands = map[string]*command{}
+
+func register(cmd *command) { commands[cmd.name] = cmd }
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: codexctl <command> [flags]")
+	fmt.Fprintln(os.Stderr, "\ncommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", name, commands[name].summary)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "codexctl: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	rf := &rootFlags{}
+	if err := cmd.run(ctx, rf, os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "codexctl %s: %v\n", cmd.name, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+func init() {
+	register(&command{
+		name:    "migrate",
+		summary: "copy a collection from one server to another",
+		run:     runMigrate,
+	})
+}
+
+func runMigrate(ctx context.Context, rf *rootFlags, args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	rf.register(fs)
+	from := fs.String("from", "", "source server base URL (defaults to -server)")
+	to := fs.String("to", "", "destination server base URL (required)")
+	batch := fs.Int("batch", 100, "records per batch")
+	throttle := fs.Duration("throttle", 0, "pause between batches")
+	reembed := fs.Bool("reembed", false, "re-embed documents on the destination's model")
+	verify := fs.Int("verify", 10, "sample size for post-migration verification")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *to == "" {
+		return fmt.Errorf("-to is required")
+	}
+	if *from == "" {
+		*from = rf.server
+	}
+	if rf.collection == "" {
+		return fmt.Errorf("-collection is required")
+	}
+
+	src, err := rf.client(codex.WithBaseURL(*from))
+	if err != nil {
+		return err
+	}
+	dst, err := rf.client(codex.WithBaseURL(*to))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "migrating %q from %s to %s...\n", rf.collection, *from, *to)
+	started := time.Now()
+	stats, err := codex.Migrate(ctx, src, dst, codex.MigrateOptions{
+		Collection:   rf.collection,
+		Reembed:      *reembed,
+		BatchSize:    *batch,
+		Throttle:     *throttle,
+		VerifySample: *verify,
+		Progress: codex.ProgressFunc(func(p codex.Progress) {
+			fmt.Fprintf(os.Stderr, "\r%d records migrated", p.Done)
+		}),
+	})
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return err
+	}
+	return rf.emit(stats, func(w io.Writer) {
+		fmt.Fprintf(w, "migrated %d records in %s (verified %d, mismatched %d)\n",
+			stats.Records, time.Since(started).Round(time.Millisecond), stats.Verified, stats.Mismatched)
+	})
+}
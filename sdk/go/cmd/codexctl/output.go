@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// emit writes v to stdout in the format chosen by -output. The table
+// function renders the human-readable default; json and yaml are derived
+// from v's JSON encoding so every subcommand scripts the same way.
+func (rf *rootFlags) emit(v any, table func(w io.Writer)) error {
+	switch rf.output {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case "yaml":
+		generic, err := toGeneric(v)
+		if err != nil {
+			return err
+		}
+		writeYAML(os.Stdout, generic, 0)
+		return nil
+	case "", "table":
+		table(os.Stdout)
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q (want json, yaml, or table)", rf.output)
+	}
+}
+
+// toGeneric round-trips v through JSON into maps and slices.
+func toGeneric(v any) (any, error) {
+	js, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := json.Unmarshal(js, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// writeYAML prints the generic value as YAML. It covers what JSON can
+// produce — maps, slices, and scalars — which is all emit ever feeds it.
+func writeYAML(w io.Writer, v any, indent int) {
+	pad := strings.Repeat("  ", indent)
+	switch v := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if isScalar(v[k]) {
+				fmt.Fprintf(w, "%s%s: %s\n", pad, k, yamlScalar(v[k]))
+			} else {
+				fmt.Fprintf(w, "%s%s:\n", pad, k)
+				writeYAML(w, v[k], indent+1)
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if isScalar(item) {
+				fmt.Fprintf(w, "%s- %s\n", pad, yamlScalar(item))
+			} else {
+				fmt.Fprintf(w, "%s-\n", pad)
+				writeYAML(w, item, indent+1)
+			}
+		}
+	default:
+		fmt.Fprintf(w, "%s%s\n", pad, yamlScalar(v))
+	}
+}
+
+func isScalar(v any) bool {
+	switch v.(type) {
+	case map[string]any, []any:
+		return false
+	}
+	return true
+}
+
+func yamlScalar(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		if v == "" || strings.ContainsAny(v, ":#{}[]\n\"'") || v != strings.TrimSpace(v) {
+			js, _ := json.Marshal(v)
+			return string(js)
+		}
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
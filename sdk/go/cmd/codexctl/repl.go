@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+func init() {
+	register(&command{
+		name:    "repl",
+		summary: "interactive session with slash commands for vector operations",
+		run:     runRepl,
+	})
+}
+
+// replSession holds the state slash commands mutate between inputs.
+type replSession struct {
+	rf         *rootFlags
+	client     *codex.Client
+	collection string
+	tier       string
+}
+
+func runRepl(ctx context.Context, rf *rootFlags, args []string) error {
+	fs := flag.NewFlagSet("repl", flag.ExitOnError)
+	rf.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	s := &replSession{rf: rf, collection: rf.collection, tier: rf.tier}
+	if err := s.reconnect(); err != nil {
+		return err
+	}
+
+	fmt.Println("codexctl repl — /help for commands, /quit to leave")
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Printf("%s> ", s.collection)
+		if !scanner.Scan() {
+			fmt.Println()
+			return scanner.Err()
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if line == "/quit" || line == "/exit" {
+			return nil
+		}
+		if err := s.eval(ctx, line); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+		}
+	}
+}
+
+// reconnect rebuilds the client after session state changes.
+func (s *replSession) reconnect() error {
+	c, err := s.rf.client(
+		codex.WithDefaultCollection(s.collection),
+		codex.WithDefaultTier(s.tier),
+	)
+	if err != nil {
+		return err
+	}
+	s.client = c
+	return nil
+}
+
+func (s *replSession) eval(ctx context.Context, line string) error {
+	if !strings.HasPrefix(line, "/") {
+		// Bare text is a question for the current collection.
+		res, err := s.client.RAGAnswer(ctx, line)
+		if err != nil {
+			return err
+		}
+		fmt.Println(res.Answer)
+		return nil
+	}
+	cmd, rest, _ := strings.Cut(line[1:], " ")
+	rest = strings.TrimSpace(rest)
+	switch cmd {
+	case "help":
+		fmt.Print(`  /query <text> [k]          embed text and show the top k matches (default 5)
+  /upsert-file <path> [id]   embed a file's contents and upsert it
+  /stats                     show the current collection's stats
+  /switch-collection <name>  change the session collection
+  /tier <name>               change the session model tier
+  /quit                      leave the repl
+  anything else              ask the current collection a question
+`)
+		return nil
+	case "query":
+		return s.query(ctx, rest)
+	case "upsert-file":
+		return s.upsertFile(ctx, rest)
+	case "stats":
+		return s.stats(ctx)
+	case "switch-collection":
+		if rest == "" {
+			return fmt.Errorf("usage: /switch-collection <name>")
+		}
+		s.collection = rest
+		return s.reconnect()
+	case "tier":
+		if rest == "" {
+			return fmt.Errorf("usage: /tier <name>")
+		}
+		s.tier = rest
+		return s.reconnect()
+	default:
+		return fmt.Errorf("unknown command /%s (try /help)", cmd)
+	}
+}
+
+func (s *replSession) query(ctx context.Context, rest string) error {
+	if rest == "" {
+		return fmt.Errorf("usage: /query <text> [k]")
+	}
+	text := rest
+	topK := 5
+	if i := strings.LastIndexByte(rest, ' '); i > 0 {
+		if k, err := strconv.Atoi(rest[i+1:]); err == nil && k > 0 {
+			text, topK = strings.TrimSpace(rest[:i]), k
+		}
+	}
+	vec, err := s.client.EmbedQuery(ctx, text)
+	if err != nil {
+		return err
+	}
+	matches, err := s.client.Query(ctx, s.collection, vec, topK, nil)
+	if err != nil {
+		return err
+	}
+	if len(matches) == 0 {
+		fmt.Println("no matches")
+		return nil
+	}
+	for _, m := range matches {
+		doc := m.Document
+		if len(doc) > 80 {
+			doc = doc[:77] + "..."
+		}
+		fmt.Printf("  %.3f  %-20s %s\n", m.Score, m.ID, doc)
+	}
+	return nil
+}
+
+func (s *replSession) upsertFile(ctx context.Context, rest string) error {
+	if rest == "" {
+		return fmt.Errorf("usage: /upsert-file <path> [id]")
+	}
+	path, id, _ := strings.Cut(rest, " ")
+	id = strings.TrimSpace(id)
+	if id == "" {
+		id = filepath.Base(path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	vec, err := s.client.EmbedDocument(ctx, string(data))
+	if err != nil {
+		return err
+	}
+	err = s.client.Upsert(ctx, s.collection, codex.VectorRecord{
+		ID:       id,
+		Vector:   vec,
+		Document: string(data),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("upserted %q (%d bytes)\n", id, len(data))
+	return nil
+}
+
+func (s *replSession) stats(ctx context.Context) error {
+	cols, err := s.client.ListCollections(ctx)
+	if err != nil {
+		return err
+	}
+	for _, col := range cols {
+		marker := " "
+		if col.Name == s.collection {
+			marker = "*"
+		}
+		fmt.Printf("%s %-24s dims=%-6d count=%d\n", marker, col.Name, col.Dimensions, col.Count)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package codex
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type collectionsWire struct {
+	Collections []Collection `json:"collections"`
+}
+
+// ListCollections returns every collection on the server.
+func (c *Client) ListCollections(ctx context.Context) ([]Collection, error) {
+	var resp collectionsWire
+	if err := c.do(ctx, http.MethodGet, "/v1/collections", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Collections, nil
+}
+
+type createCollectionRequest struct {
+	Name       string         `json:"name"`
+	Dimensions int            `json:"dimensions"`
+	Schema     MetadataSchema `json:"schema,omitempty"`
+}
+
+// CreateCollection creates a collection with the given vector dimensions.
+func (c *Client) CreateCollection(ctx context.Context, name string, dimensions int) error {
+	req := createCollectionRequest{Name: name, Dimensions: dimensions}
+	return c.do(ctx, http.MethodPost, "/v1/collections", req, nil)
+}
+
+// CreateCollectionWithSchema creates a collection whose metadata fields
+// are declared up front. The schema is sent to the server (which may use
+// the indexed flags) and registered with this client, so subsequent
+// upserts into the collection are validated against it.
+func (c *Client) CreateCollectionWithSchema(ctx context.Context, name string, dimensions int, schema MetadataSchema) error {
+	req := createCollectionRequest{Name: name, Dimensions: dimensions, Schema: schema}
+	if err := c.do(ctx, http.MethodPost, "/v1/collections", req, nil); err != nil {
+		return err
+	}
+	c.schemas.Store(name, schema)
+	return nil
+}
+
+// DeleteCollection removes a collection and all its records.
+func (c *Client) DeleteCollection(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, "/v1/collections/"+url.PathEscape(name), nil, nil)
+}
+
+type tiersWire struct {
+	Tiers []Tier `json:"tiers"`
+}
+
+// ListTiers returns the model tiers the server offers.
+func (c *Client) ListTiers(ctx context.Context) ([]Tier, error) {
+	var resp tiersWire
+	if err := c.do(ctx, http.MethodGet, "/v1/tiers", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Tiers, nil
+}
+
+type modelsWire struct {
+	Models []Model `json:"models"`
+}
+
+// ListModels returns the concrete models behind the server's tiers.
+func (c *Client) ListModels(ctx context.Context) ([]Model, error) {
+	var resp modelsWire
+	if err := c.do(ctx, http.MethodGet, "/v1/models", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Models, nil
+}
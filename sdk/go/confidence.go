@@ -0,0 +1,44 @@
+package codex
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInsufficientContext matches errors returned when retrieval found
+// nothing relevant enough to answer from:
+// errors.Is(err, codex.ErrInsufficientContext).
+var ErrInsufficientContext = errors.New("codex: insufficient context to answer")
+
+// InsufficientContextError is returned alongside the low-confidence
+// result when the answer falls below the caller's confidence floor.
+// Result holds the server's response (including its references and
+// confidence) so apps can route the question to a human with context.
+type InsufficientContextError struct {
+	Result *Result
+}
+
+func (e *InsufficientContextError) Error() string {
+	return fmt.Sprintf("codex: insufficient context to answer (confidence %.2f)", e.Result.Confidence)
+}
+
+func (e *InsufficientContextError) Is(target error) bool { return target == ErrInsufficientContext }
+
+// WithMinConfidence sets a confidence floor on RAGAnswer: when the
+// server's answerability score falls below min, or retrieval returned
+// no references at all, the call returns an InsufficientContextError
+// alongside the result instead of a likely hallucination.
+func WithMinConfidence(min float64) RAGOption {
+	return func(o *RAGOptions) { o.MinConfidence = min }
+}
+
+// checkConfidence enforces the MinConfidence floor on a decoded result.
+func checkConfidence(res *Result, o RAGOptions) error {
+	if o.MinConfidence <= 0 {
+		return nil
+	}
+	if len(res.References) == 0 || res.Confidence < o.MinConfidence {
+		return &InsufficientContextError{Result: res}
+	}
+	return nil
+}
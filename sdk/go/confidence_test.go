@@ -0,0 +1,57 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func confidenceHandler(res Result) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(res)
+	})
+}
+
+func TestMinConfidenceRejectsWeakAnswers(t *testing.T) {
+	c := newTestClient(t, confidenceHandler(Result{
+		Answer:     "maybe",
+		Confidence: 0.2,
+		References: []Reference{{ID: "r"}},
+	}))
+	res, err := c.RAGAnswer(context.Background(), "q", WithMinConfidence(0.6))
+	if !errors.Is(err, ErrInsufficientContext) {
+		t.Fatalf("err = %v", err)
+	}
+	var ice *InsufficientContextError
+	if !errors.As(err, &ice) || ice.Result == nil || ice.Result.Confidence != 0.2 {
+		t.Fatalf("err = %#v", err)
+	}
+	if res == nil || res.Answer != "maybe" {
+		t.Fatalf("result not returned alongside error: %+v", res)
+	}
+}
+
+func TestMinConfidenceRejectsEmptyRetrieval(t *testing.T) {
+	c := newTestClient(t, confidenceHandler(Result{Answer: "guess", Confidence: 0.9}))
+	_, err := c.RAGAnswer(context.Background(), "q", WithMinConfidence(0.5))
+	if !errors.Is(err, ErrInsufficientContext) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestMinConfidencePassesStrongAnswers(t *testing.T) {
+	c := newTestClient(t, confidenceHandler(Result{
+		Answer:     "certain",
+		Confidence: 0.9,
+		References: []Reference{{ID: "r"}},
+	}))
+	res, err := c.RAGAnswer(context.Background(), "q", WithMinConfidence(0.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Confidence != 0.9 {
+		t.Fatalf("confidence = %v", res.Confidence)
+	}
+}
@@ -0,0 +1,209 @@
+package codex
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FromEnv builds a client from CODEX_* environment variables — the same
+// ones codexctl reads:
+//
+//	CODEX_SERVER       base URL
+//	CODEX_API_KEY      bearer token
+//	CODEX_TIER         default model tier
+//	CODEX_COLLECTION   default collection
+//	CODEX_TENANT       tenant identifier
+//	CODEX_MAX_RETRIES  retry count
+//	CODEX_TIMEOUT      per-request timeout (Go duration, e.g. "30s")
+//
+// Explicit opts take precedence over the environment. When neither sets
+// an API key, a login stored by `codexctl login` is picked up
+// automatically (see WithStoredSession).
+func FromEnv(opts ...Option) (*Client, error) {
+	env, err := envOptions()
+	if err != nil {
+		return nil, err
+	}
+	all := append([]Option{WithStoredSession()}, env...)
+	return New(append(all, opts...)...)
+}
+
+// FromConfig builds a client from a config file, the environment, and
+// explicit options, in ascending precedence: file values are overridden
+// by CODEX_* variables, which are overridden by opts. The file is a TOML
+// table of top-level defaults plus optional named profiles:
+//
+//	server      = "https://codex.internal:8080"
+//	api_key     = "sk-..."
+//	tier        = "fast"
+//	collection  = "kb"
+//	tenant      = "acme"
+//	max_retries = 5
+//	timeout     = "30s"
+//
+//	[profiles.staging]
+//	server = "https://codex-staging.internal:8080"
+//	tier   = "cheap"
+//
+// A profile selected with WithProfile (or CODEX_PROFILE) overlays the
+// top-level defaults key by key.
+func FromConfig(path string, opts ...Option) (*Client, error) {
+	sections, err := parseConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	merged := sections[""]
+	if profile := selectedProfile(opts); profile != "" {
+		overlay, ok := sections["profiles."+profile]
+		if !ok {
+			return nil, fmt.Errorf("codex: %s: no profile %q", path, profile)
+		}
+		merged = make(map[string]string, len(sections[""])+len(overlay))
+		for k, v := range sections[""] {
+			merged[k] = v
+		}
+		for k, v := range overlay {
+			merged[k] = v
+		}
+	}
+	file, err := sectionOptions(path, merged)
+	if err != nil {
+		return nil, err
+	}
+	env, err := envOptions()
+	if err != nil {
+		return nil, err
+	}
+	return New(append(append(file, env...), opts...)...)
+}
+
+// selectedProfile resolves which profile to load: WithProfile wins over
+// the CODEX_PROFILE variable; empty means top-level defaults only.
+func selectedProfile(opts []Option) string {
+	var probe clientConfig
+	for _, opt := range opts {
+		opt(&probe)
+	}
+	if probe.profile != "" {
+		return probe.profile
+	}
+	return os.Getenv("CODEX_PROFILE")
+}
+
+func envOptions() ([]Option, error) {
+	var opts []Option
+	if v := os.Getenv("CODEX_SERVER"); v != "" {
+		opts = append(opts, WithBaseURL(v))
+	}
+	if v := os.Getenv("CODEX_API_KEY"); v != "" {
+		opts = append(opts, WithAPIKey(v))
+	}
+	if v := os.Getenv("CODEX_TIER"); v != "" {
+		opts = append(opts, WithDefaultTier(v))
+	}
+	if v := os.Getenv("CODEX_COLLECTION"); v != "" {
+		opts = append(opts, WithDefaultCollection(v))
+	}
+	if v := os.Getenv("CODEX_TENANT"); v != "" {
+		opts = append(opts, WithTenant(v))
+	}
+	if v := os.Getenv("CODEX_MAX_RETRIES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("codex: CODEX_MAX_RETRIES: %w", err)
+		}
+		opts = append(opts, WithMaxRetries(n))
+	}
+	if v := os.Getenv("CODEX_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("codex: CODEX_TIMEOUT: %w", err)
+		}
+		opts = append(opts, WithTimeout(d))
+	}
+	return opts, nil
+}
+
+// sectionOptions converts one parsed config table into client options.
+func sectionOptions(path string, section map[string]string) ([]Option, error) {
+	var opts []Option
+	for key, value := range section {
+		switch key {
+		case "server":
+			opts = append(opts, WithBaseURL(value))
+		case "api_key":
+			opts = append(opts, WithAPIKey(value))
+		case "tier":
+			opts = append(opts, WithDefaultTier(value))
+		case "collection":
+			opts = append(opts, WithDefaultCollection(value))
+		case "tenant":
+			opts = append(opts, WithTenant(value))
+		case "max_retries":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("codex: %s: max_retries: %w", path, err)
+			}
+			opts = append(opts, WithMaxRetries(n))
+		case "timeout":
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("codex: %s: timeout: %w", path, err)
+			}
+			opts = append(opts, WithTimeout(d))
+		default:
+			return nil, fmt.Errorf("codex: %s: unknown config key %q", path, key)
+		}
+	}
+	return opts, nil
+}
+
+// parseConfig reads the TOML subset the loader understands: comments,
+// `key = value` pairs (values optionally quoted), and `[section]`
+// headers. Keys before the first header land in the "" section.
+func parseConfig(path string) (map[string]map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("codex: open config: %w", err)
+	}
+	defer f.Close()
+
+	sections := map[string]map[string]string{"": {}}
+	current := sections[""]
+	scanner := bufio.NewScanner(f)
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if _, ok := sections[name]; !ok {
+				sections[name] = map[string]string{}
+			}
+			current = sections[name]
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("codex: %s:%d: expected key = value", path, lineno)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("codex: %s:%d: %w", path, lineno, err)
+			}
+			value = unquoted
+		}
+		current[strings.TrimSpace(key)] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("codex: read config: %w", err)
+	}
+	return sections, nil
+}
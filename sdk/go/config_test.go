@@ -0,0 +1,102 @@
+package codex
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "codex.toml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFromConfig(t *testing.T) {
+	path := writeConfig(t, `
+# client settings
+server      = "https://codex.example.com/"
+api_key     = "sk-test"
+tier        = "fast"
+max_retries = 5
+timeout     = "45s"
+`)
+	c, err := FromConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.BaseURL() != "https://codex.example.com" {
+		t.Fatalf("base URL: %q", c.BaseURL())
+	}
+	if c.cfg.apiKey != "sk-test" || c.cfg.defaultTier != "fast" || c.cfg.maxRetries != 5 {
+		t.Fatalf("bad config: %+v", c.cfg)
+	}
+}
+
+func TestFromConfigPrecedence(t *testing.T) {
+	path := writeConfig(t, `
+server = "https://from-file"
+tier   = "file-tier"
+`)
+	t.Setenv("CODEX_TIER", "env-tier")
+	c, err := FromConfig(path, WithDefaultCollection("opt-collection"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.cfg.baseURL != "https://from-file" {
+		t.Fatalf("file value lost: %q", c.cfg.baseURL)
+	}
+	if c.cfg.defaultTier != "env-tier" {
+		t.Fatalf("env should override file: %q", c.cfg.defaultTier)
+	}
+	if c.cfg.defaultCollection != "opt-collection" {
+		t.Fatalf("option lost: %q", c.cfg.defaultCollection)
+	}
+}
+
+func TestFromConfigProfiles(t *testing.T) {
+	path := writeConfig(t, `
+server = "https://prod"
+tier   = "fast"
+tenant = "acme"
+
+[profiles.staging]
+server = "https://staging"
+tier   = "cheap"
+`)
+	c, err := FromConfig(path, WithProfile("staging"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.cfg.baseURL != "https://staging" || c.cfg.defaultTier != "cheap" {
+		t.Fatalf("profile overlay not applied: %+v", c.cfg)
+	}
+	if c.cfg.tenant != "acme" {
+		t.Fatalf("top-level default lost under profile: %q", c.cfg.tenant)
+	}
+	if _, err := FromConfig(path, WithProfile("nope")); err == nil {
+		t.Fatal("expected error for unknown profile")
+	}
+}
+
+func TestFromConfigRejectsUnknownKey(t *testing.T) {
+	path := writeConfig(t, "serverr = \"oops\"\n")
+	if _, err := FromConfig(path); err == nil {
+		t.Fatal("expected error for unknown key")
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("CODEX_SERVER", "https://from-env")
+	t.Setenv("CODEX_TIMEOUT", "10s")
+	c, err := FromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.cfg.baseURL != "https://from-env" || c.httpc.Timeout.Seconds() != 10 {
+		t.Fatalf("bad env config: %q %v", c.cfg.baseURL, c.httpc.Timeout)
+	}
+}
@@ -0,0 +1,86 @@
+package codex
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// CostEstimate is a price preview for a request, based on estimated
+// token counts and a tier's advertised per-million-token pricing.
+type CostEstimate struct {
+	Tier         string
+	InputTokens  int
+	OutputTokens int
+	InputUSD     float64
+	OutputUSD    float64
+}
+
+// TotalUSD is the combined input and output estimate.
+func (e CostEstimate) TotalUSD() float64 { return e.InputUSD + e.OutputUSD }
+
+// ChatCost estimates what sending messages on tier would cost,
+// assuming the model produces expectedOutputTokens of completion.
+func ChatCost(tier Tier, messages []Message, expectedOutputTokens int) CostEstimate {
+	in := EstimateMessageTokens(messages)
+	return CostEstimate{
+		Tier:         tier.Name,
+		InputTokens:  in,
+		OutputTokens: expectedOutputTokens,
+		InputUSD:     float64(in) * tier.InputPricePerMTok / 1e6,
+		OutputUSD:    float64(expectedOutputTokens) * tier.OutputPricePerMTok / 1e6,
+	}
+}
+
+// EmbedCost estimates what embedding texts on tier would cost.
+// Embeddings have no completion, so only input pricing applies.
+func EmbedCost(tier Tier, texts ...string) CostEstimate {
+	var in int
+	for _, t := range texts {
+		in += EstimateTokens(t)
+	}
+	return CostEstimate{
+		Tier:        tier.Name,
+		InputTokens: in,
+		InputUSD:    float64(in) * tier.InputPricePerMTok / 1e6,
+	}
+}
+
+// EstimateCost resolves tierName against the server's tier list (cached
+// after the first call) and prices messages on it, so callers can show
+// previews or route long prompts to cheaper tiers before sending.
+func (c *Client) EstimateCost(ctx context.Context, tierName string, messages []Message, expectedOutputTokens int) (CostEstimate, error) {
+	tier, err := c.tierByName(ctx, tierName)
+	if err != nil {
+		return CostEstimate{}, err
+	}
+	return ChatCost(tier, messages, expectedOutputTokens), nil
+}
+
+// tiers caches the server's tier list per client.
+type tierCache struct {
+	mu     sync.Mutex
+	byName map[string]Tier
+}
+
+// tierByName looks a tier up in the cache, filling it from ListTiers on
+// the first call (or after a miss, in case tiers were added).
+func (c *Client) tierByName(ctx context.Context, name string) (Tier, error) {
+	c.tiers.mu.Lock()
+	defer c.tiers.mu.Unlock()
+	if tier, ok := c.tiers.byName[name]; ok {
+		return tier, nil
+	}
+	listed, err := c.ListTiers(ctx)
+	if err != nil {
+		return Tier{}, err
+	}
+	c.tiers.byName = make(map[string]Tier, len(listed))
+	for _, tier := range listed {
+		c.tiers.byName[tier.Name] = tier
+	}
+	if tier, ok := c.tiers.byName[name]; ok {
+		return tier, nil
+	}
+	return Tier{}, fmt.Errorf("codex: no tier %q on server", name)
+}
@@ -0,0 +1,53 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+var fastTier = Tier{Name: "fast", InputPricePerMTok: 3, OutputPricePerMTok: 15}
+
+func TestChatCost(t *testing.T) {
+	messages := []Message{{Role: RoleUser, Content: strings.Repeat("word ", 200)}}
+	est := ChatCost(fastTier, messages, 1000)
+	if est.InputTokens < 200 {
+		t.Fatalf("input tokens too low: %d", est.InputTokens)
+	}
+	wantOut := 1000 * 15.0 / 1e6
+	if est.OutputUSD != wantOut {
+		t.Fatalf("output cost: got %v, want %v", est.OutputUSD, wantOut)
+	}
+	if est.TotalUSD() != est.InputUSD+est.OutputUSD {
+		t.Fatal("total mismatch")
+	}
+}
+
+func TestEstimateCostCachesTiers(t *testing.T) {
+	var listCalls int
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		listCalls++
+		json.NewEncoder(w).Encode(tiersWire{Tiers: []Tier{fastTier}})
+	}))
+	ctx := context.Background()
+	messages := []Message{{Role: RoleUser, Content: "hello there"}}
+
+	est, err := c.EstimateCost(ctx, "fast", messages, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if est.Tier != "fast" || est.TotalUSD() <= 0 {
+		t.Fatalf("bad estimate: %+v", est)
+	}
+	if _, err := c.EstimateCost(ctx, "fast", messages, 100); err != nil {
+		t.Fatal(err)
+	}
+	if listCalls != 1 {
+		t.Fatalf("tier list fetched %d times, want 1", listCalls)
+	}
+	if _, err := c.EstimateCost(ctx, "absent", messages, 100); err == nil {
+		t.Fatal("expected error for unknown tier")
+	}
+}
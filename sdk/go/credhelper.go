@@ -0,0 +1,47 @@
+package codex
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// WithCredentialHelper resolves the API key by running an external
+// command, in the spirit of git and docker credential helpers, so keys
+// live in the OS keychain (via e.g. `security`, `secret-tool`, or
+// `pass`) instead of env vars and shell history:
+//
+//	c, err := codex.New(codex.WithCredentialHelper("secret-tool", "lookup", "service", "codex"))
+//
+// The helper runs once at construction, receives the server base URL on
+// stdin, and must print the key (the first line of output is used).
+// It is only consulted when no key was set with WithAPIKey.
+func WithCredentialHelper(command string, args ...string) Option {
+	return func(c *clientConfig) {
+		c.credentialHelper = append([]string{command}, args...)
+	}
+}
+
+// resolveCredentialHelper runs the configured helper and returns the
+// key it printed.
+func resolveCredentialHelper(cfg *clientConfig) (string, error) {
+	cmd := exec.Command(cfg.credentialHelper[0], cfg.credentialHelper[1:]...)
+	cmd.Stdin = strings.NewReader(cfg.baseURL + "\n")
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg != "" {
+			return "", fmt.Errorf("codex: credential helper %s: %s: %w", cfg.credentialHelper[0], msg, err)
+		}
+		return "", fmt.Errorf("codex: credential helper %s: %w", cfg.credentialHelper[0], err)
+	}
+	key, _, _ := strings.Cut(out.String(), "\n")
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return "", fmt.Errorf("codex: credential helper %s printed no key", cfg.credentialHelper[0])
+	}
+	return key, nil
+}
@@ -0,0 +1,48 @@
+package codex
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCredentialHelperSuppliesKey(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer helper-key" {
+			t.Errorf("authorization = %q", got)
+		}
+		w.Write([]byte("{}"))
+	}), WithCredentialHelper("sh", "-c", "echo helper-key"))
+	if _, err := c.ListTiers(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCredentialHelperLosesToExplicitKey(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer explicit" {
+			t.Errorf("authorization = %q", got)
+		}
+		w.Write([]byte("{}"))
+	}), WithAPIKey("explicit"), WithCredentialHelper("sh", "-c", "echo helper-key"))
+	if _, err := c.ListTiers(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCredentialHelperFailureSurfaces(t *testing.T) {
+	_, err := New(WithCredentialHelper("sh", "-c", "echo broken >&2; exit 3"))
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "broken") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestCredentialHelperEmptyOutput(t *testing.T) {
+	if _, err := New(WithCredentialHelper("sh", "-c", "true")); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,90 @@
+package codex
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// WithStrictDecoding makes the client error on response fields it does
+// not know about, instead of silently dropping them. Run test suites
+// with it to catch client/server drift early; leave production on the
+// default lenient mode, where unknown fields on chat and RAG responses
+// are preserved in their Extra field for forward compatibility.
+func WithStrictDecoding() Option {
+	return func(c *clientConfig) { c.strictDecoding = true }
+}
+
+// extraCapturer is implemented by response types that keep unknown
+// fields around in lenient mode.
+type extraCapturer interface {
+	captureExtra(raw []byte)
+}
+
+func (r *ChatResponse) captureExtra(raw []byte) { r.Extra = unknownFields(raw, r) }
+
+func (r *Result) captureExtra(raw []byte) { r.Extra = unknownFields(raw, r) }
+
+// unknownFields returns the response fields that v's struct does not
+// declare, as a JSON object, or nil when there are none.
+func unknownFields(raw []byte, v any) json.RawMessage {
+	var m map[string]json.RawMessage
+	if json.Unmarshal(raw, &m) != nil {
+		return nil
+	}
+	deleteKnown(m, reflect.TypeOf(v).Elem())
+	if len(m) == 0 {
+		return nil
+	}
+	js, err := json.Marshal(m)
+	if err != nil {
+		return nil
+	}
+	return js
+}
+
+func deleteKnown(m map[string]json.RawMessage, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			deleteKnown(m, f.Type)
+			continue
+		}
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch tag {
+		case "-":
+		case "":
+			delete(m, f.Name)
+		default:
+			delete(m, tag)
+		}
+	}
+}
+
+// decodeBody decodes one JSON value from dec into out, honoring the
+// client's decoding mode: strict decoding rejects unknown fields, and
+// lenient decoding lets extraCapturer types keep them.
+func (c *Client) decodeBody(dec *json.Decoder, out any) error {
+	cap, captures := out.(extraCapturer)
+	if !c.cfg.strictDecoding && !captures {
+		return dec.Decode(out)
+	}
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+	if c.cfg.strictDecoding {
+		strict := json.NewDecoder(bytes.NewReader(raw))
+		strict.DisallowUnknownFields()
+		if err := strict.Decode(out); err != nil {
+			return err
+		}
+	} else if err := json.Unmarshal(raw, out); err != nil {
+		return err
+	}
+	if captures && !c.cfg.strictDecoding {
+		cap.captureExtra(raw)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package codex
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLenientDecodingKeepsExtra(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"reply":"hi","finish_reason":"stop","confidence_band":"high","usage":{}}`))
+	}))
+	resp, err := c.ChatCompletion(context.Background(), []Message{{Role: RoleUser, Content: "hi"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Reply != "hi" {
+		t.Fatalf("reply: %q", resp.Reply)
+	}
+	if !strings.Contains(string(resp.Extra), `"confidence_band":"high"`) {
+		t.Fatalf("extra: %s", resp.Extra)
+	}
+
+	// Fully known responses leave Extra empty.
+	c2 := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"reply":"hi","usage":{}}`))
+	}))
+	resp, err = c2.ChatCompletion(context.Background(), []Message{{Role: RoleUser, Content: "hi"}})
+	if err != nil || resp.Extra != nil {
+		t.Fatalf("extra should be empty: %s, err %v", resp.Extra, err)
+	}
+}
+
+func TestStrictDecodingRejectsUnknownFields(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"reply":"hi","usage":{},"surprise":true}`))
+	}), WithStrictDecoding())
+	_, err := c.ChatCompletion(context.Background(), []Message{{Role: RoleUser, Content: "hi"}})
+	if err == nil || !strings.Contains(err.Error(), "surprise") {
+		t.Fatalf("want unknown-field error, got %v", err)
+	}
+}
@@ -0,0 +1,226 @@
+// Package degrade keeps an application answering when the codex server
+// is unreachable. A Middleware wraps the client with a degradation
+// policy: retry against a fallback client (say, a local embedder and
+// memstore), serve the last good answer from a cache, or fail with a
+// typed ErrDegraded carrying a user-friendly message — instead of a raw
+// connection error bubbling up to end users.
+package degrade
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// ErrDegraded marks errors produced while the service is degraded; match
+// it with errors.Is.
+var ErrDegraded = errors.New("codex: service degraded")
+
+// DegradedError is returned when neither the server, the fallback, nor
+// the cache could produce an answer. Message is safe to show to users;
+// Cause is the underlying failure for logs.
+type DegradedError struct {
+	Message string
+	Cause   error
+}
+
+func (e *DegradedError) Error() string { return e.Message }
+
+func (e *DegradedError) Is(target error) bool { return target == ErrDegraded }
+
+func (e *DegradedError) Unwrap() error { return e.Cause }
+
+// DefaultMessage is used when Middleware.Message is empty.
+const DefaultMessage = "The assistant is temporarily unavailable. Please try again in a moment."
+
+// Middleware wraps a client with a degradation policy. It satisfies
+// codex.Codex and drops in wherever the raw client is used.
+type Middleware struct {
+	codex.Codex
+	// Fallback, when set, is tried after the primary fails with an
+	// outage-shaped error — typically a client pointed at a local
+	// embedder and in-memory store.
+	Fallback codex.Codex
+	// Cache, when set, serves the last good answer for a repeated
+	// question once both primary and fallback have failed.
+	Cache *AnswerCache
+	// Message is what end users see when nothing could answer; defaults
+	// to DefaultMessage.
+	Message string
+}
+
+var _ codex.Codex = (*Middleware)(nil)
+
+// isOutage reports whether the error looks like the server being down
+// rather than the request being wrong: connection failures and 5xx/429
+// responses qualify; context cancellation and 4xx do not.
+func isOutage(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var ae *codex.APIError
+	if errors.As(err, &ae) {
+		return ae.Temporary() || ae.StatusCode >= 500
+	}
+	// Anything that never produced an HTTP response — refused
+	// connections, DNS failures — is an outage.
+	return true
+}
+
+func (m *Middleware) degraded(cause error) error {
+	msg := m.Message
+	if msg == "" {
+		msg = DefaultMessage
+	}
+	return &DegradedError{Message: msg, Cause: cause}
+}
+
+// RAGAnswer asks the primary, then the fallback, then the cache. Good
+// answers refresh the cache on the way out.
+func (m *Middleware) RAGAnswer(ctx context.Context, question string, opts ...codex.RAGOption) (*codex.Result, error) {
+	res, err := m.Codex.RAGAnswer(ctx, question, opts...)
+	if err == nil {
+		m.Cache.put(question, res)
+		return res, nil
+	}
+	if !isOutage(err) {
+		return nil, err
+	}
+	if m.Fallback != nil {
+		if res, ferr := m.Fallback.RAGAnswer(ctx, question, opts...); ferr == nil {
+			return res, nil
+		}
+	}
+	if cached, ok := m.Cache.get(question); ok {
+		return cached, nil
+	}
+	return nil, m.degraded(err)
+}
+
+// Chat degrades like RAGAnswer, keyed by the last user message.
+func (m *Middleware) Chat(ctx context.Context, messages []codex.Message, opts ...codex.ChatOption) (string, error) {
+	reply, err := m.Codex.Chat(ctx, messages, opts...)
+	key := chatKey(messages)
+	if err == nil {
+		m.Cache.put(key, &codex.Result{Answer: reply})
+		return reply, nil
+	}
+	if !isOutage(err) {
+		return "", err
+	}
+	if m.Fallback != nil {
+		if reply, ferr := m.Fallback.Chat(ctx, messages, opts...); ferr == nil {
+			return reply, nil
+		}
+	}
+	if cached, ok := m.Cache.get(key); ok {
+		return cached.Answer, nil
+	}
+	return "", m.degraded(err)
+}
+
+// Embed falls back to the fallback client; embeddings are not cached.
+func (m *Middleware) Embed(ctx context.Context, text string) ([]float32, error) {
+	vec, err := m.Codex.Embed(ctx, text)
+	if err == nil || !isOutage(err) {
+		return vec, err
+	}
+	if m.Fallback != nil {
+		if vec, ferr := m.Fallback.Embed(ctx, text); ferr == nil {
+			return vec, nil
+		}
+	}
+	return nil, m.degraded(err)
+}
+
+// EmbedBatch falls back like Embed.
+func (m *Middleware) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	vecs, err := m.Codex.EmbedBatch(ctx, texts)
+	if err == nil || !isOutage(err) {
+		return vecs, err
+	}
+	if m.Fallback != nil {
+		if vecs, ferr := m.Fallback.EmbedBatch(ctx, texts); ferr == nil {
+			return vecs, nil
+		}
+	}
+	return nil, m.degraded(err)
+}
+
+func chatKey(messages []codex.Message) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == codex.RoleUser {
+			return messages[i].Content
+		}
+	}
+	return ""
+}
+
+// AnswerCache remembers the last good answer per question, with a TTL
+// and a size cap. The zero value is unusable; a nil cache is a no-op.
+type AnswerCache struct {
+	ttl time.Duration
+	max int
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	result *codex.Result
+	added  time.Time
+}
+
+// NewAnswerCache builds a cache holding up to max answers for ttl each;
+// max <= 0 means 1000, ttl <= 0 means an hour.
+func NewAnswerCache(max int, ttl time.Duration) *AnswerCache {
+	if max <= 0 {
+		max = 1000
+	}
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+	return &AnswerCache{ttl: ttl, max: max, entries: make(map[string]cacheEntry)}
+}
+
+func (c *AnswerCache) put(key string, res *codex.Result) {
+	if c == nil || key == "" || res == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= c.max {
+		// Evict the oldest entry; the cache is small and degraded
+		// serving is rare, so a scan is fine.
+		var oldest string
+		var oldestAt time.Time
+		for k, e := range c.entries {
+			if oldest == "" || e.added.Before(oldestAt) {
+				oldest, oldestAt = k, e.added
+			}
+		}
+		delete(c.entries, oldest)
+	}
+	c.entries[normalize(key)] = cacheEntry{result: res, added: time.Now()}
+}
+
+func (c *AnswerCache) get(key string) (*codex.Result, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[normalize(key)]
+	if !ok || time.Since(e.added) > c.ttl {
+		return nil, false
+	}
+	return e.result, true
+}
+
+func normalize(key string) string {
+	return strings.Join(strings.Fields(strings.ToLower(key)), " ")
+}
@@ -0,0 +1,88 @@
+package degrade
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// stubCodex fails or answers on command; unused methods panic via the
+// embedded nil interface.
+type stubCodex struct {
+	codex.Codex
+	err    error
+	answer string
+	calls  int
+}
+
+func (s *stubCodex) RAGAnswer(ctx context.Context, question string, opts ...codex.RAGOption) (*codex.Result, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &codex.Result{Answer: s.answer}, nil
+}
+
+func (s *stubCodex) Embed(ctx context.Context, text string) ([]float32, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []float32{1}, nil
+}
+
+var errConnRefused = errors.New("dial tcp 127.0.0.1:8080: connection refused")
+
+func TestFallbackServesWhenPrimaryIsDown(t *testing.T) {
+	primary := &stubCodex{err: errConnRefused}
+	fallback := &stubCodex{answer: "local answer"}
+	m := &Middleware{Codex: primary, Fallback: fallback}
+
+	res, err := m.RAGAnswer(context.Background(), "q")
+	if err != nil || res.Answer != "local answer" {
+		t.Fatalf("res %+v, err %v", res, err)
+	}
+	if vec, err := m.Embed(context.Background(), "text"); err != nil || len(vec) != 1 {
+		t.Fatalf("embed: %v, %v", vec, err)
+	}
+}
+
+func TestCacheServesLastGoodAnswer(t *testing.T) {
+	primary := &stubCodex{answer: "fresh"}
+	m := &Middleware{Codex: primary, Cache: NewAnswerCache(10, time.Minute)}
+
+	if _, err := m.RAGAnswer(context.Background(), "What is X?"); err != nil {
+		t.Fatal(err)
+	}
+	primary.err = errConnRefused
+	// Same question, different whitespace and case.
+	res, err := m.RAGAnswer(context.Background(), "  what IS x?")
+	if err != nil || res.Answer != "fresh" {
+		t.Fatalf("cached answer: %+v, err %v", res, err)
+	}
+}
+
+func TestErrDegradedWhenNothingCanAnswer(t *testing.T) {
+	m := &Middleware{Codex: &stubCodex{err: errConnRefused}, Message: "try later"}
+	_, err := m.RAGAnswer(context.Background(), "q")
+	if !errors.Is(err, ErrDegraded) {
+		t.Fatalf("want ErrDegraded, got %v", err)
+	}
+	var de *DegradedError
+	if !errors.As(err, &de) || de.Message != "try later" || !errors.Is(de.Cause, errConnRefused) {
+		t.Fatalf("degraded error: %+v", de)
+	}
+}
+
+func TestNonOutageErrorsPassThrough(t *testing.T) {
+	bad := &codex.APIError{StatusCode: 400, Message: "bad request"}
+	fallback := &stubCodex{answer: "should not run"}
+	m := &Middleware{Codex: &stubCodex{err: bad}, Fallback: fallback}
+	_, err := m.RAGAnswer(context.Background(), "q")
+	if !errors.Is(err, error(bad)) || fallback.calls != 0 {
+		t.Fatalf("err %v, fallback calls %d", err, fallback.calls)
+	}
+}
@@ -0,0 +1,14 @@
+// Package codex is the Go client for the codex-extended server.
+//
+// The client exposes the server's chat, embedding, vector-store, and
+// retrieval-augmented-generation (RAG) endpoints behind a small typed API:
+//
+//	client, err := codex.New(codex.WithBaseURL("http://localhost:8080"))
+//	if err != nil { ... }
+//	reply, err := client.Chat(ctx, []codex.Message{
+//		{Role: codex.RoleUser, Content: "hello"},
+//	})
+//
+// Bulk document ingestion lives in the ingest subpackage, which feeds
+// extracted and chunked text through Embed/Upsert into a collection.
+package codex
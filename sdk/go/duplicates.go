@@ -0,0 +1,140 @@
+package codex
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+// DuplicateCluster is one group of near-identical chunks. Keep is the
+// suggested survivor (the longest document, ties broken by lowest ID);
+// Delete lists the rest.
+type DuplicateCluster struct {
+	Keep   string   `json:"keep"`
+	Delete []string `json:"delete"`
+	// Similarity is the lowest pairwise similarity inside the cluster.
+	Similarity float32 `json:"similarity"`
+}
+
+type duplicatesRequest struct {
+	Threshold float32 `json:"threshold"`
+}
+
+type duplicatesWire struct {
+	Clusters []DuplicateCluster `json:"clusters"`
+}
+
+// FindDuplicates reports clusters of near-identical chunks in
+// collection: pairs whose cosine similarity is at least threshold
+// (e.g. 0.97) end up in the same cluster, each with a suggested record
+// to keep. The server computes the report when it supports the
+// endpoint; on a 404 the client falls back to scanning the collection
+// with Scroll and comparing vectors locally, which is O(n²) in the
+// collection size — fine for spot checks, slow for very large corpora.
+func (c *Client) FindDuplicates(ctx context.Context, collection string, threshold float32) ([]DuplicateCluster, error) {
+	path := "/v1/collections/" + url.PathEscape(c.collection(collection)) + "/duplicates"
+	var resp duplicatesWire
+	err := c.do(ctx, http.MethodPost, path, duplicatesRequest{Threshold: threshold}, &resp)
+	if err == nil {
+		return resp.Clusters, nil
+	}
+	if !IsNotFound(err) {
+		return nil, err
+	}
+	return c.findDuplicatesLocally(ctx, collection, threshold)
+}
+
+func (c *Client) findDuplicatesLocally(ctx context.Context, collection string, threshold float32) ([]DuplicateCluster, error) {
+	var recs []VectorRecord
+	cursor := ""
+	for {
+		page, next, err := c.Scroll(ctx, collection, cursor, defaultPageSize)
+		if err != nil {
+			return nil, err
+		}
+		recs = append(recs, page...)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	// Union-find over pairs above the threshold.
+	parent := make([]int, len(recs))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	minSim := make(map[int]float32)
+	for i := 0; i < len(recs); i++ {
+		for j := i + 1; j < len(recs); j++ {
+			sim := cosineSimilarity(recs[i].Vector, recs[j].Vector)
+			if sim < threshold {
+				continue
+			}
+			ri, rj := find(i), find(j)
+			if ri != rj {
+				parent[rj] = ri
+			}
+			root := find(ri)
+			if cur, ok := minSim[root]; !ok || sim < cur {
+				minSim[root] = sim
+			}
+		}
+	}
+
+	groups := map[int][]int{}
+	for i := range recs {
+		root := find(i)
+		groups[root] = append(groups[root], i)
+	}
+	var clusters []DuplicateCluster
+	for root, members := range groups {
+		if len(members) < 2 {
+			continue
+		}
+		keep := members[0]
+		for _, m := range members[1:] {
+			if len(recs[m].Document) > len(recs[keep].Document) ||
+				(len(recs[m].Document) == len(recs[keep].Document) && recs[m].ID < recs[keep].ID) {
+				keep = m
+			}
+		}
+		cluster := DuplicateCluster{Keep: recs[keep].ID, Similarity: minSim[root]}
+		for _, m := range members {
+			if m != keep {
+				cluster.Delete = append(cluster.Delete, recs[m].ID)
+			}
+		}
+		sort.Strings(cluster.Delete)
+		clusters = append(clusters, cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Keep < clusters[j].Keep })
+	return clusters, nil
+}
+
+// cosineSimilarity returns the cosine of the angle between a and b, or
+// zero when either is empty or they differ in length.
+func cosineSimilarity(a, b []float32) float32 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return float32(dot / (math.Sqrt(na) * math.Sqrt(nb)))
+}
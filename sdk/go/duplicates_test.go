@@ -0,0 +1,74 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestFindDuplicatesServerSide(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/collections/kb/duplicates" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		var req duplicatesRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Threshold != 0.97 {
+			t.Errorf("threshold = %v", req.Threshold)
+		}
+		json.NewEncoder(w).Encode(duplicatesWire{Clusters: []DuplicateCluster{
+			{Keep: "a", Delete: []string{"b"}, Similarity: 0.99},
+		}})
+	}))
+	clusters, err := c.FindDuplicates(context.Background(), "kb", 0.97)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clusters) != 1 || clusters[0].Keep != "a" {
+		t.Fatalf("clusters: %+v", clusters)
+	}
+}
+
+func TestFindDuplicatesLocalFallback(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/collections/kb/duplicates":
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(APIError{Message: "no such endpoint"})
+		case "/v1/collections/kb/scroll":
+			json.NewEncoder(w).Encode(map[string]any{"records": []VectorRecord{
+				{ID: "dup1", Document: "short", Vector: []float32{1, 0}},
+				{ID: "dup2", Document: "longer text", Vector: []float32{0.999, 0.01}},
+				{ID: "lone", Document: "different", Vector: []float32{0, 1}},
+			}})
+		default:
+			t.Errorf("path = %s", r.URL.Path)
+		}
+	}))
+	clusters, err := c.FindDuplicates(context.Background(), "kb", 0.97)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clusters) != 1 {
+		t.Fatalf("clusters: %+v", clusters)
+	}
+	if clusters[0].Keep != "dup2" || len(clusters[0].Delete) != 1 || clusters[0].Delete[0] != "dup1" {
+		t.Fatalf("cluster: %+v", clusters[0])
+	}
+	if clusters[0].Similarity < 0.97 {
+		t.Fatalf("similarity = %v", clusters[0].Similarity)
+	}
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	if sim := cosineSimilarity([]float32{1, 0}, []float32{0, 1}); sim != 0 {
+		t.Fatalf("orthogonal = %v", sim)
+	}
+	if sim := cosineSimilarity([]float32{2, 0}, []float32{5, 0}); sim < 0.999 {
+		t.Fatalf("parallel = %v", sim)
+	}
+	if sim := cosineSimilarity(nil, []float32{1}); sim != 0 {
+		t.Fatalf("empty = %v", sim)
+	}
+}
@@ -0,0 +1,278 @@
+package codex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type embedRequest struct {
+	Inputs     []string `json:"inputs"`
+	Tier       string   `json:"tier,omitempty"`
+	Model      string   `json:"model,omitempty"`
+	Dimensions int      `json:"dimensions,omitempty"`
+	Normalize  bool     `json:"normalize,omitempty"`
+	TaskType   string   `json:"task_type,omitempty"`
+}
+
+type embedWire struct {
+	Vectors    [][]float32       `json:"vectors"`
+	Model      string            `json:"model,omitempty"`
+	Dimensions int               `json:"dimensions,omitempty"`
+	Usage      Usage             `json:"usage"`
+	Failures   []wireItemFailure `json:"failures,omitempty"`
+}
+
+// EmbedOptions carries per-call embedding parameters; use the
+// WithEmbed* options to set them.
+type EmbedOptions struct {
+	Model      string
+	Dimensions int
+	Normalize  bool
+	TaskType   TaskType
+}
+
+// TaskType tells an asymmetric embedding model which side of retrieval a
+// text sits on. Mixing the two silently degrades recall, so the query
+// and document paths should each declare theirs.
+type TaskType string
+
+const (
+	// TaskQuery marks a search query.
+	TaskQuery TaskType = "query"
+	// TaskDocument marks a document being indexed.
+	TaskDocument TaskType = "document"
+)
+
+// EmbedOption configures a single EmbedWith call.
+type EmbedOption func(*EmbedOptions)
+
+// WithEmbedModel pins the embedding model by name instead of letting the
+// tier choose, for deployments that run several models side by side.
+func WithEmbedModel(name string) EmbedOption {
+	return func(o *EmbedOptions) { o.Model = name }
+}
+
+// WithEmbedDimensions asks the server to truncate vectors to n
+// dimensions (Matryoshka-style), trading recall for storage.
+func WithEmbedDimensions(n int) EmbedOption {
+	return func(o *EmbedOptions) { o.Dimensions = n }
+}
+
+// WithEmbedNormalize asks the server for unit-length vectors, so dot
+// product and cosine similarity coincide.
+func WithEmbedNormalize() EmbedOption {
+	return func(o *EmbedOptions) { o.Normalize = true }
+}
+
+// WithTaskType marks which side of retrieval the texts sit on; see
+// TaskQuery and TaskDocument.
+func WithTaskType(t TaskType) EmbedOption {
+	return func(o *EmbedOptions) { o.TaskType = t }
+}
+
+// EmbedResult is the outcome of an EmbedWith call, including which model
+// and dimensionality the server actually used.
+type EmbedResult struct {
+	Vectors    [][]float32
+	Model      string
+	Dimensions int
+}
+
+// EmbedWith embeds texts with explicit model, dimension, and
+// normalization control, and reports the model and dimensionality the
+// server actually applied. Plain Embed/EmbedBatch remain the simple path
+// when the tier's defaults are fine.
+func (c *Client) EmbedWith(ctx context.Context, texts []string, opts ...EmbedOption) (*EmbedResult, error) {
+	if len(texts) == 0 {
+		return &EmbedResult{}, nil
+	}
+	var o EmbedOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	started := time.Now()
+	req := embedRequest{
+		Inputs:     texts,
+		Tier:       c.cfg.defaultTier,
+		Model:      o.Model,
+		Dimensions: o.Dimensions,
+		Normalize:  o.Normalize,
+		TaskType:   string(o.TaskType),
+	}
+	var resp embedWire
+	if err := c.do(ctx, http.MethodPost, "/v1/embed", req, &resp); err != nil {
+		c.report(Progress{Operation: "embed", Total: len(texts), Failed: len(texts), Started: started})
+		return nil, err
+	}
+	if len(resp.Vectors) != len(texts) {
+		return nil, fmt.Errorf("codex: embed returned %d vectors for %d inputs", len(resp.Vectors), len(texts))
+	}
+	failed := len(resp.Failures)
+	c.report(Progress{Operation: "embed", Done: len(texts) - failed, Total: len(texts), Failed: failed, Started: started})
+	res := &EmbedResult{Vectors: resp.Vectors, Model: resp.Model, Dimensions: resp.Dimensions}
+	if res.Dimensions == 0 && len(resp.Vectors) > 0 {
+		res.Dimensions = len(resp.Vectors[0])
+	}
+	return res, batchError("embed", resp.Failures)
+}
+
+// Embed returns the embedding vector for a single text.
+func (c *Client) Embed(ctx context.Context, text string) ([]float32, error) {
+	vecs, err := c.EmbedBatch(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return vecs[0], nil
+}
+
+// EmbedQuery embeds a search query with the query task type, for
+// asymmetric models that encode queries and documents differently.
+func (c *Client) EmbedQuery(ctx context.Context, text string, opts ...EmbedOption) ([]float32, error) {
+	return c.embedTyped(ctx, text, TaskQuery, opts)
+}
+
+// EmbedDocument embeds a document with the document task type; the
+// counterpart to EmbedQuery.
+func (c *Client) EmbedDocument(ctx context.Context, text string, opts ...EmbedOption) ([]float32, error) {
+	return c.embedTyped(ctx, text, TaskDocument, opts)
+}
+
+func (c *Client) embedTyped(ctx context.Context, text string, task TaskType, opts []EmbedOption) ([]float32, error) {
+	opts = append(opts, WithTaskType(task))
+	res, err := c.EmbedWith(ctx, []string{text}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return res.Vectors[0], nil
+}
+
+// PartialEmbedError reports that a context deadline cut an embedding job
+// short. The vectors returned alongside it are valid for the first
+// Embedded inputs; Unembedded lists the indexes that were not reached.
+// It matches context.DeadlineExceeded with errors.Is.
+type PartialEmbedError struct {
+	Embedded   int
+	Unembedded []int
+}
+
+func (e *PartialEmbedError) Error() string {
+	return fmt.Sprintf("codex: deadline left %d inputs unembedded (%d embedded)", len(e.Unembedded), e.Embedded)
+}
+
+func (e *PartialEmbedError) Is(target error) bool { return target == context.DeadlineExceeded }
+
+// EmbedBatch embeds texts, returning one vector per input in the same
+// order. Without a context deadline everything goes in one request. With
+// a deadline, the job is split into chunks sized from the observed
+// throughput so each request fits the remaining time; if time runs out
+// mid-job, the vectors embedded so far are returned together with a
+// *PartialEmbedError naming the unembedded inputs, so callers keep the
+// work already paid for.
+func (c *Client) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		return c.embedAll(ctx, texts)
+	}
+	return c.embedWithDeadline(ctx, texts)
+}
+
+// embedAll issues one embed request for all texts.
+func (c *Client) embedAll(ctx context.Context, texts []string) ([][]float32, error) {
+	started := time.Now()
+	req := embedRequest{Inputs: texts, Tier: c.cfg.defaultTier}
+	var resp embedWire
+	if err := c.do(ctx, http.MethodPost, "/v1/embed", req, &resp); err != nil {
+		c.report(Progress{Operation: "embed", Total: len(texts), Failed: len(texts), Started: started})
+		return nil, err
+	}
+	if len(resp.Vectors) != len(texts) {
+		return nil, fmt.Errorf("codex: embed returned %d vectors for %d inputs", len(resp.Vectors), len(texts))
+	}
+	var bytes int64
+	for _, t := range texts {
+		bytes += int64(len(t))
+	}
+	failed := len(resp.Failures)
+	c.report(Progress{Operation: "embed", Done: len(texts) - failed, Total: len(texts), Failed: failed, Bytes: bytes, Started: started})
+	// Failed inputs leave nil vector slots; report them itemized so the
+	// caller can resubmit just that subset.
+	return resp.Vectors, batchError("embed", resp.Failures)
+}
+
+const (
+	embedChunkInitial = 32
+	embedChunkMax     = 256
+)
+
+func (c *Client) embedWithDeadline(ctx context.Context, texts []string) ([][]float32, error) {
+	deadline, _ := ctx.Deadline()
+	out := make([][]float32, 0, len(texts))
+	chunk := embedChunkInitial
+	var perItem time.Duration // EWMA of observed time per input
+	var itemFailures []ItemError
+	timedOut := false
+
+	for len(out) < len(texts) && !timedOut {
+		if perItem > 0 {
+			// Spend at most 80% of the remaining time on the next
+			// chunk, keeping headroom for variance.
+			fit := int(float64(time.Until(deadline)) * 0.8 / float64(perItem))
+			if fit < 1 {
+				break
+			}
+			chunk = min(min(fit, chunk*2), embedChunkMax)
+		}
+		n := min(chunk, len(texts)-len(out))
+		started := time.Now()
+		vecs, err := c.embedAll(ctx, texts[len(out):len(out)+n])
+		if err != nil {
+			var be *BatchError
+			switch {
+			case errors.As(err, &be):
+				// Per-item failures: shift indexes from chunk-relative
+				// to batch-relative and carry on with the rest.
+				for i := range be.Items {
+					be.Items[i].Index += len(out)
+				}
+				itemFailures = append(itemFailures, be.Items...)
+			case errors.Is(err, context.DeadlineExceeded):
+				timedOut = true
+				continue
+			default:
+				return out, err
+			}
+		}
+		observed := time.Since(started) / time.Duration(n)
+		if perItem == 0 {
+			perItem = observed
+		} else {
+			perItem = (perItem + observed) / 2
+		}
+		out = append(out, vecs...)
+	}
+
+	var errs []error
+	if len(itemFailures) > 0 {
+		errs = append(errs, &BatchError{Op: "embed", Items: itemFailures})
+	}
+	if len(out) < len(texts) {
+		missing := make([]int, 0, len(texts)-len(out))
+		for i := len(out); i < len(texts); i++ {
+			missing = append(missing, i)
+		}
+		errs = append(errs, &PartialEmbedError{Embedded: len(out), Unembedded: missing})
+	}
+	return out, errors.Join(errs...)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
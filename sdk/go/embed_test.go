@@ -0,0 +1,115 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func embedHandler(delay time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req embedRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		time.Sleep(delay)
+		vecs := make([][]float32, len(req.Inputs))
+		for i := range vecs {
+			vecs[i] = []float32{1}
+		}
+		json.NewEncoder(w).Encode(embedWire{Vectors: vecs})
+	})
+}
+
+func TestEmbedBatchWithoutDeadlineSingleRequest(t *testing.T) {
+	c := newTestClient(t, embedHandler(0))
+	vecs, err := c.EmbedBatch(context.Background(), make([]string, 100))
+	if err != nil || len(vecs) != 100 {
+		t.Fatalf("got %d vectors, err %v", len(vecs), err)
+	}
+}
+
+func TestEmbedBatchDeadlinePartial(t *testing.T) {
+	// Each chunk takes ~40ms; a 100ms budget cannot fit all 96 inputs
+	// (three chunks of 32 plus headroom).
+	c := newTestClient(t, embedHandler(40*time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	vecs, err := c.EmbedBatch(ctx, make([]string, 96))
+	if err == nil {
+		t.Fatal("expected partial embed error")
+	}
+	var pe *PartialEmbedError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expected *PartialEmbedError, got %v", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("should match context.DeadlineExceeded")
+	}
+	if len(vecs) == 0 || len(vecs) >= 96 {
+		t.Fatalf("expected partial progress, got %d vectors", len(vecs))
+	}
+	if pe.Embedded != len(vecs) || len(pe.Unembedded) != 96-len(vecs) {
+		t.Fatalf("inconsistent accounting: %+v vs %d vectors", pe, len(vecs))
+	}
+	if pe.Unembedded[0] != len(vecs) {
+		t.Fatalf("unembedded indexes should start at %d, got %d", len(vecs), pe.Unembedded[0])
+	}
+}
+
+func TestEmbedBatchDeadlineCompletes(t *testing.T) {
+	c := newTestClient(t, embedHandler(0))
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	vecs, err := c.EmbedBatch(ctx, make([]string, 500))
+	if err != nil || len(vecs) != 500 {
+		t.Fatalf("got %d vectors, err %v", len(vecs), err)
+	}
+}
+
+func TestEmbedWith(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req embedRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Model != "mini-v3" || req.Dimensions != 256 || !req.Normalize {
+			t.Errorf("options not forwarded: %+v", req)
+		}
+		json.NewEncoder(w).Encode(embedWire{
+			Vectors: [][]float32{{1, 2}},
+			Model:   "mini-v3",
+		})
+	}))
+	res, err := c.EmbedWith(context.Background(), []string{"hello"},
+		WithEmbedModel("mini-v3"), WithEmbedDimensions(256), WithEmbedNormalize())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Model != "mini-v3" {
+		t.Fatalf("model: %q", res.Model)
+	}
+	// Dimensions fall back to the vector width when the server omits them.
+	if res.Dimensions != 2 || len(res.Vectors) != 1 {
+		t.Fatalf("bad result: %+v", res)
+	}
+}
+
+func TestEmbedQueryAndDocumentTaskTypes(t *testing.T) {
+	var tasks []string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req embedRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		tasks = append(tasks, req.TaskType)
+		json.NewEncoder(w).Encode(embedWire{Vectors: [][]float32{{1}}})
+	}))
+	if _, err := c.EmbedQuery(context.Background(), "where is x?"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.EmbedDocument(context.Background(), "x is here"); err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 2 || tasks[0] != "query" || tasks[1] != "document" {
+		t.Fatalf("task types: %v", tasks)
+	}
+}
@@ -0,0 +1,193 @@
+package codex
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Keyring holds named AES-256 keys for client-side encryption. The
+// active key encrypts new writes; every key can decrypt, so old records
+// stay readable after a rotation (rotate by adding the new key and
+// making it active, then re-upserting at leisure).
+type Keyring struct {
+	active string
+	keys   map[string]cipher.AEAD
+}
+
+// NewKeyring builds a keyring with one active key. key must be 32 bytes.
+func NewKeyring(keyID string, key []byte) (*Keyring, error) {
+	kr := &Keyring{keys: map[string]cipher.AEAD{}}
+	if err := kr.Add(keyID, key); err != nil {
+		return nil, err
+	}
+	kr.active = keyID
+	return kr, nil
+}
+
+// Add registers an additional decryption key.
+func (kr *Keyring) Add(keyID string, key []byte) error {
+	if keyID == "" || strings.Contains(keyID, ":") {
+		return errors.New("codex: key ID must be non-empty and contain no colon")
+	}
+	if len(key) != 32 {
+		return fmt.Errorf("codex: key %s must be 32 bytes, got %d", keyID, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+	kr.keys[keyID] = aead
+	return nil
+}
+
+// SetActive switches which key encrypts new writes.
+func (kr *Keyring) SetActive(keyID string) error {
+	if _, ok := kr.keys[keyID]; !ok {
+		return fmt.Errorf("codex: unknown key %s", keyID)
+	}
+	kr.active = keyID
+	return nil
+}
+
+const encPrefix = "enc:v1:"
+
+// Encrypt seals plaintext with the active key. The output embeds the key
+// ID and nonce: "enc:v1:<keyID>:<base64(nonce|ciphertext)>".
+func (kr *Keyring) Encrypt(plaintext string) (string, error) {
+	aead := kr.keys[kr.active]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encPrefix + kr.active + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a value produced by Encrypt. Values without the
+// encryption prefix are returned unchanged, so mixed collections (some
+// records written before encryption was enabled) read cleanly.
+func (kr *Keyring) Decrypt(value string) (string, error) {
+	if !strings.HasPrefix(value, encPrefix) {
+		return value, nil
+	}
+	rest := strings.TrimPrefix(value, encPrefix)
+	keyID, payload, ok := strings.Cut(rest, ":")
+	if !ok {
+		return "", errors.New("codex: malformed encrypted value")
+	}
+	aead, found := kr.keys[keyID]
+	if !found {
+		return "", fmt.Errorf("codex: no key %s in keyring", keyID)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("codex: malformed encrypted value: %w", err)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("codex: malformed encrypted value")
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("codex: decrypt with key %s: %w", keyID, err)
+	}
+	return string(plain), nil
+}
+
+// WithEncryption encrypts VectorRecord.Document (and the named string
+// metadata fields) with the keyring before upsert, and transparently
+// decrypts on fetch, query, and scroll. Vectors are left in the clear so
+// similarity search still works; the raw text never leaves the process
+// unencrypted.
+func WithEncryption(kr *Keyring, metadataFields ...string) Option {
+	return func(c *clientConfig) {
+		c.keyring = kr
+		c.encryptedFields = metadataFields
+	}
+}
+
+// encryptRecord seals the configured fields of rec, returning a copy.
+func (c *Client) encryptRecord(rec VectorRecord) (VectorRecord, error) {
+	kr := c.cfg.keyring
+	if rec.Document != "" {
+		enc, err := kr.Encrypt(rec.Document)
+		if err != nil {
+			return rec, err
+		}
+		rec.Document = enc
+	}
+	if len(c.cfg.encryptedFields) > 0 && rec.Metadata != nil {
+		meta := make(map[string]any, len(rec.Metadata))
+		for k, v := range rec.Metadata {
+			meta[k] = v
+		}
+		for _, field := range c.cfg.encryptedFields {
+			if s, ok := meta[field].(string); ok {
+				enc, err := kr.Encrypt(s)
+				if err != nil {
+					return rec, err
+				}
+				meta[field] = enc
+			}
+		}
+		rec.Metadata = meta
+	}
+	return rec, nil
+}
+
+// decryptRecords opens the configured fields of every record in place.
+// It is a no-op without a configured keyring.
+func (c *Client) decryptRecords(recs []VectorRecord) error {
+	if c.cfg.keyring == nil {
+		return nil
+	}
+	for i := range recs {
+		if err := c.decryptRecord(&recs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decryptMatches opens the configured fields of every match in place.
+func (c *Client) decryptMatches(matches []Match) error {
+	if c.cfg.keyring == nil {
+		return nil
+	}
+	for i := range matches {
+		if err := c.decryptRecord(&matches[i].VectorRecord); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decryptRecord opens the configured fields of rec in place.
+func (c *Client) decryptRecord(rec *VectorRecord) error {
+	kr := c.cfg.keyring
+	if rec.Document != "" {
+		plain, err := kr.Decrypt(rec.Document)
+		if err != nil {
+			return err
+		}
+		rec.Document = plain
+	}
+	for _, field := range c.cfg.encryptedFields {
+		if s, ok := rec.Metadata[field].(string); ok {
+			plain, err := kr.Decrypt(s)
+			if err != nil {
+				return err
+			}
+			rec.Metadata[field] = plain
+		}
+	}
+	return nil
+}
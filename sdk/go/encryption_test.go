@@ -0,0 +1,108 @@
+package codex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func testKeyring(t *testing.T, keyID string) *Keyring {
+	t.Helper()
+	kr, err := NewKeyring(keyID, bytes.Repeat([]byte{0x42}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return kr
+}
+
+func TestKeyringRoundTrip(t *testing.T) {
+	kr := testKeyring(t, "k1")
+	sealed, err := kr.Encrypt("secret document")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(sealed, "enc:v1:k1:") || strings.Contains(sealed, "secret") {
+		t.Fatalf("bad ciphertext: %q", sealed)
+	}
+	plain, err := kr.Decrypt(sealed)
+	if err != nil || plain != "secret document" {
+		t.Fatalf("decrypt: %q, %v", plain, err)
+	}
+	// Unencrypted values pass through for mixed collections.
+	if got, err := kr.Decrypt("already plain"); err != nil || got != "already plain" {
+		t.Fatalf("passthrough: %q, %v", got, err)
+	}
+}
+
+func TestKeyringRotation(t *testing.T) {
+	kr := testKeyring(t, "k1")
+	old, err := kr.Encrypt("written under k1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := kr.Add("k2", bytes.Repeat([]byte{0x7f}, 32)); err != nil {
+		t.Fatal(err)
+	}
+	if err := kr.SetActive("k2"); err != nil {
+		t.Fatal(err)
+	}
+	fresh, err := kr.Encrypt("written under k2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(fresh, "enc:v1:k2:") {
+		t.Fatalf("new writes should use k2: %q", fresh)
+	}
+	if plain, err := kr.Decrypt(old); err != nil || plain != "written under k1" {
+		t.Fatalf("old key unreadable after rotation: %q, %v", plain, err)
+	}
+}
+
+func TestEncryptionRoundTripThroughClient(t *testing.T) {
+	var stored []VectorRecord
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/collections/kb/vectors", func(w http.ResponseWriter, r *http.Request) {
+		var req upsertRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		stored = req.Records
+	})
+	mux.HandleFunc("/v1/collections/kb/fetch", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(fetchWire{Records: stored})
+	})
+
+	kr := testKeyring(t, "k1")
+	c := newTestClient(t, mux, WithEncryption(kr, "author"))
+
+	rec := VectorRecord{
+		ID:       "a",
+		Vector:   []float32{1, 2},
+		Document: "top secret",
+		Metadata: map[string]any{"author": "alice", "lang": "en"},
+	}
+	if err := c.Upsert(context.Background(), "kb", rec); err != nil {
+		t.Fatal(err)
+	}
+	if stored[0].Document == "top secret" || stored[0].Metadata["author"] == "alice" {
+		t.Fatalf("plaintext reached the server: %+v", stored[0])
+	}
+	if stored[0].Metadata["lang"] != "en" {
+		t.Fatalf("unlisted metadata field should stay clear: %+v", stored[0].Metadata)
+	}
+	// The caller's record must not be mutated by the sealing copy.
+	if rec.Document != "top secret" || rec.Metadata["author"] != "alice" {
+		t.Fatalf("input record mutated: %+v", rec)
+	}
+
+	got, err := c.Fetch(context.Background(), "kb", []string{"a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0].Document != "top secret" || got[0].Metadata["author"] != "alice" {
+		t.Fatalf("fetch did not decrypt: %+v", got[0])
+	}
+}
@@ -0,0 +1,144 @@
+package codex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// APIError is a non-2xx response from the server.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Code       string `json:"code,omitempty"`
+	Message    string `json:"message"`
+	RequestID  string `json:"request_id,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "codex: %s (", e.Message)
+	if e.Code != "" {
+		fmt.Fprintf(&b, "%s, ", e.Code)
+	}
+	fmt.Fprintf(&b, "http %d", e.StatusCode)
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, ", request %s", e.RequestID)
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+// Is makes server-side deadline failures match context.DeadlineExceeded,
+// so callers that already handle context timeouts handle both uniformly.
+func (e *APIError) Is(target error) bool {
+	if target == context.DeadlineExceeded {
+		return e.Code == "deadline_exceeded" || e.StatusCode == http.StatusGatewayTimeout
+	}
+	return false
+}
+
+// Temporary reports whether retrying the same request may succeed.
+func (e *APIError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// ErrTruncated matches errors returned when a generation stopped because
+// it hit the token limit: errors.Is(err, codex.ErrTruncated).
+var ErrTruncated = errors.New("codex: generation truncated at token limit")
+
+// TruncatedError is returned alongside a partial response when the model
+// stopped for length. Response holds the truncated output so pipelines
+// can continue the generation with a follow-up request.
+type TruncatedError struct {
+	Response *ChatResponse
+}
+
+func (e *TruncatedError) Error() string {
+	return fmt.Sprintf("codex: generation truncated at token limit after %d completion tokens", e.Response.Usage.CompletionTokens)
+}
+
+func (e *TruncatedError) Is(target error) bool { return target == ErrTruncated }
+
+// AttemptInfo records one attempt of a retried request, for the audit
+// trail attached to errors by RetryError.
+type AttemptInfo struct {
+	// Endpoint is the method and path, e.g. "POST /v1/chat".
+	Endpoint string
+	Duration time.Duration
+	// Status is the HTTP status of the attempt, or 0 when the request
+	// never produced a response (network error, timeout).
+	Status int
+	// Class buckets the failure: "rate_limited", "server_error",
+	// "api_error", "timeout", "canceled", or "network".
+	Class string
+	// Err is the attempt's error text.
+	Err string
+}
+
+// RetryError is returned when a request fails after more than one
+// attempt. It carries every attempt's endpoint, duration, status, and
+// error class, so on-call engineers can see what the client tried
+// without having had debug logging enabled. It unwraps to the final
+// attempt's error, so errors.Is and errors.As checks keep working.
+type RetryError struct {
+	Attempts []AttemptInfo
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("codex: %d attempts failed: %v", len(e.Attempts), e.Err)
+}
+
+func (e *RetryError) Unwrap() error { return e.Err }
+
+// errorClass buckets an attempt's failure for AttemptInfo.
+func errorClass(err error) string {
+	var ae *APIError
+	switch {
+	case errors.As(err, &ae):
+		switch {
+		case ae.StatusCode == http.StatusTooManyRequests:
+			return "rate_limited"
+		case ae.StatusCode >= 500:
+			return "server_error"
+		default:
+			return "api_error"
+		}
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	default:
+		return "network"
+	}
+}
+
+// attemptInfo summarizes one failed attempt for the audit trail.
+func attemptInfo(method, path string, d time.Duration, err error) AttemptInfo {
+	info := AttemptInfo{
+		Endpoint: method + " " + path,
+		Duration: d,
+		Class:    errorClass(err),
+		Err:      err.Error(),
+	}
+	var ae *APIError
+	if errors.As(err, &ae) {
+		info.Status = ae.StatusCode
+	}
+	return info
+}
+
+// IsNotFound reports whether err is a 404 from the server.
+func IsNotFound(err error) bool {
+	var ae *APIError
+	return errors.As(err, &ae) && ae.StatusCode == http.StatusNotFound
+}
+
+// IsRateLimited reports whether err is a 429 from the server.
+func IsRateLimited(err error) bool {
+	var ae *APIError
+	return errors.As(err, &ae) && ae.StatusCode == http.StatusTooManyRequests
+}
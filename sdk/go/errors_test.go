@@ -0,0 +1,84 @@
+package codex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryErrorCarriesAttempts(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error":{"code":"overloaded","message":"try later"}}`))
+	}), WithMaxRetries(2))
+	c.cfg.retryBaseDelay = time.Millisecond
+
+	_, err := c.ListCollections(context.Background())
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	var re *RetryError
+	if !errors.As(err, &re) {
+		t.Fatalf("err = %T, want *RetryError", err)
+	}
+	if len(re.Attempts) != 3 || calls.Load() != 3 {
+		t.Fatalf("attempts = %d, calls = %d", len(re.Attempts), calls.Load())
+	}
+	for _, a := range re.Attempts {
+		if a.Endpoint != "GET /v1/collections" {
+			t.Errorf("endpoint = %q", a.Endpoint)
+		}
+		if a.Status != http.StatusServiceUnavailable || a.Class != "server_error" {
+			t.Errorf("attempt: %+v", a)
+		}
+		if a.Err == "" || a.Duration <= 0 {
+			t.Errorf("attempt missing detail: %+v", a)
+		}
+	}
+
+	// The audit wrapper must not hide the underlying API error.
+	var ae *APIError
+	if !errors.As(err, &ae) || ae.Code != "overloaded" {
+		t.Fatalf("unwrapped: %v", err)
+	}
+}
+
+func TestSingleAttemptFailureStaysUnwrapped(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}), WithMaxRetries(2))
+
+	_, err := c.ListCollections(context.Background())
+	var re *RetryError
+	if errors.As(err, &re) {
+		t.Fatalf("non-retried failure wrapped in RetryError: %v", err)
+	}
+	if !IsNotFound(err) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestErrorClassBuckets(t *testing.T) {
+	cases := []struct {
+		err  error
+		want string
+	}{
+		{&APIError{StatusCode: http.StatusTooManyRequests}, "rate_limited"},
+		{&APIError{StatusCode: http.StatusBadGateway}, "server_error"},
+		{&APIError{StatusCode: http.StatusBadRequest}, "api_error"},
+		{context.DeadlineExceeded, "timeout"},
+		{context.Canceled, "canceled"},
+		{errors.New("connection refused"), "network"},
+	}
+	for _, tc := range cases {
+		if got := errorClass(tc.err); got != tc.want {
+			t.Errorf("errorClass(%v) = %q, want %q", tc.err, got, tc.want)
+		}
+	}
+}
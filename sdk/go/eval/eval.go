@@ -0,0 +1,189 @@
+// Package eval runs golden Q&A datasets against a codex server so
+// knowledge-base changes can be gated in CI: each case asks a question
+// through RAGAnswer and checks the answer for expected facts and
+// forbidden content, literally or via an LLM judge.
+package eval
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// Case is one golden question with its acceptance criteria.
+type Case struct {
+	// Name identifies the case in reports; defaults to the question.
+	Name     string `json:"name,omitempty"`
+	Question string `json:"question"`
+	// ExpectedFacts must all be present in the answer. Each is checked
+	// as a case-insensitive substring first; when a judge tier is
+	// configured, facts that fail the literal check are put to the
+	// judge, so paraphrased answers still pass.
+	ExpectedFacts []string `json:"expected_facts,omitempty"`
+	// Forbidden content fails the case if it appears in the answer.
+	Forbidden []string `json:"forbidden,omitempty"`
+	// Collection overrides the client's default for this case.
+	Collection string `json:"collection,omitempty"`
+}
+
+// LoadJSONL reads a dataset with one JSON case per line. Blank lines and
+// lines starting with # are skipped, so datasets can carry comments.
+func LoadJSONL(r io.Reader) ([]Case, error) {
+	var cases []Case
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		var c Case
+		if err := json.Unmarshal([]byte(text), &c); err != nil {
+			return nil, fmt.Errorf("eval: line %d: %w", line, err)
+		}
+		if c.Question == "" {
+			return nil, fmt.Errorf("eval: line %d: case has no question", line)
+		}
+		if c.Name == "" {
+			c.Name = c.Question
+		}
+		cases = append(cases, c)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return cases, nil
+}
+
+// Options configures a Run.
+type Options struct {
+	// JudgeTier, when set, names the model tier used to judge facts
+	// that are not literal substrings of the answer. Empty disables the
+	// judge and facts must match literally.
+	JudgeTier string
+	// RAGOptions are applied to every case's RAGAnswer call.
+	RAGOptions []codex.RAGOption
+}
+
+// CaseResult is the outcome of one case.
+type CaseResult struct {
+	Case   Case   `json:"case"`
+	Answer string `json:"answer,omitempty"`
+	Passed bool   `json:"passed"`
+	// MissingFacts are expected facts the answer did not establish.
+	MissingFacts []string `json:"missing_facts,omitempty"`
+	// ForbiddenHits are forbidden strings that appeared.
+	ForbiddenHits []string `json:"forbidden_hits,omitempty"`
+	Err           string   `json:"error,omitempty"`
+}
+
+// Diff renders a human-readable account of why the case failed.
+func (r CaseResult) Diff() string {
+	if r.Passed {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "FAIL %s\n", r.Case.Name)
+	if r.Err != "" {
+		fmt.Fprintf(&b, "  error: %s\n", r.Err)
+	}
+	for _, f := range r.MissingFacts {
+		fmt.Fprintf(&b, "  missing: %s\n", f)
+	}
+	for _, f := range r.ForbiddenHits {
+		fmt.Fprintf(&b, "  forbidden: %s\n", f)
+	}
+	if r.Answer != "" {
+		fmt.Fprintf(&b, "  answer: %s\n", r.Answer)
+	}
+	return b.String()
+}
+
+// Report aggregates a Run.
+type Report struct {
+	Results []CaseResult `json:"results"`
+	Passed  int          `json:"passed"`
+	Failed  int          `json:"failed"`
+}
+
+// OK reports whether every case passed.
+func (r *Report) OK() bool { return r.Failed == 0 }
+
+// Run executes every case against client and returns the aggregated
+// report. It only returns an error for setup problems; individual case
+// failures are recorded in the report, so CI can print every diff.
+func Run(ctx context.Context, client codex.Codex, cases []Case, opts Options) (*Report, error) {
+	report := &Report{Results: make([]CaseResult, 0, len(cases))}
+	for _, c := range cases {
+		res := runCase(ctx, client, c, opts)
+		if res.Passed {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+		report.Results = append(report.Results, res)
+	}
+	return report, nil
+}
+
+func runCase(ctx context.Context, client codex.Codex, c Case, opts Options) CaseResult {
+	res := CaseResult{Case: c}
+	ragOpts := opts.RAGOptions
+	if c.Collection != "" {
+		ragOpts = append(append([]codex.RAGOption{}, ragOpts...), codex.WithCollection(c.Collection))
+	}
+	answer, err := client.RAGAnswer(ctx, c.Question, ragOpts...)
+	if err != nil {
+		res.Err = err.Error()
+		return res
+	}
+	res.Answer = answer.Answer
+	lower := strings.ToLower(answer.Answer)
+
+	for _, fact := range c.ExpectedFacts {
+		if strings.Contains(lower, strings.ToLower(fact)) {
+			continue
+		}
+		if opts.JudgeTier != "" {
+			ok, err := judge(ctx, client, opts.JudgeTier, answer.Answer, fact)
+			if err != nil {
+				res.Err = err.Error()
+				return res
+			}
+			if ok {
+				continue
+			}
+		}
+		res.MissingFacts = append(res.MissingFacts, fact)
+	}
+	for _, bad := range c.Forbidden {
+		if strings.Contains(lower, strings.ToLower(bad)) {
+			res.ForbiddenHits = append(res.ForbiddenHits, bad)
+		}
+	}
+	res.Passed = len(res.MissingFacts) == 0 && len(res.ForbiddenHits) == 0
+	return res
+}
+
+// judge asks the judge tier whether the answer establishes the fact.
+func judge(ctx context.Context, client codex.Codex, tier, answer, fact string) (bool, error) {
+	prompt := fmt.Sprintf(
+		"Answer:\n%s\n\nDoes the answer above state or clearly imply the following fact?\nFact: %s\n\nReply with exactly YES or NO.",
+		answer, fact)
+	resp, err := client.ChatCompletion(ctx, []codex.Message{
+		{Role: codex.RoleSystem, Content: "You are a strict grader. Reply with exactly YES or NO."},
+		{Role: codex.RoleUser, Content: prompt},
+	}, codex.WithTier(tier))
+	if err != nil {
+		return false, fmt.Errorf("eval: judge: %w", err)
+	}
+	verdict := strings.ToUpper(strings.TrimSpace(resp.Reply))
+	return strings.HasPrefix(verdict, "YES"), nil
+}
@@ -0,0 +1,92 @@
+package eval
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// stubCodex serves canned answers per question and a fixed judge
+// verdict; unused methods panic via the embedded nil interface.
+type stubCodex struct {
+	codex.Codex
+	answers    map[string]string
+	judgeReply string
+	judged     []string
+}
+
+func (s *stubCodex) RAGAnswer(ctx context.Context, question string, opts ...codex.RAGOption) (*codex.Result, error) {
+	return &codex.Result{Answer: s.answers[question]}, nil
+}
+
+func (s *stubCodex) ChatCompletion(ctx context.Context, messages []codex.Message, opts ...codex.ChatOption) (*codex.ChatResponse, error) {
+	s.judged = append(s.judged, messages[len(messages)-1].Content)
+	return &codex.ChatResponse{Reply: s.judgeReply}, nil
+}
+
+func TestLoadJSONL(t *testing.T) {
+	data := `# golden set for the billing KB
+{"question":"What is the refund window?","expected_facts":["30 days"]}
+
+{"name":"no-legal-advice","question":"Can I sue?","forbidden":["you should sue"]}
+`
+	cases, err := LoadJSONL(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) != 2 || cases[0].Name != "What is the refund window?" || cases[1].Name != "no-legal-advice" {
+		t.Fatalf("cases: %+v", cases)
+	}
+
+	if _, err := LoadJSONL(strings.NewReader(`{"expected_facts":["x"]}`)); err == nil {
+		t.Fatal("case without question should fail to load")
+	}
+}
+
+func TestRunLiteralChecks(t *testing.T) {
+	stub := &stubCodex{answers: map[string]string{
+		"q1": "Refunds are accepted within 30 days of purchase.",
+		"q2": "You should sue them immediately.",
+	}}
+	cases := []Case{
+		{Name: "refund", Question: "q1", ExpectedFacts: []string{"30 DAYS"}},
+		{Name: "legal", Question: "q2", Forbidden: []string{"you should sue"}},
+	}
+	report, err := Run(context.Background(), stub, cases, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Passed != 1 || report.Failed != 1 || report.OK() {
+		t.Fatalf("report: %+v", report)
+	}
+	fail := report.Results[1]
+	if fail.Passed || len(fail.ForbiddenHits) != 1 || !strings.Contains(fail.Diff(), "forbidden: you should sue") {
+		t.Fatalf("failure detail: %+v", fail)
+	}
+}
+
+func TestRunJudgeRescuesParaphrase(t *testing.T) {
+	stub := &stubCodex{
+		answers:    map[string]string{"q": "You have about a month to send it back."},
+		judgeReply: "YES",
+	}
+	cases := []Case{{Question: "q", ExpectedFacts: []string{"30 day refund window"}}}
+	report, err := Run(context.Background(), stub, cases, Options{JudgeTier: "judge"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.OK() {
+		t.Fatalf("judge should have passed the case: %+v", report.Results[0])
+	}
+	if len(stub.judged) != 1 || !strings.Contains(stub.judged[0], "30 day refund window") {
+		t.Fatalf("judge prompt: %v", stub.judged)
+	}
+
+	stub.judgeReply = "NO"
+	report, _ = Run(context.Background(), stub, cases, Options{JudgeTier: "judge"})
+	if report.OK() {
+		t.Fatal("NO verdict should fail the case")
+	}
+}
@@ -0,0 +1,125 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// GenerateOptions configures Generate.
+type GenerateOptions struct {
+	// Tier selects the model that writes the Q&A pairs. Empty uses the
+	// client's default.
+	Tier string
+	// Documents is how many documents to sample. Defaults to 20.
+	Documents int
+	// PairsPerDocument is how many Q&A pairs to ask for per sampled
+	// document. Defaults to 2.
+	PairsPerDocument int
+	// Seed makes the document sample reproducible; zero seeds randomly.
+	Seed int64
+}
+
+const generateSystem = "You write evaluation questions for a retrieval system. Given a document, " +
+	"produce question/answer pairs whose answers are stated in the document. Reply with one " +
+	`JSON object per line: {"question": "...", "answer": "..."}. No other text.`
+
+// Generate samples documents from collection, asks the model for Q&A
+// pairs grounded in each, and writes them to w in the eval dataset
+// format (one Case per line, the answer as an expected fact), returning
+// how many cases were written. Review the output before trusting it —
+// generated questions inherit the model's blind spots.
+func Generate(ctx context.Context, client codex.Codex, collection string, w io.Writer, opts GenerateOptions) (int, error) {
+	if opts.Documents <= 0 {
+		opts.Documents = 20
+	}
+	if opts.PairsPerDocument <= 0 {
+		opts.PairsPerDocument = 2
+	}
+
+	docs, err := sampleDocuments(ctx, client, collection, opts.Documents, opts.Seed)
+	if err != nil {
+		return 0, err
+	}
+	if len(docs) == 0 {
+		return 0, fmt.Errorf("eval: collection %q has no documents to sample", collection)
+	}
+
+	var chatOpts []codex.ChatOption
+	if opts.Tier != "" {
+		chatOpts = append(chatOpts, codex.WithTier(opts.Tier))
+	}
+
+	enc := json.NewEncoder(w)
+	written := 0
+	for _, doc := range docs {
+		reply, err := client.Chat(ctx, []codex.Message{
+			{Role: "system", Content: generateSystem},
+			{Role: "user", Content: fmt.Sprintf("Write %d pairs for this document:\n\n%s", opts.PairsPerDocument, doc.Document)},
+		}, chatOpts...)
+		if err != nil {
+			return written, err
+		}
+		for _, line := range strings.Split(reply, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || line[0] != '{' {
+				continue
+			}
+			var pair struct {
+				Question string `json:"question"`
+				Answer   string `json:"answer"`
+			}
+			if json.Unmarshal([]byte(line), &pair) != nil || pair.Question == "" || pair.Answer == "" {
+				continue
+			}
+			c := Case{
+				Question:      pair.Question,
+				ExpectedFacts: []string{pair.Answer},
+				Collection:    collection,
+			}
+			if err := enc.Encode(c); err != nil {
+				return written, err
+			}
+			written++
+		}
+	}
+	return written, nil
+}
+
+// sampleDocuments reservoir-samples n records with non-empty documents
+// from collection.
+func sampleDocuments(ctx context.Context, client codex.Codex, collection string, n int, seed int64) ([]codex.VectorRecord, error) {
+	rng := rand.New(rand.NewSource(seed))
+	if seed == 0 {
+		rng = rand.New(rand.NewSource(rand.Int63()))
+	}
+	var sample []codex.VectorRecord
+	seen := 0
+	cursor := ""
+	for {
+		recs, next, err := client.Scroll(ctx, collection, cursor, 100)
+		if err != nil {
+			return nil, err
+		}
+		for _, rec := range recs {
+			if rec.Document == "" {
+				continue
+			}
+			seen++
+			if len(sample) < n {
+				sample = append(sample, rec)
+			} else if i := rng.Intn(seen); i < n {
+				sample[i] = rec
+			}
+		}
+		if next == "" {
+			return sample, nil
+		}
+		cursor = next
+	}
+}
@@ -0,0 +1,62 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// genStub serves two pages of records and writes one pair per document.
+type genStub struct {
+	codex.Codex
+	chats int
+}
+
+func (s *genStub) Scroll(ctx context.Context, collection, cursor string, limit int) ([]codex.VectorRecord, string, error) {
+	if cursor == "" {
+		return []codex.VectorRecord{
+			{ID: "a", Document: "The refund window is 30 days."},
+			{ID: "b"}, // no document text, skipped
+		}, "p2", nil
+	}
+	return []codex.VectorRecord{
+		{ID: "c", Document: "Support is open on weekdays."},
+	}, "", nil
+}
+
+func (s *genStub) Chat(ctx context.Context, messages []codex.Message, opts ...codex.ChatOption) (string, error) {
+	s.chats++
+	doc := messages[len(messages)-1].Content
+	switch {
+	case strings.Contains(doc, "refund"):
+		return `{"question":"How long is the refund window?","answer":"30 days"}` + "\nnot json", nil
+	default:
+		return fmt.Sprintf(`{"question":"When is support open?","answer":"weekdays"}`), nil
+	}
+}
+
+func TestGenerateWritesEvalCases(t *testing.T) {
+	stub := &genStub{}
+	var out strings.Builder
+	n, err := Generate(context.Background(), stub, "kb", &out, GenerateOptions{Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 || stub.chats != 2 {
+		t.Fatalf("n=%d chats=%d", n, stub.chats)
+	}
+	cases, err := LoadJSONL(strings.NewReader(out.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) != 2 {
+		t.Fatalf("cases: %+v", cases)
+	}
+	if cases[0].Question != "How long is the refund window?" ||
+		cases[0].ExpectedFacts[0] != "30 days" || cases[0].Collection != "kb" {
+		t.Fatalf("case: %+v", cases[0])
+	}
+}
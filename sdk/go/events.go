@@ -0,0 +1,51 @@
+package codex
+
+import "encoding/json"
+
+// StreamEvent is one typed event from a streaming endpoint. The concrete
+// types are TokenEvent, ToolCallEvent, CitationEvent, UsageEvent, and
+// DoneEvent; consumers switch on the type:
+//
+//	switch ev := ev.(type) {
+//	case codex.TokenEvent:
+//		fmt.Print(ev.Token)
+//	case codex.CitationEvent:
+//		refs = append(refs, ev.Reference)
+//	}
+type StreamEvent interface {
+	streamEvent()
+}
+
+// TokenEvent carries one generated text fragment.
+type TokenEvent struct {
+	Token string `json:"token"`
+}
+
+// ToolCallEvent reports that the model requested a tool invocation.
+type ToolCallEvent struct {
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// CitationEvent attributes the surrounding output to a retrieved chunk
+// (RAG streams only).
+type CitationEvent struct {
+	Reference Reference `json:"reference"`
+}
+
+// UsageEvent carries the final token accounting for the request.
+type UsageEvent struct {
+	Usage Usage `json:"usage"`
+}
+
+// DoneEvent is the last event of a stream.
+type DoneEvent struct {
+	FinishReason string `json:"finish_reason,omitempty"`
+}
+
+func (TokenEvent) streamEvent()    {}
+func (ToolCallEvent) streamEvent() {}
+func (CitationEvent) streamEvent() {}
+func (UsageEvent) streamEvent()    {}
+func (DoneEvent) streamEvent()     {}
@@ -0,0 +1,157 @@
+package codex
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Variant is one arm of an experiment: a name, a traffic share, and the
+// RAG options that distinguish it from the control.
+type Variant struct {
+	Name string
+	// Weight is the variant's relative share of traffic; weights need
+	// not sum to anything in particular.
+	Weight float64
+	// Options are applied on top of the caller's own options.
+	Options []RAGOption
+}
+
+// VariantMetrics aggregates what happened to one variant's traffic.
+type VariantMetrics struct {
+	Calls    int64
+	Errors   int64
+	Outcomes int64
+	Positive int64
+	ScoreSum float64
+}
+
+// PositiveRate is the fraction of reported outcomes that were positive.
+func (m VariantMetrics) PositiveRate() float64 {
+	if m.Outcomes == 0 {
+		return 0
+	}
+	return float64(m.Positive) / float64(m.Outcomes)
+}
+
+// MeanScore is the average reported score across outcomes.
+func (m VariantMetrics) MeanScore() float64 {
+	if m.Outcomes == 0 {
+		return 0
+	}
+	return m.ScoreSum / float64(m.Outcomes)
+}
+
+// Experiment routes a share of RAGAnswer traffic through alternative
+// configurations and aggregates outcome metrics per variant, so RAG
+// changes (topK, reranker, tier) can be compared on live traffic before
+// they become the default.
+type Experiment struct {
+	client   Codex
+	name     string
+	variants []Variant
+	total    float64
+
+	mu      sync.Mutex
+	rng     *rand.Rand
+	metrics map[string]*VariantMetrics
+}
+
+// NewExperiment sets up an experiment over the given variants. The first
+// variant is conventionally the control.
+func NewExperiment(client Codex, name string, variants ...Variant) (*Experiment, error) {
+	if len(variants) == 0 {
+		return nil, fmt.Errorf("codex: experiment %q needs at least one variant", name)
+	}
+	e := &Experiment{
+		client:   client,
+		name:     name,
+		variants: variants,
+		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		metrics:  make(map[string]*VariantMetrics, len(variants)),
+	}
+	for _, v := range variants {
+		if v.Weight < 0 {
+			return nil, fmt.Errorf("codex: experiment %q: variant %q has negative weight", name, v.Name)
+		}
+		e.total += v.Weight
+		e.metrics[v.Name] = &VariantMetrics{}
+	}
+	if e.total == 0 {
+		return nil, fmt.Errorf("codex: experiment %q has zero total weight", name)
+	}
+	return e, nil
+}
+
+// Seed makes variant assignment deterministic, for tests.
+func (e *Experiment) Seed(seed int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rng = rand.New(rand.NewSource(seed))
+}
+
+// pick draws a variant according to the weights.
+func (e *Experiment) pick() Variant {
+	e.mu.Lock()
+	r := e.rng.Float64() * e.total
+	e.mu.Unlock()
+	for _, v := range e.variants {
+		if r < v.Weight {
+			return v
+		}
+		r -= v.Weight
+	}
+	return e.variants[len(e.variants)-1]
+}
+
+// RAGAnswer answers through a randomly drawn variant, applying its
+// options after the caller's, and tags the result with the variant name.
+func (e *Experiment) RAGAnswer(ctx context.Context, question string, opts ...RAGOption) (*Result, error) {
+	v := e.pick()
+	all := make([]RAGOption, 0, len(opts)+len(v.Options))
+	all = append(all, opts...)
+	all = append(all, v.Options...)
+	res, err := e.client.RAGAnswer(ctx, question, all...)
+	e.mu.Lock()
+	m := e.metrics[v.Name]
+	m.Calls++
+	if err != nil {
+		m.Errors++
+	}
+	e.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	res.Variant = v.Name
+	return res, nil
+}
+
+// ReportOutcome feeds a quality signal (a thumbs-up, a resolved ticket, a
+// human rating) back into the variant's metrics. Score is optional
+// context for graded signals; pass 0 when only the boolean matters.
+func (e *Experiment) ReportOutcome(variant string, positive bool, score float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	m, ok := e.metrics[variant]
+	if !ok {
+		return
+	}
+	m.Outcomes++
+	if positive {
+		m.Positive++
+	}
+	m.ScoreSum += score
+}
+
+// Metrics snapshots the per-variant aggregates.
+func (e *Experiment) Metrics() map[string]VariantMetrics {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	out := make(map[string]VariantMetrics, len(e.metrics))
+	for name, m := range e.metrics {
+		out[name] = *m
+	}
+	return out
+}
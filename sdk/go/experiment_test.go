@@ -0,0 +1,64 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestExperimentRoutesAndTags(t *testing.T) {
+	var topKs []int
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ragRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		topKs = append(topKs, req.TopK)
+		json.NewEncoder(w).Encode(Result{Answer: "ok"})
+	}))
+	e, err := NewExperiment(c, "topk-bump",
+		Variant{Name: "control", Weight: 1, Options: []RAGOption{WithTopK(5)}},
+		Variant{Name: "wide", Weight: 1, Options: []RAGOption{WithTopK(20)}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Seed(1)
+
+	counts := map[string]int{}
+	for i := 0; i < 40; i++ {
+		res, err := e.RAGAnswer(context.Background(), "q")
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts[res.Variant]++
+	}
+	if counts["control"] == 0 || counts["wide"] == 0 {
+		t.Fatalf("both variants should see traffic: %v", counts)
+	}
+	for i, k := range topKs {
+		if k != 5 && k != 20 {
+			t.Fatalf("request %d had topK %d", i, k)
+		}
+	}
+
+	e.ReportOutcome("wide", true, 0.9)
+	e.ReportOutcome("wide", false, 0.2)
+	e.ReportOutcome("nonexistent", true, 1)
+	m := e.Metrics()
+	if m["control"].Calls+m["wide"].Calls != 40 {
+		t.Fatalf("calls: %+v", m)
+	}
+	wide := m["wide"]
+	if wide.Outcomes != 2 || wide.PositiveRate() != 0.5 || wide.MeanScore() != 0.55 {
+		t.Fatalf("wide metrics: %+v", wide)
+	}
+}
+
+func TestNewExperimentValidatesWeights(t *testing.T) {
+	if _, err := NewExperiment(nil, "empty"); err == nil {
+		t.Fatal("expected error for no variants")
+	}
+	if _, err := NewExperiment(nil, "zero", Variant{Name: "a"}); err == nil {
+		t.Fatal("expected error for zero total weight")
+	}
+}
@@ -0,0 +1,47 @@
+package codex
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Explain carries the retrieval internals the server reports in explain
+// mode: how wide the candidate set was, how selective the filter proved,
+// which index path served the query, and where the time went. For RAG it
+// also includes the fully rendered prompt sent to the model.
+type Explain struct {
+	// Candidates is how many vectors were scored before the topK cut.
+	Candidates int `json:"candidates"`
+	// Filtered is how many candidates the metadata filter rejected.
+	Filtered int `json:"filtered"`
+	// FilterSelectivity is the fraction of candidates the filter admitted.
+	FilterSelectivity float64 `json:"filter_selectivity"`
+	// IndexPath names the index route taken, e.g. "hnsw/ef=128".
+	IndexPath string `json:"index_path,omitempty"`
+	// TimingsMS breaks the server-side latency down by phase.
+	TimingsMS map[string]float64 `json:"timings_ms,omitempty"`
+	// RenderedPrompt is the exact prompt the model saw (RAG only).
+	RenderedPrompt string `json:"rendered_prompt,omitempty"`
+}
+
+// QueryExplain is Query with the server's explain output alongside the
+// matches, for debugging relevance problems.
+func (c *Client) QueryExplain(ctx context.Context, collection string, vector []float32, topK int, filter Filter) ([]Match, *Explain, error) {
+	path := "/v1/collections/" + url.PathEscape(c.collection(collection)) + "/query"
+	req := queryRequest{Vector: vector, TopK: topK, Filter: filter, Explain: true}
+	var resp queryWire
+	if err := c.do(ctx, http.MethodPost, path, req, &resp); err != nil {
+		return nil, nil, err
+	}
+	if err := c.decryptMatches(resp.Matches); err != nil {
+		return nil, nil, err
+	}
+	return resp.Matches, resp.Explain, nil
+}
+
+// WithExplain asks the server to attach retrieval internals — and the
+// rendered prompt — to the RAG result; see Result.Explain.
+func WithExplain() RAGOption {
+	return func(o *RAGOptions) { o.Explain = true }
+}
@@ -0,0 +1,86 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestQueryExplain(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req queryRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if !req.Explain {
+			t.Error("explain flag not set")
+		}
+		json.NewEncoder(w).Encode(queryWire{
+			Matches: []Match{{VectorRecord: VectorRecord{ID: "a"}}},
+			Explain: &Explain{
+				Candidates:        1200,
+				Filtered:          900,
+				FilterSelectivity: 0.25,
+				IndexPath:         "hnsw/ef=128",
+				TimingsMS:         map[string]float64{"scan": 3.1},
+			},
+		})
+	}))
+	matches, explain, err := c.QueryExplain(context.Background(), "kb", []float32{1}, 5, Filter{"lang": "en"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || explain == nil {
+		t.Fatalf("matches %d, explain %v", len(matches), explain)
+	}
+	if explain.Candidates != 1200 || explain.IndexPath != "hnsw/ef=128" || explain.TimingsMS["scan"] != 3.1 {
+		t.Fatalf("explain: %+v", explain)
+	}
+}
+
+func TestRAGAnswerWithExplain(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ragRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if !req.Explain {
+			t.Error("explain flag not set")
+		}
+		json.NewEncoder(w).Encode(Result{
+			Answer:  "42",
+			Explain: &Explain{RenderedPrompt: "Context:\n[1] doc\n\nQuestion: x"},
+		})
+	}))
+	res, err := c.RAGAnswer(context.Background(), "x", WithExplain())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Explain == nil || res.Explain.RenderedPrompt == "" {
+		t.Fatalf("explain missing: %+v", res)
+	}
+}
+
+func TestRAGAnswerWithTrace(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ragRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if !req.Trace {
+			t.Error("trace flag not set")
+		}
+		json.NewEncoder(w).Encode(Result{
+			Answer: "42",
+			Trace: &Trace{
+				Retrieved:   []Match{{VectorRecord: VectorRecord{ID: "a"}, Score: 0.9}},
+				Reranking:   []RerankDecision{{ID: "a", From: 0, To: 0, Score: 0.95}},
+				Prompt:      "Context...",
+				StageTokens: map[string]int{"prompt": 120, "completion": 8},
+			},
+		})
+	}))
+	res, err := c.RAGAnswer(context.Background(), "x", WithTrace())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := res.Trace
+	if tr == nil || len(tr.Retrieved) != 1 || tr.Reranking[0].Score != 0.95 || tr.StageTokens["prompt"] != 120 {
+		t.Fatalf("trace: %+v", tr)
+	}
+}
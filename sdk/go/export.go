@@ -0,0 +1,158 @@
+package codex
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ExportFormat selects the encoding Export writes.
+type ExportFormat string
+
+const (
+	// ExportJSONL writes one JSON object per record per line.
+	ExportJSONL ExportFormat = "jsonl"
+	// ExportCSV writes a header row followed by one row per record.
+	ExportCSV ExportFormat = "csv"
+)
+
+// ExportOptions controls what Export writes.
+type ExportOptions struct {
+	// Collection to export; defaults to the client's default collection.
+	Collection string
+	// Format of the output; defaults to ExportJSONL.
+	Format ExportFormat
+	// Fields selects columns: "id", "document", "metadata", "vector",
+	// or "metadata.<key>" for a single metadata value. Defaults to id,
+	// document, and metadata — vectors are only included when asked for.
+	Fields []string
+	// Filter restricts which records are exported.
+	Filter Filter
+	// PageSize is how many records are scrolled per round; defaults to
+	// the usual page size.
+	PageSize int
+}
+
+// Export streams a collection's records to w for auditing or lightweight
+// backups, scrolling page by page so the collection never sits in memory.
+// It returns the number of records written.
+func (c *Client) Export(ctx context.Context, w io.Writer, opts ExportOptions) (int, error) {
+	fields := opts.Fields
+	if len(fields) == 0 {
+		fields = []string{"id", "document", "metadata"}
+	}
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	var write func([]VectorRecord) error
+	var flush func() error
+	switch opts.Format {
+	case ExportCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(fields); err != nil {
+			return 0, fmt.Errorf("codex: export: %w", err)
+		}
+		write = func(recs []VectorRecord) error {
+			row := make([]string, len(fields))
+			for _, rec := range recs {
+				for i, f := range fields {
+					row[i] = exportCell(rec, f)
+				}
+				if err := cw.Write(row); err != nil {
+					return fmt.Errorf("codex: export: %w", err)
+				}
+			}
+			return nil
+		}
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	case ExportJSONL, "":
+		enc := json.NewEncoder(w)
+		write = func(recs []VectorRecord) error {
+			for _, rec := range recs {
+				obj := make(map[string]any, len(fields))
+				for _, f := range fields {
+					obj[f] = exportValue(rec, f)
+				}
+				if err := enc.Encode(obj); err != nil {
+					return fmt.Errorf("codex: export: %w", err)
+				}
+			}
+			return nil
+		}
+		flush = func() error { return nil }
+	default:
+		return 0, fmt.Errorf("codex: export: unknown format %q", opts.Format)
+	}
+
+	n := 0
+	cursor := ""
+	for {
+		recs, next, err := c.scrollPage(ctx, opts.Collection, cursor, pageSize, opts.Filter)
+		if err != nil {
+			return n, fmt.Errorf("codex: export: scroll: %w", err)
+		}
+		if err := write(recs); err != nil {
+			return n, err
+		}
+		n += len(recs)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	return n, flush()
+}
+
+// exportValue resolves a field selector against a record.
+func exportValue(rec VectorRecord, field string) any {
+	switch field {
+	case "id":
+		return rec.ID
+	case "document":
+		return rec.Document
+	case "metadata":
+		return rec.Metadata
+	case "vector":
+		return rec.Vector
+	}
+	if key, ok := strings.CutPrefix(field, "metadata."); ok {
+		return rec.Metadata[key]
+	}
+	return nil
+}
+
+// exportCell renders a field selector as a CSV cell.
+func exportCell(rec VectorRecord, field string) string {
+	v := exportValue(rec, field)
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []float32:
+		if len(v) == 0 {
+			return ""
+		}
+		js, _ := json.Marshal(v)
+		return string(js)
+	default:
+		js, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(js)
+	}
+}
@@ -0,0 +1,185 @@
+// Package arrowio streams codex vector records into Apache Arrow IPC
+// and Parquet files, with vectors as fixed-size list columns, so
+// collections can be analyzed directly in pandas, polars, or duckdb.
+//
+// It lives in its own module so the core SDK stays dependency-free;
+// only exports pull in the Arrow libraries.
+package arrowio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/ipc"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/apache/arrow/go/v14/parquet"
+	"github.com/apache/arrow/go/v14/parquet/compress"
+	"github.com/apache/arrow/go/v14/parquet/pqarrow"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// Schema returns the Arrow schema used for exported records: id and
+// document as strings, metadata as a JSON string, and the vector as a
+// fixed-size list of float32 with dims elements.
+func Schema(dims int) *arrow.Schema {
+	return arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.BinaryTypes.String},
+		{Name: "document", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "metadata", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "vector", Type: arrow.FixedSizeListOf(int32(dims), arrow.PrimitiveTypes.Float32), Nullable: true},
+	}, nil)
+}
+
+// batchWriter is the part shared by the IPC and Parquet writers.
+type batchWriter interface {
+	Write(arrow.Record) error
+	Close() error
+}
+
+// Writer streams batches of records into an Arrow IPC stream or a
+// Parquet file. Write may be called any number of times; Close finishes
+// the file and must be called for the output to be readable.
+type Writer struct {
+	schema  *arrow.Schema
+	dims    int
+	out     batchWriter
+	builder *array.RecordBuilder
+}
+
+// NewIPCWriter starts an Arrow IPC stream on w for vectors of the given
+// dimensionality.
+func NewIPCWriter(w io.Writer, dims int) *Writer {
+	schema := Schema(dims)
+	return &Writer{
+		schema:  schema,
+		dims:    dims,
+		out:     ipc.NewWriter(w, ipc.WithSchema(schema)),
+		builder: array.NewRecordBuilder(memory.DefaultAllocator, schema),
+	}
+}
+
+// NewParquetWriter starts a Parquet file on w for vectors of the given
+// dimensionality.
+func NewParquetWriter(w io.Writer, dims int) (*Writer, error) {
+	schema := Schema(dims)
+	fw, err := pqarrow.NewFileWriter(schema, w,
+		parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Snappy)),
+		pqarrow.DefaultWriterProps())
+	if err != nil {
+		return nil, fmt.Errorf("arrowio: open parquet writer: %w", err)
+	}
+	return &Writer{schema: schema, dims: dims, out: fw, builder: array.NewRecordBuilder(memory.DefaultAllocator, schema)}, nil
+}
+
+// Write appends one batch of records as a record batch.
+func (w *Writer) Write(recs []codex.VectorRecord) error {
+	if len(recs) == 0 {
+		return nil
+	}
+	ids := w.builder.Field(0).(*array.StringBuilder)
+	docs := w.builder.Field(1).(*array.StringBuilder)
+	meta := w.builder.Field(2).(*array.StringBuilder)
+	vecs := w.builder.Field(3).(*array.FixedSizeListBuilder)
+	floats := vecs.ValueBuilder().(*array.Float32Builder)
+
+	for _, rec := range recs {
+		ids.Append(rec.ID)
+		if rec.Document == "" {
+			docs.AppendNull()
+		} else {
+			docs.Append(rec.Document)
+		}
+		if len(rec.Metadata) == 0 {
+			meta.AppendNull()
+		} else {
+			js, err := json.Marshal(rec.Metadata)
+			if err != nil {
+				return fmt.Errorf("arrowio: encode metadata for %q: %w", rec.ID, err)
+			}
+			meta.Append(string(js))
+		}
+		if len(rec.Vector) == 0 {
+			vecs.AppendNull()
+		} else if len(rec.Vector) != w.dims {
+			return fmt.Errorf("arrowio: record %q has %d dimensions, schema has %d", rec.ID, len(rec.Vector), w.dims)
+		} else {
+			vecs.Append(true)
+			floats.AppendValues(rec.Vector, nil)
+		}
+	}
+
+	batch := w.builder.NewRecord()
+	defer batch.Release()
+	return w.out.Write(batch)
+}
+
+// Close finishes the stream or file.
+func (w *Writer) Close() error {
+	w.builder.Release()
+	return w.out.Close()
+}
+
+// Format selects the output encoding for Export.
+type Format int
+
+const (
+	// IPC writes an Arrow IPC stream (.arrows).
+	IPC Format = iota
+	// Parquet writes a Parquet file.
+	Parquet
+)
+
+// Export scrolls an entire collection out of the client and writes it to
+// w in the requested format, batch by batch, without holding the
+// collection in memory. The vector dimensionality is taken from the
+// collection's listing.
+func Export(ctx context.Context, c *codex.Client, collection string, w io.Writer, format Format) error {
+	cols, err := c.ListCollections(ctx)
+	if err != nil {
+		return fmt.Errorf("arrowio: list collections: %w", err)
+	}
+	dims := 0
+	for _, col := range cols {
+		if col.Name == collection {
+			dims = col.Dimensions
+		}
+	}
+	if dims == 0 {
+		return fmt.Errorf("arrowio: collection %q not found", collection)
+	}
+
+	var out *Writer
+	switch format {
+	case IPC:
+		out = NewIPCWriter(w, dims)
+	case Parquet:
+		out, err = NewParquetWriter(w, dims)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("arrowio: unknown format %d", format)
+	}
+
+	const pageSize = 1000
+	cursor := ""
+	for {
+		recs, next, err := c.Scroll(ctx, collection, cursor, pageSize)
+		if err != nil {
+			return fmt.Errorf("arrowio: scroll: %w", err)
+		}
+		if err := out.Write(recs); err != nil {
+			return err
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	return out.Close()
+}
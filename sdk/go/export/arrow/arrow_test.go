@@ -0,0 +1,142 @@
+package arrowio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/ipc"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/apache/arrow/go/v14/parquet/file"
+	"github.com/apache/arrow/go/v14/parquet/pqarrow"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+var sample = []codex.VectorRecord{
+	{ID: "a", Vector: []float32{1, 2}, Document: "doc a", Metadata: map[string]any{"lang": "en"}},
+	{ID: "b", Vector: []float32{3, 4}},
+}
+
+func TestIPCRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewIPCWriter(&buf, 2)
+	if err := w.Write(sample); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := ipc.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+	if !r.Next() {
+		t.Fatal("no record batch")
+	}
+	rec := r.Record()
+	if rec.NumRows() != 2 {
+		t.Fatalf("rows: %d", rec.NumRows())
+	}
+	ids := rec.Column(0).(*array.String)
+	if ids.Value(0) != "a" || ids.Value(1) != "b" {
+		t.Fatalf("ids: %v", ids)
+	}
+	docs := rec.Column(1).(*array.String)
+	if docs.Value(0) != "doc a" || !docs.IsNull(1) {
+		t.Fatalf("docs: %v", docs)
+	}
+	vecs := rec.Column(3).(*array.FixedSizeList)
+	floats := vecs.ListValues().(*array.Float32)
+	if floats.Value(0) != 1 || floats.Value(3) != 4 {
+		t.Fatalf("vectors: %v", floats)
+	}
+}
+
+func TestParquetRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewParquetWriter(&buf, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(sample); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	pf, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pf.Close()
+	fr, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table, err := fr.ReadTable(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer table.Release()
+	if table.NumRows() != 2 || table.NumCols() != 4 {
+		t.Fatalf("table shape: %dx%d", table.NumRows(), table.NumCols())
+	}
+	meta := table.Column(2).Data().Chunk(0).(*array.String)
+	if !strings.Contains(meta.Value(0), `"lang":"en"`) {
+		t.Fatalf("metadata: %q", meta.Value(0))
+	}
+}
+
+func TestExportScrollsCollection(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/collections":
+			json.NewEncoder(w).Encode(map[string]any{
+				"collections": []codex.Collection{{Name: "kb", Dimensions: 2}},
+			})
+		case strings.HasSuffix(r.URL.Path, "/scroll"):
+			var req struct {
+				Cursor string `json:"cursor"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Cursor == "" {
+				json.NewEncoder(w).Encode(map[string]any{"records": sample[:1], "next_cursor": "p2"})
+			} else {
+				json.NewEncoder(w).Encode(map[string]any{"records": sample[1:]})
+			}
+		default:
+			t.Errorf("unexpected call: %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	c, err := codex.New(codex.WithBaseURL(srv.URL), codex.WithAPIKey("k"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := Export(context.Background(), c, "kb", &buf, IPC); err != nil {
+		t.Fatal(err)
+	}
+	r, err := ipc.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+	rows := int64(0)
+	for r.Next() {
+		rows += r.Record().NumRows()
+	}
+	if rows != 2 {
+		t.Fatalf("exported %d rows", rows)
+	}
+}
@@ -0,0 +1,73 @@
+package codex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestExportJSONLExcludesVectorsByDefault(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req scrollRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Filter["lang"] != "en" {
+			t.Errorf("filter not forwarded: %v", req.Filter)
+		}
+		json.NewEncoder(w).Encode(scrollWire{Records: []VectorRecord{
+			{ID: "a", Vector: []float32{1}, Document: "doc a", Metadata: map[string]any{"lang": "en"}},
+		}})
+	}))
+	var buf bytes.Buffer
+	n, err := c.Export(context.Background(), &buf, ExportOptions{
+		Collection: "kb",
+		Filter:     Filter{"lang": "en"},
+	})
+	if err != nil || n != 1 {
+		t.Fatalf("exported %d, err %v", n, err)
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &obj); err != nil {
+		t.Fatal(err)
+	}
+	if obj["id"] != "a" || obj["document"] != "doc a" {
+		t.Fatalf("bad line: %v", obj)
+	}
+	if _, ok := obj["vector"]; ok {
+		t.Fatal("vector exported without being selected")
+	}
+}
+
+func TestExportCSVWithFieldSelection(t *testing.T) {
+	pages := map[string]scrollWire{
+		"": {Records: []VectorRecord{
+			{ID: "a", Metadata: map[string]any{"lang": "en"}},
+		}, NextCursor: "p2"},
+		"p2": {Records: []VectorRecord{
+			{ID: "b", Vector: []float32{1, 2}},
+		}},
+	}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req scrollRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		json.NewEncoder(w).Encode(pages[req.Cursor])
+	}))
+	var buf bytes.Buffer
+	n, err := c.Export(context.Background(), &buf, ExportOptions{
+		Collection: "kb",
+		Format:     ExportCSV,
+		Fields:     []string{"id", "metadata.lang", "vector"},
+	})
+	if err != nil || n != 2 {
+		t.Fatalf("exported %d, err %v", n, err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || lines[0] != "id,metadata.lang,vector" {
+		t.Fatalf("csv: %q", lines)
+	}
+	if lines[1] != "a,en," || lines[2] != `b,,"[1,2]"` {
+		t.Fatalf("rows: %q", lines[1:])
+	}
+}
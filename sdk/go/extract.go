@@ -0,0 +1,134 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Extraction is the outcome of Extract: the typed value plus, per
+// populated field, which retrieved document it came from.
+type Extraction[T any] struct {
+	Value T
+	// Citations maps json field names to the reference supporting them.
+	Citations map[string]Reference
+	// References lists every retrieved document, cited or not.
+	References []Reference
+}
+
+// Extract retrieves the documents matching query (restricted by filter,
+// which may be nil) and extracts T's fields from them with per-field
+// source citations. The schema string describes the fields to the model;
+// pass "" to derive it from T's json tags. The collection and tier are
+// the client's defaults.
+//
+//	sheet, err := codex.Extract[CompanySheet](ctx, c, "Acme Corp financials", "", nil)
+func Extract[T any](ctx context.Context, c *Client, query, schema string, filter Filter) (*Extraction[T], error) {
+	if schema == "" {
+		schema = schemaFromType(reflect.TypeOf((*T)(nil)).Elem())
+	}
+
+	vec, err := c.Embed(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := c.Query(ctx, "", vec, 8, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString("Extract the following fields from the documents. Reply with only a JSON object " +
+		`of the form {"value": {...}, "citations": {"field": <document number>}}. ` +
+		"Omit fields the documents do not establish.\n\nFields:\n")
+	b.WriteString(schema)
+	b.WriteString("\n\nDocuments:\n")
+	for i, m := range matches {
+		fmt.Fprintf(&b, "[%d] %s\n", i+1, m.Document)
+	}
+	fmt.Fprintf(&b, "\nQuery: %s\n", query)
+
+	reply, err := c.Chat(ctx, []Message{{Role: "user", Content: b.String()}})
+	if err != nil {
+		return nil, err
+	}
+
+	var wire struct {
+		Value     json.RawMessage `json:"value"`
+		Citations map[string]int  `json:"citations"`
+	}
+	if err := json.Unmarshal(extractJSON(reply), &wire); err != nil {
+		return nil, fmt.Errorf("codex: extraction reply is not the expected JSON: %w", err)
+	}
+	out := &Extraction[T]{Citations: map[string]Reference{}}
+	if len(wire.Value) > 0 {
+		if err := json.Unmarshal(wire.Value, &out.Value); err != nil {
+			return nil, fmt.Errorf("codex: extracted value does not fit %T: %w", out.Value, err)
+		}
+	}
+	for _, m := range matches {
+		out.References = append(out.References, Reference{
+			ID: m.ID, Document: m.Document, Score: m.Score, Metadata: m.Metadata,
+		})
+	}
+	for field, n := range wire.Citations {
+		if n >= 1 && n <= len(out.References) {
+			out.Citations[field] = out.References[n-1]
+		}
+	}
+	return out, nil
+}
+
+// schemaFromType renders a struct's json fields as one "name (type)"
+// line each, for the extraction prompt.
+func schemaFromType(t reflect.Type) string {
+	if t.Kind() != reflect.Struct {
+		return t.String()
+	}
+	var lines []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		lines = append(lines, "- "+name+" ("+f.Type.String()+")")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// extractJSON returns the first top-level JSON object in reply, so
+// models that wrap their answer in prose or fences still parse.
+func extractJSON(reply string) []byte {
+	start := strings.IndexByte(reply, '{')
+	if start < 0 {
+		return []byte(reply)
+	}
+	depth := 0
+	inString := false
+	for i := start; i < len(reply); i++ {
+		switch reply[i] {
+		case '"':
+			if i == 0 || reply[i-1] != '\\' {
+				inString = !inString
+			}
+		case '{':
+			if !inString {
+				depth++
+			}
+		case '}':
+			if !inString {
+				depth--
+				if depth == 0 {
+					return []byte(reply[start : i+1])
+				}
+			}
+		}
+	}
+	return []byte(reply[start:])
+}
@@ -0,0 +1,63 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type companySheet struct {
+	Founded int    `json:"founded"`
+	CEO     string `json:"ceo"`
+	HQ      string `json:"hq,omitempty"`
+}
+
+func TestExtractTypedFieldsWithCitations(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/embed":
+			json.NewEncoder(w).Encode(embedWire{Vectors: [][]float32{{1, 0}}})
+		case strings.HasSuffix(r.URL.Path, "/query"):
+			json.NewEncoder(w).Encode(queryWire{Matches: []Match{
+				{VectorRecord: VectorRecord{ID: "a", Document: "Acme was founded in 1907."}, Score: 0.9},
+				{VectorRecord: VectorRecord{ID: "b", Document: "Jo Smith is Acme's CEO."}, Score: 0.8},
+			}})
+		case r.URL.Path == "/v1/chat":
+			var req chatRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			prompt := req.Messages[0].Content
+			if !strings.Contains(prompt, "- founded (int)") || !strings.Contains(prompt, "- ceo (string)") {
+				t.Errorf("derived schema missing: %s", prompt)
+			}
+			json.NewEncoder(w).Encode(ChatResponse{
+				Reply: "Here you go:\n" + `{"value": {"founded": 1907, "ceo": "Jo Smith"}, "citations": {"founded": 1, "ceo": 2}}`,
+			})
+		default:
+			t.Errorf("path = %s", r.URL.Path)
+		}
+	}), WithDefaultCollection("kb"))
+
+	got, err := Extract[companySheet](context.Background(), c, "Acme Corp", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Value.Founded != 1907 || got.Value.CEO != "Jo Smith" || got.Value.HQ != "" {
+		t.Fatalf("value: %+v", got.Value)
+	}
+	if got.Citations["founded"].ID != "a" || got.Citations["ceo"].ID != "b" {
+		t.Fatalf("citations: %+v", got.Citations)
+	}
+	if len(got.References) != 2 {
+		t.Fatalf("references: %+v", got.References)
+	}
+}
+
+func TestExtractJSONUnwrapsProse(t *testing.T) {
+	got := extractJSON("Sure! {\"value\": {\"a\": \"b}\"}} trailing")
+	var v map[string]any
+	if err := json.Unmarshal(got, &v); err != nil {
+		t.Fatalf("unmarshal %s: %v", got, err)
+	}
+}
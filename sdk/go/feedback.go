@@ -0,0 +1,34 @@
+package codex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Rating is a user's verdict on an answer.
+type Rating string
+
+const (
+	// RatingUp is a thumbs-up.
+	RatingUp Rating = "up"
+	// RatingDown is a thumbs-down.
+	RatingDown Rating = "down"
+)
+
+type feedbackRequest struct {
+	RequestID string `json:"request_id"`
+	Rating    Rating `json:"rating"`
+	Comment   string `json:"comment,omitempty"`
+}
+
+// SubmitFeedback posts a thumbs-up/down — and optionally a correction or
+// comment — for the answer served under requestID, closing the quality
+// loop from the application back to the serving team.
+func (c *Client) SubmitFeedback(ctx context.Context, requestID string, rating Rating, comment string) error {
+	if requestID == "" {
+		return errors.New("codex: feedback needs the original request ID")
+	}
+	req := feedbackRequest{RequestID: requestID, Rating: rating, Comment: comment}
+	return c.do(ctx, http.MethodPost, "/v1/feedback", req, nil)
+}
@@ -0,0 +1,29 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestSubmitFeedback(t *testing.T) {
+	var got feedbackRequest
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/feedback" {
+			t.Errorf("path: %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+	}))
+	err := c.SubmitFeedback(context.Background(), "req-123", RatingDown, "cited the wrong doc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.RequestID != "req-123" || got.Rating != RatingDown || got.Comment != "cited the wrong doc" {
+		t.Fatalf("request: %+v", got)
+	}
+
+	if err := c.SubmitFeedback(context.Background(), "", RatingUp, ""); err == nil {
+		t.Fatal("expected error for missing request ID")
+	}
+}
@@ -0,0 +1,102 @@
+package codex
+
+import "time"
+
+// Field starts a filter expression on a metadata field; see F.
+type Field string
+
+// F begins a fluent filter on the named metadata field:
+//
+//	F("lang").Eq("en").And(F("ts").After(cutoff))
+//
+// The result serializes to the same Filter JSON the server accepts, so
+// built expressions and hand-written maps are interchangeable.
+func F(name string) Field { return Field(name) }
+
+// Expr is a composable filter expression. The zero value matches
+// everything (it serializes to a nil Filter).
+type Expr struct {
+	f Filter
+}
+
+// Eq matches records whose field equals v.
+func (f Field) Eq(v any) Expr { return Expr{Filter{string(f): v}} }
+
+// Ne matches records whose field does not equal v.
+func (f Field) Ne(v any) Expr { return f.op("$ne", v) }
+
+// Gt matches records whose field is greater than v.
+func (f Field) Gt(v any) Expr { return f.op("$gt", v) }
+
+// Gte matches records whose field is greater than or equal to v.
+func (f Field) Gte(v any) Expr { return f.op("$gte", v) }
+
+// Lt matches records whose field is less than v.
+func (f Field) Lt(v any) Expr { return f.op("$lt", v) }
+
+// Lte matches records whose field is less than or equal to v.
+func (f Field) Lte(v any) Expr { return f.op("$lte", v) }
+
+// In matches records whose field equals any of vals.
+func (f Field) In(vals ...any) Expr { return f.op("$in", vals) }
+
+// Exists matches records that have the field at all.
+func (f Field) Exists() Expr { return f.op("$exists", true) }
+
+// Contains matches records whose string field contains substr.
+func (f Field) Contains(substr string) Expr { return f.op("$contains", substr) }
+
+// After matches time fields strictly later than t.
+func (f Field) After(t time.Time) Expr { return f.op("$gt", t.Format(time.RFC3339)) }
+
+// Before matches time fields strictly earlier than t.
+func (f Field) Before(t time.Time) Expr { return f.op("$lt", t.Format(time.RFC3339)) }
+
+func (f Field) op(op string, v any) Expr {
+	return Expr{Filter{string(f): map[string]any{op: v}}}
+}
+
+// And matches records satisfying e and every expression in others.
+func (e Expr) And(others ...Expr) Expr { return combine("$and", e, others) }
+
+// Or matches records satisfying e or any expression in others.
+func (e Expr) Or(others ...Expr) Expr { return combine("$or", e, others) }
+
+// Not inverts an expression.
+func Not(e Expr) Expr {
+	if e.f == nil {
+		return Expr{}
+	}
+	return Expr{Filter{"$not": e.f}}
+}
+
+// combine flattens chained calls of the same operator so
+// a.And(b).And(c) serializes as one three-clause $and.
+func combine(op string, e Expr, others []Expr) Expr {
+	clauses := make([]Filter, 0, len(others)+1)
+	add := func(x Expr) {
+		if x.f == nil {
+			return
+		}
+		if nested, ok := x.f[op].([]Filter); ok && len(x.f) == 1 {
+			clauses = append(clauses, nested...)
+			return
+		}
+		clauses = append(clauses, x.f)
+	}
+	add(e)
+	for _, o := range others {
+		add(o)
+	}
+	switch len(clauses) {
+	case 0:
+		return Expr{}
+	case 1:
+		return Expr{clauses[0]}
+	}
+	return Expr{Filter{op: clauses}}
+}
+
+// Filter serializes the expression to the server's filter JSON. A zero
+// expression yields nil, meaning no filtering.
+func (e Expr) Filter() Filter { return e.f }
@@ -0,0 +1,48 @@
+package codex
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func filterJSON(t *testing.T, e Expr) string {
+	t.Helper()
+	data, err := json.Marshal(e.Filter())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestFilterBuilder(t *testing.T) {
+	cutoff := time.Date(2026, 8, 30, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name string
+		expr Expr
+		want string
+	}{
+		{"eq matches plain map form", F("lang").Eq("en"), `{"lang":"en"}`},
+		{"comparison", F("views").Gte(100), `{"views":{"$gte":100}}`},
+		{"in", F("lang").In("en", "de"), `{"lang":{"$in":["en","de"]}}`},
+		{"time", F("ts").After(cutoff), `{"ts":{"$gt":"2026-08-30T00:00:00Z"}}`},
+		{
+			"and flattens",
+			F("lang").Eq("en").And(F("views").Gt(10)).And(F("draft").Eq(false)),
+			`{"$and":[{"lang":"en"},{"views":{"$gt":10}},{"draft":false}]}`,
+		},
+		{
+			"or",
+			F("lang").Eq("en").Or(F("lang").Eq("de")),
+			`{"$or":[{"lang":"en"},{"lang":"de"}]}`,
+		},
+		{"not", Not(F("draft").Eq(true)), `{"$not":{"draft":true}}`},
+		{"zero expr is nil", Expr{}, `null`},
+		{"and with zero collapses", Expr{}.And(F("lang").Eq("en")), `{"lang":"en"}`},
+	}
+	for _, tc := range cases {
+		if got := filterJSON(t, tc.expr); got != tc.want {
+			t.Errorf("%s: got %s, want %s", tc.name, got, tc.want)
+		}
+	}
+}
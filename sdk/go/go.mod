@@ -0,0 +1,3 @@
+module github.com/hackall360/codex-extended/sdk/go
+
+go 1.21
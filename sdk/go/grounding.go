@@ -0,0 +1,135 @@
+package codex
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// WithGroundingCheck enables a post-generation verification pass: after
+// the answer is generated, a cheap tier checks each answer sentence
+// against the retrieved contexts, and the result carries a
+// GroundingReport flagging unsupported claims. The check runs
+// client-side and costs one extra chat call.
+func WithGroundingCheck() RAGOption {
+	return func(o *RAGOptions) { o.GroundingCheck = true }
+}
+
+// WithGroundingStrip is WithGroundingCheck plus removal: sentences the
+// verifier flags as unsupported are stripped from the answer text (they
+// remain listed in the report).
+func WithGroundingStrip() RAGOption {
+	return func(o *RAGOptions) {
+		o.GroundingCheck = true
+		o.GroundingStrip = true
+	}
+}
+
+// WithGroundingTier selects the tier that runs the verification pass.
+// Defaults to the client's default tier.
+func WithGroundingTier(tier string) RAGOption {
+	return func(o *RAGOptions) { o.GroundingTier = tier }
+}
+
+// GroundingReport is the outcome of the verification pass.
+type GroundingReport struct {
+	// Score is the fraction of answer sentences supported by the
+	// retrieved contexts, in [0, 1].
+	Score float64 `json:"score"`
+	// Unsupported lists the sentences the verifier could not ground.
+	Unsupported []string `json:"unsupported,omitempty"`
+}
+
+const groundingSystem = "You are a strict fact checker. For each numbered claim, decide whether it is " +
+	"directly supported by the provided context. Reply with one line per claim, in order, " +
+	"containing only the claim number and SUPPORTED or UNSUPPORTED."
+
+// verifyGrounding checks each answer sentence against the result's
+// references and attaches a GroundingReport, optionally stripping
+// unsupported sentences from the answer.
+func (c *Client) verifyGrounding(ctx context.Context, res *Result, o RAGOptions) error {
+	sentences := splitSentences(res.Answer)
+	if len(sentences) == 0 || len(res.References) == 0 {
+		res.Grounding = &GroundingReport{Score: 0, Unsupported: sentences}
+		return nil
+	}
+
+	var b strings.Builder
+	b.WriteString("Context:\n")
+	for _, ref := range res.References {
+		b.WriteString(ref.Document)
+		b.WriteString("\n\n")
+	}
+	b.WriteString("Claims:\n")
+	for i, s := range sentences {
+		b.WriteString(strconv.Itoa(i+1) + ". " + s + "\n")
+	}
+
+	var chatOpts []ChatOption
+	if o.GroundingTier != "" {
+		chatOpts = append(chatOpts, WithTier(o.GroundingTier))
+	}
+	verdicts, err := c.Chat(ctx, []Message{
+		{Role: "system", Content: groundingSystem},
+		{Role: "user", Content: b.String()},
+	}, chatOpts...)
+	if err != nil {
+		return err
+	}
+
+	unsupported := map[int]bool{}
+	for _, line := range strings.Split(verdicts, "\n") {
+		line = strings.ToUpper(strings.TrimSpace(line))
+		if line == "" {
+			continue
+		}
+		n := 0
+		for len(line) > 0 && line[0] >= '0' && line[0] <= '9' {
+			n = n*10 + int(line[0]-'0')
+			line = line[1:]
+		}
+		if n >= 1 && n <= len(sentences) && strings.Contains(line, "UNSUPPORTED") {
+			unsupported[n-1] = true
+		}
+	}
+
+	report := &GroundingReport{}
+	var kept []string
+	for i, s := range sentences {
+		if unsupported[i] {
+			report.Unsupported = append(report.Unsupported, s)
+			continue
+		}
+		kept = append(kept, s)
+	}
+	report.Score = float64(len(kept)) / float64(len(sentences))
+	res.Grounding = report
+	if o.GroundingStrip {
+		res.Answer = strings.Join(kept, " ")
+	}
+	return nil
+}
+
+// splitSentences breaks text on sentence-ending punctuation followed by
+// whitespace. It is deliberately simple; the verifier tolerates a claim
+// spanning two fragments.
+func splitSentences(text string) []string {
+	var out []string
+	start := 0
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '.', '!', '?':
+			if i+1 == len(runes) || runes[i+1] == ' ' || runes[i+1] == '\n' {
+				if s := strings.TrimSpace(string(runes[start : i+1])); s != "" {
+					out = append(out, s)
+				}
+				start = i + 1
+			}
+		}
+	}
+	if s := strings.TrimSpace(string(runes[start:])); s != "" {
+		out = append(out, s)
+	}
+	return out
+}
@@ -0,0 +1,77 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func groundingHandler(t *testing.T, verdicts string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/rag/answer":
+			json.NewEncoder(w).Encode(Result{
+				Answer: "Go has generics. Go was released in 1985.",
+				References: []Reference{
+					{ID: "ref", Document: "Go 1.18 introduced generics. Go was first released in 2009."},
+				},
+			})
+		case "/v1/chat":
+			var req chatRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Tier != "fast" {
+				t.Errorf("tier = %q", req.Tier)
+			}
+			json.NewEncoder(w).Encode(ChatResponse{Reply: verdicts})
+		default:
+			t.Errorf("path = %s", r.URL.Path)
+		}
+	})
+}
+
+func TestGroundingCheckFlagsUnsupported(t *testing.T) {
+	c := newTestClient(t, groundingHandler(t, "1. SUPPORTED\n2. UNSUPPORTED"))
+	res, err := c.RAGAnswer(context.Background(), "q",
+		WithGroundingCheck(), WithGroundingTier("fast"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Grounding == nil {
+		t.Fatal("no grounding report")
+	}
+	if res.Grounding.Score != 0.5 {
+		t.Fatalf("score = %v", res.Grounding.Score)
+	}
+	if len(res.Grounding.Unsupported) != 1 || res.Grounding.Unsupported[0] != "Go was released in 1985." {
+		t.Fatalf("unsupported: %v", res.Grounding.Unsupported)
+	}
+	if res.Answer != "Go has generics. Go was released in 1985." {
+		t.Fatalf("answer changed without strip: %q", res.Answer)
+	}
+}
+
+func TestGroundingStripRemovesUnsupported(t *testing.T) {
+	c := newTestClient(t, groundingHandler(t, "1 SUPPORTED\n2 UNSUPPORTED"))
+	res, err := c.RAGAnswer(context.Background(), "q",
+		WithGroundingStrip(), WithGroundingTier("fast"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Answer != "Go has generics." {
+		t.Fatalf("answer = %q", res.Answer)
+	}
+}
+
+func TestSplitSentences(t *testing.T) {
+	got := splitSentences("One. Two! Is three? Ends without punctuation")
+	want := []string{"One.", "Two!", "Is three?", "Ends without punctuation"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v", got)
+		}
+	}
+}
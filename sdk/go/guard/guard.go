@@ -0,0 +1,129 @@
+// Package guard validates model output against composable rules (JSON
+// schema, regex, allowlists) and can reject, redact, or automatically
+// repair failing responses with a follow-up round trip.
+package guard
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// Violation is one failed validation.
+type Violation struct {
+	Validator string
+	Message   string
+}
+
+// Validator checks one property of a model output.
+type Validator interface {
+	Name() string
+	Validate(output string) []Violation
+}
+
+// Redactor is an optional extension: validators that can scrub their own
+// violations out of the output implement it, enabling PolicyRedact.
+type Redactor interface {
+	Redact(output string) string
+}
+
+// Policy selects what happens when validation fails.
+type Policy int
+
+const (
+	// PolicyReject returns a *ValidationError.
+	PolicyReject Policy = iota
+	// PolicyRedact scrubs violations using validators that support
+	// redaction, then rejects if violations remain.
+	PolicyRedact
+	// PolicyRepair sends the output and the violation list back to the
+	// model for a corrected response, up to Guard.MaxRepairs rounds.
+	PolicyRepair
+)
+
+// ValidationError reports output that failed validation (after any
+// redaction or repair the policy allowed).
+type ValidationError struct {
+	Output     string
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.Validator + ": " + v.Message
+	}
+	return "guard: output failed validation: " + strings.Join(msgs, "; ")
+}
+
+// Guard is a composable set of validators with a failure policy.
+type Guard struct {
+	Validators []Validator
+	Policy     Policy
+	// MaxRepairs bounds repair round trips. Defaults to 2.
+	MaxRepairs int
+}
+
+// Check runs every validator and returns all violations.
+func (g *Guard) Check(output string) []Violation {
+	var all []Violation
+	for _, v := range g.Validators {
+		all = append(all, v.Validate(output)...)
+	}
+	return all
+}
+
+// Apply enforces the guard on output produced for messages. client and
+// opts are only used by PolicyRepair; they may be nil/empty otherwise.
+// The returned string is the (possibly redacted or repaired) output.
+func (g *Guard) Apply(ctx context.Context, client codex.Codex, messages []codex.Message, output string, opts ...codex.ChatOption) (string, error) {
+	violations := g.Check(output)
+	if len(violations) == 0 {
+		return output, nil
+	}
+	switch g.Policy {
+	case PolicyRedact:
+		for _, v := range g.Validators {
+			if r, ok := v.(Redactor); ok {
+				output = r.Redact(output)
+			}
+		}
+		if violations = g.Check(output); len(violations) == 0 {
+			return output, nil
+		}
+	case PolicyRepair:
+		maxRepairs := g.MaxRepairs
+		if maxRepairs <= 0 {
+			maxRepairs = 2
+		}
+		for round := 0; round < maxRepairs; round++ {
+			repaired, err := g.repair(ctx, client, messages, output, violations, opts...)
+			if err != nil {
+				return output, err
+			}
+			output = repaired
+			if violations = g.Check(output); len(violations) == 0 {
+				return output, nil
+			}
+		}
+	}
+	return output, &ValidationError{Output: output, Violations: violations}
+}
+
+func (g *Guard) repair(ctx context.Context, client codex.Codex, messages []codex.Message, output string, violations []Violation, opts ...codex.ChatOption) (string, error) {
+	var b strings.Builder
+	b.WriteString("Your previous response failed validation:\n")
+	for _, v := range violations {
+		fmt.Fprintf(&b, "- %s: %s\n", v.Validator, v.Message)
+	}
+	b.WriteString("Respond again, fixing these problems. Output only the corrected response.")
+	round := make([]codex.Message, 0, len(messages)+2)
+	round = append(round, messages...)
+	round = append(round,
+		codex.Message{Role: codex.RoleAssistant, Content: output},
+		codex.Message{Role: codex.RoleUser, Content: b.String()},
+	)
+	return client.Chat(ctx, round, opts...)
+}
@@ -0,0 +1,45 @@
+package guard
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestJSONSchemaValidator(t *testing.T) {
+	v := JSONSchema(Schema{"name": "string", "count": "number"})
+	if got := v.Validate(`{"name":"a","count":3}`); len(got) != 0 {
+		t.Fatalf("unexpected violations: %+v", got)
+	}
+	if got := v.Validate("```json\n{\"name\":\"a\",\"count\":3}\n```"); len(got) != 0 {
+		t.Fatalf("fenced JSON rejected: %+v", got)
+	}
+	if got := v.Validate(`{"name":42}`); len(got) != 2 {
+		t.Fatalf("expected 2 violations, got %+v", got)
+	}
+}
+
+func TestRedactPolicy(t *testing.T) {
+	g := &Guard{
+		Validators: []Validator{Forbid("no-email", regexp.MustCompile(`\b\S+@\S+\.\S+\b`))},
+		Policy:     PolicyRedact,
+	}
+	out, err := g.Apply(context.Background(), nil, nil, "contact bob@example.com please")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, "bob@example.com") || !strings.Contains(out, "[redacted]") {
+		t.Fatalf("got %q", out)
+	}
+}
+
+func TestAllowlistRejects(t *testing.T) {
+	g := &Guard{Validators: []Validator{Allowlist("yes", "no")}}
+	if _, err := g.Apply(context.Background(), nil, nil, "maybe"); err == nil {
+		t.Fatal("expected validation error")
+	}
+	if out, err := g.Apply(context.Background(), nil, nil, " yes\n"); err != nil || out != " yes\n" {
+		t.Fatalf("got %q, %v", out, err)
+	}
+}
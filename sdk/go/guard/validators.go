@@ -0,0 +1,140 @@
+package guard
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Schema describes the expected shape of a JSON output: field name to
+// expected kind ("string", "number", "bool", "array", "object"). Every
+// listed field is required.
+type Schema map[string]string
+
+type schemaValidator struct {
+	schema Schema
+}
+
+// JSONSchema validates that the output is a JSON object matching schema.
+// A fenced ```json block is accepted and unwrapped before validation.
+func JSONSchema(schema Schema) Validator {
+	return schemaValidator{schema: schema}
+}
+
+func (schemaValidator) Name() string { return "json-schema" }
+
+func (v schemaValidator) Validate(output string) []Violation {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(UnwrapJSON(output)), &obj); err != nil {
+		return []Violation{{Validator: v.Name(), Message: "output is not a JSON object: " + err.Error()}}
+	}
+	var violations []Violation
+	for field, kind := range v.schema {
+		raw, ok := obj[field]
+		if !ok {
+			violations = append(violations, Violation{Validator: v.Name(), Message: fmt.Sprintf("missing required field %q", field)})
+			continue
+		}
+		if got := jsonKind(raw); got != kind {
+			violations = append(violations, Violation{Validator: v.Name(), Message: fmt.Sprintf("field %q should be %s, got %s", field, kind, got)})
+		}
+	}
+	return violations
+}
+
+func jsonKind(raw json.RawMessage) string {
+	s := strings.TrimSpace(string(raw))
+	if s == "" {
+		return "empty"
+	}
+	switch s[0] {
+	case '"':
+		return "string"
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case 't', 'f':
+		return "bool"
+	case 'n':
+		return "null"
+	default:
+		return "number"
+	}
+}
+
+// UnwrapJSON strips a markdown code fence around a JSON payload, a
+// formatting habit models are hard to talk out of.
+func UnwrapJSON(output string) string {
+	s := strings.TrimSpace(output)
+	if strings.HasPrefix(s, "```") {
+		s = strings.TrimPrefix(s, "```json")
+		s = strings.TrimPrefix(s, "```")
+		s = strings.TrimSuffix(strings.TrimSpace(s), "```")
+	}
+	return strings.TrimSpace(s)
+}
+
+type regexValidator struct {
+	name      string
+	pattern   *regexp.Regexp
+	mustMatch bool
+}
+
+// Match requires the output to match pattern.
+func Match(name string, pattern *regexp.Regexp) Validator {
+	return regexValidator{name: name, pattern: pattern, mustMatch: true}
+}
+
+// Forbid rejects output containing pattern. Under PolicyRedact, matches
+// are replaced with "[redacted]".
+func Forbid(name string, pattern *regexp.Regexp) Validator {
+	return regexValidator{name: name, pattern: pattern}
+}
+
+func (v regexValidator) Name() string { return v.name }
+
+func (v regexValidator) Validate(output string) []Violation {
+	matched := v.pattern.MatchString(output)
+	if v.mustMatch && !matched {
+		return []Violation{{Validator: v.name, Message: "output does not match required pattern " + v.pattern.String()}}
+	}
+	if !v.mustMatch && matched {
+		return []Violation{{Validator: v.name, Message: "output contains forbidden pattern " + v.pattern.String()}}
+	}
+	return nil
+}
+
+// Redact implements Redactor for Forbid validators.
+func (v regexValidator) Redact(output string) string {
+	if v.mustMatch {
+		return output
+	}
+	return v.pattern.ReplaceAllString(output, "[redacted]")
+}
+
+type allowlistValidator struct {
+	values []string
+}
+
+// Allowlist requires the trimmed output to be exactly one of values —
+// useful for classification prompts.
+func Allowlist(values ...string) Validator {
+	return allowlistValidator{values: values}
+}
+
+func (allowlistValidator) Name() string { return "allowlist" }
+
+func (v allowlistValidator) Validate(output string) []Violation {
+	got := strings.TrimSpace(output)
+	for _, want := range v.values {
+		if got == want {
+			return nil
+		}
+	}
+	return []Violation{{
+		Validator: v.Name(),
+		Message:   fmt.Sprintf("output %q is not one of: %s", got, strings.Join(v.values, ", ")),
+	}}
+}
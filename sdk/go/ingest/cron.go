@@ -0,0 +1,138 @@
+package ingest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSpec is a parsed five-field cron expression (minute, hour, day of
+// month, month, day of week) in the classic crontab dialect: numbers,
+// ranges, comma lists, "*", and "/step".
+type CronSpec struct {
+	minute, hour, dom, month, dow uint64 // bitsets
+	spec                          string
+}
+
+// cronField describes one position's valid range.
+var cronFields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 6},
+}
+
+// ParseCron parses a five-field cron expression.
+func ParseCron(spec string) (*CronSpec, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("ingest: cron spec %q needs 5 fields, has %d", spec, len(fields))
+	}
+	c := &CronSpec{spec: spec}
+	out := []*uint64{&c.minute, &c.hour, &c.dom, &c.month, &c.dow}
+	for i, f := range fields {
+		bits, err := parseCronField(f, cronFields[i].min, cronFields[i].max)
+		if err != nil {
+			return nil, fmt.Errorf("ingest: cron %s: %w", cronFields[i].name, err)
+		}
+		*out[i] = bits
+	}
+	return c, nil
+}
+
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if body, st, ok := strings.Cut(part, "/"); ok {
+			n, err := strconv.Atoi(st)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("bad step %q", st)
+			}
+			step, part = n, body
+		}
+		lo, hi := min, max
+		if part != "*" {
+			if a, b, ok := strings.Cut(part, "-"); ok {
+				var err error
+				if lo, err = strconv.Atoi(a); err != nil {
+					return 0, fmt.Errorf("bad range %q", part)
+				}
+				if hi, err = strconv.Atoi(b); err != nil {
+					return 0, fmt.Errorf("bad range %q", part)
+				}
+			} else {
+				n, err := strconv.Atoi(part)
+				if err != nil {
+					return 0, fmt.Errorf("bad value %q", part)
+				}
+				lo, hi = n, n
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q out of range %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// String returns the original expression.
+func (c *CronSpec) String() string { return c.spec }
+
+// Next returns the first time after t that matches the spec.
+func (c *CronSpec) Next(t time.Time) time.Time {
+	// Scan minute by minute; cron resolution is one minute and the
+	// furthest match (e.g. Feb 29) is within a few years.
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for ; t.Before(limit); t = t.Add(time.Minute) {
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			continue
+		}
+		if c.month&(1<<uint(t.Month())) == 0 {
+			continue
+		}
+		// Like crontab: when both day fields are restricted, either
+		// may match; otherwise the restricted one decides.
+		domOK := c.dom&(1<<uint(t.Day())) != 0
+		dowOK := c.dow&(1<<uint(t.Weekday())) != 0
+		domAll := c.dom == fieldAll(1, 31)
+		dowAll := c.dow == fieldAll(0, 6)
+		switch {
+		case domAll && dowAll:
+		case domAll:
+			if !dowOK {
+				continue
+			}
+		case dowAll:
+			if !domOK {
+				continue
+			}
+		default:
+			if !domOK && !dowOK {
+				continue
+			}
+		}
+		return t
+	}
+	return t
+}
+
+func fieldAll(min, max int) uint64 {
+	var bits uint64
+	for v := min; v <= max; v++ {
+		bits |= 1 << uint(v)
+	}
+	return bits
+}
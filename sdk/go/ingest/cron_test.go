@@ -0,0 +1,66 @@
+package ingest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseCronAndNext(t *testing.T) {
+	spec, err := ParseCron("0 3 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := time.Date(2026, 8, 30, 12, 0, 0, 0, time.UTC)
+	next := spec.Next(from)
+	want := time.Date(2026, 8, 31, 3, 0, 0, 0, time.UTC)
+	if !next.Equal(want) {
+		t.Fatalf("next: %v, want %v", next, want)
+	}
+
+	// Steps, ranges, and lists.
+	spec, err = ParseCron("*/15 9-17 * * 1-5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A Saturday noon rolls to Monday 09:00.
+	from = time.Date(2026, 8, 29, 12, 0, 0, 0, time.UTC) // Saturday
+	next = spec.Next(from)
+	want = time.Date(2026, 8, 31, 9, 0, 0, 0, time.UTC) // Monday
+	if !next.Equal(want) {
+		t.Fatalf("next: %v, want %v", next, want)
+	}
+	// Within the window the step applies.
+	from = time.Date(2026, 8, 31, 9, 1, 0, 0, time.UTC)
+	if next = spec.Next(from); next.Minute() != 15 || next.Hour() != 9 {
+		t.Fatalf("next: %v", next)
+	}
+}
+
+func TestParseCronRejectsBadSpecs(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "61 * * * *", "* 24 * * *", "a * * * *", "*/0 * * * *"} {
+		if _, err := ParseCron(spec); err == nil {
+			t.Errorf("spec %q parsed", spec)
+		}
+	}
+}
+
+func TestSchedulerLockFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ingest.lock")
+	release, err := acquireLock(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := acquireLock(path); err == nil {
+		t.Fatal("second acquire should fail while held")
+	}
+	release()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatal("lock file not removed on release")
+	}
+	if release, err = acquireLock(path); err != nil {
+		t.Fatalf("reacquire after release: %v", err)
+	}
+	release()
+}
@@ -0,0 +1,128 @@
+package ingest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// DeadLetter records one document that failed to ingest.
+type DeadLetter struct {
+	Source   string    `json:"source"`
+	Ref      Ref       `json:"ref"`
+	Stage    string    `json:"stage"` // fetch, extract, embed, or upsert
+	Error    string    `json:"error"`
+	FailedAt time.Time `json:"failed_at"`
+	Attempts int       `json:"attempts"`
+}
+
+// DeadLetterStore persists failed ingestion items for later inspection
+// and retry.
+type DeadLetterStore interface {
+	Put(ctx context.Context, dl DeadLetter) error
+	List(ctx context.Context) ([]DeadLetter, error)
+	Remove(ctx context.Context, source, refID string) error
+}
+
+// FileDeadLetterStore keeps dead letters in a JSONL file, one entry per
+// line. It is safe for concurrent use within one process.
+type FileDeadLetterStore struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// Put implements DeadLetterStore, replacing any existing entry for the
+// same source and document.
+func (s *FileDeadLetterStore) Put(ctx context.Context, dl DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i := range entries {
+		if entries[i].Source == dl.Source && entries[i].Ref.ID == dl.Ref.ID {
+			entries[i] = dl
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		entries = append(entries, dl)
+	}
+	return s.save(entries)
+}
+
+// List implements DeadLetterStore.
+func (s *FileDeadLetterStore) List(ctx context.Context) ([]DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+// Remove implements DeadLetterStore. Removing an absent entry is not an
+// error.
+func (s *FileDeadLetterStore) Remove(ctx context.Context, source, refID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+	kept := entries[:0]
+	for _, e := range entries {
+		if e.Source != source || e.Ref.ID != refID {
+			kept = append(kept, e)
+		}
+	}
+	return s.save(kept)
+}
+
+func (s *FileDeadLetterStore) load() ([]DeadLetter, error) {
+	f, err := os.Open(s.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var entries []DeadLetter
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for sc.Scan() {
+		var dl DeadLetter
+		if err := json.Unmarshal(sc.Bytes(), &dl); err != nil {
+			return nil, fmt.Errorf("ingest: corrupt dead-letter file %s: %w", s.Path, err)
+		}
+		entries = append(entries, dl)
+	}
+	return entries, sc.Err()
+}
+
+func (s *FileDeadLetterStore) save(entries []DeadLetter) error {
+	tmp := s.Path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			f.Close()
+			os.Remove(tmp)
+			return err
+		}
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, s.Path)
+}
@@ -0,0 +1,132 @@
+package ingest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileDeadLetterStorePutReplacesAndRemoves(t *testing.T) {
+	store := &FileDeadLetterStore{Path: filepath.Join(t.TempDir(), "dead.jsonl")}
+	ctx := context.Background()
+
+	first := DeadLetter{Source: "wiki", Ref: Ref{ID: "a.txt"}, Stage: "fetch", Error: "boom", Attempts: 1}
+	if err := store.Put(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(ctx, DeadLetter{Source: "wiki", Ref: Ref{ID: "b.txt"}, Stage: "extract", Attempts: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A second Put for the same source and document replaces, not appends.
+	first.Attempts = 2
+	first.Error = "boom again"
+	if err := store.Put(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := store.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(entries))
+	}
+	if entries[0].Attempts != 2 || entries[0].Error != "boom again" {
+		t.Fatalf("entry not replaced: %+v", entries[0])
+	}
+
+	if err := store.Remove(ctx, "wiki", "a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	// Removing an absent entry is not an error.
+	if err := store.Remove(ctx, "wiki", "a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	entries, err = store.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Ref.ID != "b.txt" {
+		t.Fatalf("entries after remove: %+v", entries)
+	}
+}
+
+func TestIngestCapturesFailuresAndRetryRedrives(t *testing.T) {
+	src := newFakeSource("wiki", map[string]string{
+		"good.txt": "fine document",
+		"bad.txt":  "eventually fine document",
+	})
+	src.failFetch["bad.txt"] = true
+
+	store := &FileDeadLetterStore{Path: filepath.Join(t.TempDir(), "dead.jsonl")}
+	ing := NewIngestor(fakeEmbedder{}, &fakeUpserter{}, "kb")
+	ing.DeadLetters = store
+	ctx := context.Background()
+
+	stats, err := ing.IngestSource(ctx, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Documents != 1 || stats.Failed != 1 {
+		t.Fatalf("stats = %+v", stats)
+	}
+	entries, err := store.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("dead letters = %+v", entries)
+	}
+	dl := entries[0]
+	if dl.Source != "wiki" || dl.Ref.ID != "bad.txt" || dl.Stage != "fetch" || dl.Attempts != 1 {
+		t.Fatalf("dead letter: %+v", dl)
+	}
+	if dl.FailedAt.IsZero() || dl.Error == "" {
+		t.Fatalf("dead letter missing detail: %+v", dl)
+	}
+
+	// A retry that fails again re-records with an incremented attempt count.
+	if _, err := ing.Retry(ctx, src); err != nil {
+		t.Fatal(err)
+	}
+	entries, _ = store.List(ctx)
+	if len(entries) != 1 || entries[0].Attempts != 2 {
+		t.Fatalf("after failed retry: %+v", entries)
+	}
+
+	// Once the document is fetchable, Retry ingests it and clears the entry.
+	src.mu.Lock()
+	src.failFetch["bad.txt"] = false
+	src.mu.Unlock()
+	stats, err = ing.Retry(ctx, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Documents != 1 || stats.Failed != 0 {
+		t.Fatalf("retry stats = %+v", stats)
+	}
+	entries, _ = store.List(ctx)
+	if len(entries) != 0 {
+		t.Fatalf("dead letters not cleared: %+v", entries)
+	}
+}
+
+func TestRetryIgnoresOtherSources(t *testing.T) {
+	store := &FileDeadLetterStore{Path: filepath.Join(t.TempDir(), "dead.jsonl")}
+	ctx := context.Background()
+	if err := store.Put(ctx, DeadLetter{Source: "other", Ref: Ref{ID: "x"}, Stage: "fetch", FailedAt: time.Now(), Attempts: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	ing := NewIngestor(fakeEmbedder{}, &fakeUpserter{}, "kb")
+	ing.DeadLetters = store
+	src := newFakeSource("wiki", map[string]string{})
+	if _, err := ing.Retry(ctx, src); err != nil {
+		t.Fatal(err)
+	}
+	entries, _ := store.List(ctx)
+	if len(entries) != 1 {
+		t.Fatalf("entry for unrelated source touched: %+v", entries)
+	}
+}
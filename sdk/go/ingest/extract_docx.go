@@ -0,0 +1,81 @@
+package ingest
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// docxExtractor pulls paragraph text out of the main document part of a
+// DOCX (OOXML) archive. The whole file is buffered in memory, which is
+// acceptable for office documents.
+type docxExtractor struct{}
+
+func (docxExtractor) ContentTypes() []string {
+	return []string{"application/vnd.openxmlformats-officedocument.wordprocessingml.document"}
+}
+
+func (docxExtractor) Extract(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("ingest: open docx: %w", err)
+	}
+	for _, f := range zr.File {
+		if f.Name == "word/document.xml" {
+			rc, err := f.Open()
+			if err != nil {
+				return "", err
+			}
+			defer rc.Close()
+			return docxText(rc)
+		}
+	}
+	return "", fmt.Errorf("ingest: docx has no word/document.xml")
+}
+
+// docxText walks the document XML, collecting w:t runs and inserting
+// newlines at paragraph ends and tabs for w:tab.
+func docxText(r io.Reader) (string, error) {
+	dec := xml.NewDecoder(r)
+	var b strings.Builder
+	inText := false
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("ingest: parse docx xml: %w", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				b.WriteString("\t")
+			case "br":
+				b.WriteString("\n")
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				b.WriteString("\n")
+			}
+		case xml.CharData:
+			if inText {
+				b.Write(t)
+			}
+		}
+	}
+	return strings.TrimSpace(b.String()), nil
+}
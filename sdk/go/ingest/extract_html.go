@@ -0,0 +1,122 @@
+package ingest
+
+import (
+	"html"
+	"io"
+	"strings"
+)
+
+// htmlExtractor converts HTML into readable plain text: tags are dropped,
+// script/style/nav content is skipped, and block elements become line
+// breaks.
+type htmlExtractor struct{}
+
+func (htmlExtractor) ContentTypes() []string {
+	return []string{"text/html", "application/xhtml+xml"}
+}
+
+// blockTags are elements that imply a line break around their content.
+var blockTags = map[string]bool{
+	"p": true, "div": true, "br": true, "li": true, "tr": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"blockquote": true, "pre": true, "section": true, "article": true,
+	"table": true, "ul": true, "ol": true,
+}
+
+// skippedTags are elements whose entire content is boilerplate.
+var skippedTags = map[string]bool{
+	"script": true, "style": true, "noscript": true, "head": true,
+	"nav": true, "footer": true, "template": true, "svg": true,
+}
+
+func (htmlExtractor) Extract(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	src := string(data)
+	var b strings.Builder
+	skipUntil := "" // closing tag name we are discarding content until
+	for i := 0; i < len(src); {
+		if src[i] != '<' {
+			j := strings.IndexByte(src[i:], '<')
+			if j < 0 {
+				j = len(src) - i
+			}
+			if skipUntil == "" {
+				b.WriteString(src[i : i+j])
+			}
+			i += j
+			continue
+		}
+		// Comments and declarations.
+		if strings.HasPrefix(src[i:], "<!--") {
+			if j := strings.Index(src[i:], "-->"); j >= 0 {
+				i += j + 3
+			} else {
+				i = len(src)
+			}
+			continue
+		}
+		end := strings.IndexByte(src[i:], '>')
+		if end < 0 {
+			break
+		}
+		tag := src[i+1 : i+end]
+		i += end + 1
+		name, closing := tagName(tag)
+		if skipUntil != "" {
+			if closing && name == skipUntil {
+				skipUntil = ""
+			}
+			continue
+		}
+		if !closing && skippedTags[name] {
+			skipUntil = name
+			continue
+		}
+		if blockTags[name] {
+			b.WriteString("\n")
+		} else {
+			b.WriteString(" ")
+		}
+	}
+	return collapseWhitespace(html.UnescapeString(b.String())), nil
+}
+
+// tagName extracts the lowercase element name from raw tag content and
+// whether it is a closing tag.
+func tagName(tag string) (string, bool) {
+	tag = strings.TrimSpace(tag)
+	closing := strings.HasPrefix(tag, "/")
+	tag = strings.TrimPrefix(tag, "/")
+	for i := 0; i < len(tag); i++ {
+		c := tag[i]
+		if c == ' ' || c == '\t' || c == '\n' || c == '/' || c == '>' {
+			tag = tag[:i]
+			break
+		}
+	}
+	return strings.ToLower(tag), closing
+}
+
+// collapseWhitespace trims runs of spaces and limits consecutive blank
+// lines to one.
+func collapseWhitespace(s string) string {
+	lines := strings.Split(s, "\n")
+	var out []string
+	blank := true
+	for _, line := range lines {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			if !blank {
+				out = append(out, "")
+			}
+			blank = true
+			continue
+		}
+		out = append(out, line)
+		blank = false
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
@@ -0,0 +1,153 @@
+package ingest
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"strings"
+)
+
+// pdfExtractor recovers text from PDF content streams. It is a minimal
+// pure-Go reader: FlateDecode streams are inflated and the Tj/TJ/' text
+// operators are collected. PDFs that encode text via embedded font CMaps
+// only will come out garbled, but the common generator output (reports,
+// exported docs) extracts well.
+type pdfExtractor struct{}
+
+func (pdfExtractor) ContentTypes() []string {
+	return []string{"application/pdf"}
+}
+
+func (pdfExtractor) Extract(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, stream := range pdfStreams(data) {
+		writePDFText(&b, stream)
+	}
+	return collapseWhitespace(b.String()), nil
+}
+
+// pdfStreams returns every stream body in the file, inflating FlateDecode
+// streams and passing others through untouched.
+func pdfStreams(data []byte) [][]byte {
+	var streams [][]byte
+	rest := data
+	for {
+		i := bytes.Index(rest, []byte("stream"))
+		if i < 0 {
+			break
+		}
+		body := rest[i+len("stream"):]
+		// The keyword is followed by CRLF or LF.
+		body = bytes.TrimPrefix(body, []byte("\r\n"))
+		body = bytes.TrimPrefix(body, []byte("\n"))
+		end := bytes.Index(body, []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		raw := bytes.TrimRight(body[:end], "\r\n")
+		if zr, err := zlib.NewReader(bytes.NewReader(raw)); err == nil {
+			if inflated, err := io.ReadAll(zr); err == nil {
+				raw = inflated
+			}
+			zr.Close()
+		}
+		streams = append(streams, raw)
+		rest = body[end+len("endstream"):]
+	}
+	return streams
+}
+
+// writePDFText scans a content stream for text-showing operators and
+// appends their string operands to b.
+func writePDFText(b *strings.Builder, stream []byte) {
+	i := 0
+	wroteAny := false
+	for i < len(stream) {
+		switch stream[i] {
+		case '(':
+			text, next := pdfString(stream, i)
+			b.WriteString(text)
+			wroteAny = wroteAny || text != ""
+			i = next
+		case 'T':
+			// TD/Td/T* move the cursor to a new line; approximate
+			// with whitespace so words don't run together.
+			if i+1 < len(stream) {
+				switch stream[i+1] {
+				case 'd', 'D', '*':
+					b.WriteString("\n")
+				case 'J':
+					b.WriteString("")
+				}
+			}
+			i++
+		case '%':
+			for i < len(stream) && stream[i] != '\n' {
+				i++
+			}
+		default:
+			i++
+		}
+	}
+	if wroteAny {
+		b.WriteString("\n")
+	}
+}
+
+// pdfString decodes a parenthesized PDF string starting at stream[start],
+// returning the text and the index just past the closing parenthesis.
+func pdfString(stream []byte, start int) (string, int) {
+	var b strings.Builder
+	depth := 0
+	i := start
+	for i < len(stream) {
+		c := stream[i]
+		switch c {
+		case '\\':
+			if i+1 >= len(stream) {
+				return b.String(), i + 1
+			}
+			i++
+			switch e := stream[i]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r', 't', 'b', 'f':
+				b.WriteByte(' ')
+			case '(', ')', '\\':
+				b.WriteByte(e)
+			default:
+				if e >= '0' && e <= '7' {
+					// Up to three octal digits.
+					v := 0
+					for n := 0; n < 3 && i < len(stream) && stream[i] >= '0' && stream[i] <= '7'; n++ {
+						v = v*8 + int(stream[i]-'0')
+						i++
+					}
+					i--
+					if v >= 32 && v < 127 {
+						b.WriteByte(byte(v))
+					}
+				}
+			}
+		case '(':
+			depth++
+			if depth > 1 {
+				b.WriteByte(c)
+			}
+		case ')':
+			depth--
+			if depth == 0 {
+				return b.String(), i + 1
+			}
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+		i++
+	}
+	return b.String(), i
+}
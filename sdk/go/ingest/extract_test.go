@@ -0,0 +1,60 @@
+package ingest
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHTMLExtractorStripsBoilerplate(t *testing.T) {
+	in := `<html><head><title>t</title><style>p{color:red}</style></head>
+<body><nav>menu</nav><h1>Title</h1><p>First &amp; second.</p>
+<script>var x = "<p>";</script><p>Another paragraph.</p></body></html>`
+	out, err := htmlExtractor{}.Extract(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, "menu") || strings.Contains(out, "color") || strings.Contains(out, "var x") {
+		t.Fatalf("boilerplate leaked into output: %q", out)
+	}
+	for _, want := range []string{"Title", "First & second.", "Another paragraph."} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in %q", want, out)
+		}
+	}
+}
+
+func TestDocxExtractor(t *testing.T) {
+	const docXML = `<?xml version="1.0"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+  <w:body>
+    <w:p><w:r><w:t>Hello</w:t></w:r><w:r><w:t xml:space="preserve"> world</w:t></w:r></w:p>
+    <w:p><w:r><w:t>Second paragraph</w:t></w:r></w:p>
+  </w:body>
+</w:document>`
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, _ := zw.Create("word/document.xml")
+	w.Write([]byte(docXML))
+	zw.Close()
+
+	out, err := docxExtractor{}.Extract(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "Hello world") || !strings.Contains(out, "Second paragraph") {
+		t.Fatalf("got %q", out)
+	}
+}
+
+func TestPDFExtractor(t *testing.T) {
+	pdf := "%PDF-1.4\n1 0 obj\n<< /Length 60 >>\nstream\nBT /F1 12 Tf (Hello) Tj 0 -14 Td (from a PDF) Tj ET\nendstream\nendobj\n%%EOF"
+	out, err := pdfExtractor{}.Extract(strings.NewReader(pdf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "Hello") || !strings.Contains(out, "from a PDF") {
+		t.Fatalf("got %q", out)
+	}
+}
@@ -0,0 +1,465 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// Embedder is the subset of the client used to embed chunk text.
+type Embedder interface {
+	EmbedBatch(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// Upserter is the subset of the client used to store embedded chunks.
+type Upserter interface {
+	UpsertBatch(ctx context.Context, collection string, recs []codex.VectorRecord) error
+}
+
+// Superseder removes the chunks of outdated document versions. The
+// codex.Client satisfies it via SupersedeDocument.
+type Superseder interface {
+	SupersedeDocument(ctx context.Context, collection, docID string, keepVersion int) error
+}
+
+// Extractor converts a raw document body into plain text.
+type Extractor interface {
+	// Extract reads the document content and returns its text.
+	Extract(r io.Reader) (string, error)
+	// ContentTypes lists the MIME types this extractor handles.
+	ContentTypes() []string
+}
+
+// ChunkOptions controls how extracted text is split before embedding.
+type ChunkOptions struct {
+	// Size is the target chunk length in runes. Defaults to 1500.
+	Size int
+	// Overlap is how many runes consecutive chunks share. Defaults to 200.
+	Overlap int
+}
+
+func (o ChunkOptions) withDefaults() ChunkOptions {
+	if o.Size <= 0 {
+		o.Size = 1500
+	}
+	if o.Overlap < 0 || o.Overlap >= o.Size {
+		o.Overlap = o.Size / 8
+	}
+	return o
+}
+
+// Ingestor pulls documents from sources, extracts and chunks their text,
+// embeds the chunks, and upserts them into a collection.
+type Ingestor struct {
+	Embedder   Embedder
+	Upserter   Upserter
+	Collection string
+	Chunking   ChunkOptions
+	// BatchSize is how many chunks are embedded and upserted per call.
+	// Defaults to 64.
+	BatchSize int
+	// Languages routes chunks in specific languages (ISO 639-1 codes)
+	// to alternate embedders or through translation before embedding.
+	// Chunks in unlisted languages use the default Embedder.
+	Languages map[string]LanguagePolicy
+	// DeadLetters, when set, captures documents that fail to fetch,
+	// extract, embed, or upsert instead of aborting the run. Failed
+	// items can be re-driven with Retry.
+	DeadLetters DeadLetterStore
+	// Progress, when set, receives an update after every document
+	// processed during a run.
+	Progress codex.ProgressSink
+	// Supersede, when set, deletes the chunks of older versions of each
+	// document once its new version is fully written. A document's
+	// version is its doc_version metadata value; documents without one
+	// are never superseded.
+	Supersede Superseder
+
+	extractors map[string]Extractor
+}
+
+// NewIngestor builds an Ingestor writing to collection. The codex.Client
+// satisfies both interface arguments.
+func NewIngestor(e Embedder, u Upserter, collection string) *Ingestor {
+	ing := &Ingestor{Embedder: e, Upserter: u, Collection: collection}
+	ing.RegisterExtractor(textExtractor{})
+	ing.RegisterExtractor(htmlExtractor{})
+	ing.RegisterExtractor(docxExtractor{})
+	ing.RegisterExtractor(pdfExtractor{})
+	return ing
+}
+
+// RegisterExtractor adds (or replaces) the extractor for its content types.
+func (ing *Ingestor) RegisterExtractor(e Extractor) {
+	if ing.extractors == nil {
+		ing.extractors = make(map[string]Extractor)
+	}
+	for _, ct := range e.ContentTypes() {
+		ing.extractors[ct] = e
+	}
+}
+
+// Stats summarizes one ingestion run.
+type Stats struct {
+	Documents int
+	Chunks    int
+	Skipped   int // documents with no registered extractor
+	Failed    int // documents captured in the dead-letter store
+}
+
+// stageError tags an ingestion failure with the pipeline stage it
+// occurred in, for dead-letter records.
+type stageError struct {
+	stage string
+	err   error
+}
+
+func (e *stageError) Error() string { return e.stage + ": " + e.err.Error() }
+func (e *stageError) Unwrap() error { return e.err }
+
+func stageOf(err error) string {
+	var se *stageError
+	if errors.As(err, &se) {
+		return se.stage
+	}
+	return "ingest"
+}
+
+// IngestSource lists every document in src and ingests each one.
+func (ing *Ingestor) IngestSource(ctx context.Context, src Source) (Stats, error) {
+	return ing.ingestListing(ctx, src, src.List)
+}
+
+// IngestChanges ingests only documents added or modified since the given
+// time, for incremental syncs.
+func (ing *Ingestor) IngestChanges(ctx context.Context, src Source, since time.Time) (Stats, error) {
+	return ing.ingestListing(ctx, src, func(ctx context.Context, fn func(Ref) error) error {
+		return src.Changes(ctx, since, fn)
+	})
+}
+
+func (ing *Ingestor) ingestListing(ctx context.Context, src Source, list func(context.Context, func(Ref) error) error) (Stats, error) {
+	var stats Stats
+	started := time.Now()
+	var bytes int64
+	err := list(ctx, func(ref Ref) error {
+		err := ing.ingestRef(ctx, src, ref, &stats)
+		bytes += ref.Size
+		ing.reportProgress(stats, bytes, started)
+		if err == nil {
+			return nil
+		}
+		if ing.DeadLetters == nil || ctx.Err() != nil {
+			return err
+		}
+		// Capture the failure and keep going with the rest of the run.
+		stats.Failed++
+		return ing.DeadLetters.Put(ctx, DeadLetter{
+			Source:   src.Name(),
+			Ref:      ref,
+			Stage:    stageOf(err),
+			Error:    err.Error(),
+			FailedAt: time.Now(),
+			Attempts: 1,
+		})
+	})
+	return stats, err
+}
+
+func (ing *Ingestor) reportProgress(stats Stats, bytes int64, started time.Time) {
+	if ing.Progress == nil {
+		return
+	}
+	ing.Progress.Progress(codex.Progress{
+		Operation: "ingest",
+		Done:      stats.Documents + stats.Skipped,
+		Bytes:     bytes,
+		Failed:    stats.Failed,
+		Started:   started,
+	})
+}
+
+func (ing *Ingestor) ingestRef(ctx context.Context, src Source, ref Ref, stats *Stats) error {
+	doc, err := src.Fetch(ctx, ref.ID)
+	if err != nil {
+		return &stageError{"fetch", fmt.Errorf("fetch %s from %s: %w", ref.ID, src.Name(), err)}
+	}
+	n, err := ing.IngestDocument(ctx, doc)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		stats.Skipped++
+	} else {
+		stats.Documents++
+		stats.Chunks += n
+	}
+	return nil
+}
+
+// Retry re-drives every dead-lettered document belonging to one of the
+// given sources. Entries that succeed are removed from the store; entries
+// that fail again are re-recorded with an incremented attempt count.
+func (ing *Ingestor) Retry(ctx context.Context, sources ...Source) (Stats, error) {
+	if ing.DeadLetters == nil {
+		return Stats{}, errors.New("ingest: no dead-letter store configured")
+	}
+	byName := make(map[string]Source, len(sources))
+	for _, src := range sources {
+		byName[src.Name()] = src
+	}
+	entries, err := ing.DeadLetters.List(ctx)
+	if err != nil {
+		return Stats{}, err
+	}
+	var stats Stats
+	for _, dl := range entries {
+		src, ok := byName[dl.Source]
+		if !ok {
+			continue
+		}
+		if err := ing.ingestRef(ctx, src, dl.Ref, &stats); err != nil {
+			if ctx.Err() != nil {
+				return stats, err
+			}
+			stats.Failed++
+			dl.Stage = stageOf(err)
+			dl.Error = err.Error()
+			dl.FailedAt = time.Now()
+			dl.Attempts++
+			if err := ing.DeadLetters.Put(ctx, dl); err != nil {
+				return stats, err
+			}
+			continue
+		}
+		if err := ing.DeadLetters.Remove(ctx, dl.Source, dl.Ref.ID); err != nil {
+			return stats, err
+		}
+	}
+	return stats, nil
+}
+
+// IngestDocument extracts, chunks, embeds, and upserts a single document,
+// returning the number of chunks written. Documents whose content type has
+// no registered extractor are skipped with a zero count. The document body
+// is always closed.
+func (ing *Ingestor) IngestDocument(ctx context.Context, doc *Document) (int, error) {
+	defer doc.Body.Close()
+	ext, ok := ing.extractors[doc.ContentType]
+	if !ok {
+		return 0, nil
+	}
+	text, err := ext.Extract(doc.Body)
+	if err != nil {
+		return 0, &stageError{"extract", fmt.Errorf("extract %s: %w", doc.ID, err)}
+	}
+	chunks := Chunk(text, ing.Chunking)
+	if len(chunks) == 0 {
+		return 0, nil
+	}
+
+	batch := ing.BatchSize
+	if batch <= 0 {
+		batch = 64
+	}
+	for start := 0; start < len(chunks); start += batch {
+		end := start + batch
+		if end > len(chunks) {
+			end = len(chunks)
+		}
+		if err := ing.writeChunks(ctx, doc, chunks[start:end], start); err != nil {
+			return start, err
+		}
+	}
+	if ing.Supersede != nil {
+		if v := docVersion(doc); v > 0 {
+			if err := ing.Supersede.SupersedeDocument(ctx, ing.Collection, doc.ID, v); err != nil {
+				return len(chunks), &stageError{"supersede", fmt.Errorf("supersede %s: %w", doc.ID, err)}
+			}
+		}
+	}
+	return len(chunks), nil
+}
+
+// docVersion reads a document's doc_version metadata, tolerating the
+// numeric types JSON decoding produces.
+func docVersion(doc *Document) int {
+	switch v := doc.Metadata["doc_version"].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+func (ing *Ingestor) writeChunks(ctx context.Context, doc *Document, chunks []string, offset int) error {
+	langs := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		langs[i] = DetectLanguage(chunk)
+	}
+	vecs, err := ing.embedByLanguage(ctx, chunks, langs)
+	if err != nil {
+		return &stageError{"embed", fmt.Errorf("embed %s: %w", doc.ID, err)}
+	}
+	recs := make([]codex.VectorRecord, len(chunks))
+	for i, chunk := range chunks {
+		meta := map[string]any{
+			"source":   doc.SourceName,
+			"doc_id":   doc.ID,
+			"ordinal":  offset + i,
+			"mod_time": doc.ModTime,
+		}
+		if langs[i] != "" {
+			meta["lang"] = langs[i]
+		}
+		if doc.Title != "" {
+			meta["title"] = doc.Title
+		}
+		for k, v := range doc.Metadata {
+			meta[k] = v
+		}
+		recs[i] = codex.VectorRecord{
+			ID:       fmt.Sprintf("%s#%d", doc.ID, offset+i),
+			Vector:   vecs[i],
+			Document: chunk,
+			Metadata: meta,
+		}
+	}
+	if err := ing.Upserter.UpsertBatch(ctx, ing.Collection, recs); err != nil {
+		return &stageError{"upsert", fmt.Errorf("upsert %s: %w", doc.ID, err)}
+	}
+	return nil
+}
+
+// embedByLanguage embeds chunks, routing each chunk through its
+// language's policy (alternate embedder, translation) when one is
+// configured. Vectors are returned in chunk order.
+func (ing *Ingestor) embedByLanguage(ctx context.Context, chunks, langs []string) ([][]float32, error) {
+	groups := map[string][]int{}
+	for i, lang := range langs {
+		if _, ok := ing.Languages[lang]; !ok {
+			lang = ""
+		}
+		groups[lang] = append(groups[lang], i)
+	}
+	vecs := make([][]float32, len(chunks))
+	for lang, idxs := range groups {
+		policy := ing.Languages[lang]
+		embedder := policy.Embedder
+		if embedder == nil {
+			embedder = ing.Embedder
+		}
+		texts := make([]string, len(idxs))
+		for j, i := range idxs {
+			text := chunks[i]
+			if policy.Translator != nil {
+				target := policy.TargetLang
+				if target == "" {
+					target = "en"
+				}
+				translated, err := policy.Translator.Translate(ctx, text, target)
+				if err != nil {
+					return nil, fmt.Errorf("translate %s chunk: %w", lang, err)
+				}
+				text = translated
+			}
+			texts[j] = text
+		}
+		groupVecs, err := embedder.EmbedBatch(ctx, texts)
+		if err != nil {
+			return nil, err
+		}
+		for j, i := range idxs {
+			vecs[i] = groupVecs[j]
+		}
+	}
+	return vecs, nil
+}
+
+// Chunk splits text into overlapping chunks, preferring to break at
+// paragraph and sentence boundaries near the target size.
+func Chunk(text string, opts ChunkOptions) []string {
+	opts = opts.withDefaults()
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	runes := []rune(text)
+	if len(runes) <= opts.Size {
+		return []string{text}
+	}
+	var chunks []string
+	for start := 0; start < len(runes); {
+		end := start + opts.Size
+		if end >= len(runes) {
+			end = len(runes)
+		} else {
+			end = breakPoint(runes, start, end)
+		}
+		chunk := strings.TrimSpace(string(runes[start:end]))
+		if chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+		if end == len(runes) {
+			break
+		}
+		start = end - opts.Overlap
+		if start < 0 {
+			start = 0
+		}
+	}
+	return chunks
+}
+
+// breakPoint scans backwards from end for a paragraph break, then a
+// sentence end, then any whitespace, so chunks end at natural boundaries.
+// It never backs up past the midpoint of the chunk.
+func breakPoint(runes []rune, start, end int) int {
+	min := start + (end-start)/2
+	for i := end; i > min; i-- {
+		if runes[i-1] == '\n' && i < len(runes) && runes[i] == '\n' {
+			return i
+		}
+	}
+	for i := end; i > min; i-- {
+		r := runes[i-1]
+		if r == '.' || r == '!' || r == '?' || r == '\n' {
+			return i
+		}
+	}
+	for i := end; i > min; i-- {
+		if isSpace(runes[i-1]) {
+			return i
+		}
+	}
+	return end
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
+
+// textExtractor handles plain-text content types as-is.
+type textExtractor struct{}
+
+func (textExtractor) ContentTypes() []string {
+	return []string{"text/plain", "text/markdown", "text/x-markdown"}
+}
+
+func (textExtractor) Extract(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(data) {
+		return "", fmt.Errorf("ingest: document is not valid UTF-8")
+	}
+	return string(data), nil
+}
@@ -0,0 +1,74 @@
+package ingest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestChunkShortTextIsSingleChunk(t *testing.T) {
+	chunks := Chunk("hello world", ChunkOptions{})
+	if len(chunks) != 1 || chunks[0] != "hello world" {
+		t.Fatalf("got %q", chunks)
+	}
+}
+
+func TestChunkOverlapAndBoundaries(t *testing.T) {
+	text := strings.Repeat("One sentence here. ", 200)
+	chunks := Chunk(text, ChunkOptions{Size: 300, Overlap: 50})
+	if len(chunks) < 2 {
+		t.Fatalf("expected multiple chunks, got %d", len(chunks))
+	}
+	for i, c := range chunks {
+		if len([]rune(c)) > 300 {
+			t.Fatalf("chunk %d exceeds size: %d runes", i, len([]rune(c)))
+		}
+	}
+}
+
+func TestObjectStoreSourceListAndFetch(t *testing.T) {
+	const listing = `<?xml version="1.0"?>
+<ListBucketResult>
+  <IsTruncated>false</IsTruncated>
+  <Contents><Key>docs/a.txt</Key><Size>5</Size><LastModified>2024-01-02T03:04:05Z</LastModified></Contents>
+  <Contents><Key>docs/</Key><Size>0</Size></Contents>
+</ListBucketResult>`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("list-type") == "2" {
+			io.WriteString(w, listing)
+			return
+		}
+		if r.URL.Path == "/kb/docs/a.txt" {
+			w.Header().Set("Content-Type", "text/plain")
+			io.WriteString(w, "hello")
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	src := &ObjectStoreSource{Endpoint: srv.URL, Bucket: "kb", Prefix: "docs/", PathStyle: true}
+	var refs []Ref
+	if err := src.List(context.Background(), func(r Ref) error {
+		refs = append(refs, r)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(refs) != 1 || refs[0].ID != "docs/a.txt" || refs[0].Size != 5 {
+		t.Fatalf("unexpected refs: %+v", refs)
+	}
+
+	doc, err := src.Fetch(context.Background(), "docs/a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer doc.Body.Close()
+	body, _ := io.ReadAll(doc.Body)
+	if string(body) != "hello" || doc.ContentType != "text/plain" {
+		t.Fatalf("got body %q content type %q", body, doc.ContentType)
+	}
+}
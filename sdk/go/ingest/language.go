@@ -0,0 +1,123 @@
+package ingest
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+// DetectLanguage guesses the ISO 639-1 language code of text. Non-Latin
+// scripts are identified by their Unicode ranges; Latin-script languages
+// are distinguished by stopword frequency. It returns "" when no guess is
+// confident enough.
+func DetectLanguage(text string) string {
+	counts := map[string]int{}
+	total := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		total++
+		switch {
+		case unicode.Is(unicode.Han, r):
+			counts["zh"]++
+		case unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r):
+			counts["ja"]++
+		case unicode.Is(unicode.Hangul, r):
+			counts["ko"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["ru"]++
+		case unicode.Is(unicode.Arabic, r):
+			counts["ar"]++
+		case unicode.Is(unicode.Hebrew, r):
+			counts["he"]++
+		case unicode.Is(unicode.Greek, r):
+			counts["el"]++
+		case unicode.Is(unicode.Devanagari, r):
+			counts["hi"]++
+		case unicode.Is(unicode.Thai, r):
+			counts["th"]++
+		case unicode.Is(unicode.Latin, r):
+			counts["latin"]++
+		}
+	}
+	if total == 0 {
+		return ""
+	}
+	best, bestN := "", 0
+	for lang, n := range counts {
+		if n > bestN {
+			best, bestN = lang, n
+		}
+	}
+	// Japanese text mixes kana with Han; prefer ja when kana is present.
+	if best == "zh" && counts["ja"] > 0 {
+		best = "ja"
+	}
+	if bestN*2 < total {
+		return ""
+	}
+	if best != "latin" {
+		return best
+	}
+	return detectLatinLanguage(text)
+}
+
+// latinStopwords maps a language to words that are frequent in it and
+// rare in the other candidates.
+var latinStopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "that", "with", "for"},
+	"es": {"el", "la", "los", "las", "una", "que", "para", "con"},
+	"fr": {"le", "les", "des", "une", "est", "que", "dans", "pour"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "ein", "mit"},
+	"pt": {"o", "os", "uma", "que", "não", "para", "com", "mais"},
+	"it": {"il", "gli", "una", "che", "non", "per", "con", "sono"},
+	"nl": {"de", "het", "een", "van", "niet", "met", "voor", "zijn"},
+}
+
+func detectLatinLanguage(text string) string {
+	words := strings.Fields(strings.ToLower(text))
+	if len(words) == 0 {
+		return ""
+	}
+	scores := map[string]int{}
+	for _, w := range words {
+		w = strings.Trim(w, ".,;:!?\"'()[]")
+		for lang, stops := range latinStopwords {
+			for _, s := range stops {
+				if w == s {
+					scores[lang]++
+				}
+			}
+		}
+	}
+	best, bestN := "", 0
+	for lang, n := range scores {
+		if n > bestN {
+			best, bestN = lang, n
+		}
+	}
+	if bestN == 0 {
+		return ""
+	}
+	return best
+}
+
+// Translator translates text into a target language, used for
+// translate-before-embedding pipelines.
+type Translator interface {
+	Translate(ctx context.Context, text, targetLang string) (string, error)
+}
+
+// LanguagePolicy configures how chunks in one language are embedded.
+type LanguagePolicy struct {
+	// Embedder overrides the Ingestor's default embedder, e.g. a client
+	// configured with a multilingual embedding tier.
+	Embedder Embedder
+	// Translator, when set, translates chunks into TargetLang before
+	// embedding. The original text is still stored as the document.
+	Translator Translator
+	// TargetLang is the language Translator translates into ("en" by
+	// default).
+	TargetLang string
+}
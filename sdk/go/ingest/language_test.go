@@ -0,0 +1,116 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDetectLanguageScripts(t *testing.T) {
+	cases := []struct {
+		text string
+		want string
+	}{
+		{"the cat sat on the mat and looked at the dog", "en"},
+		{"el perro corre por la calle que va para el parque", "es"},
+		{"le chat dort dans les jardins pour une heure", "fr"},
+		{"der Hund ist nicht mit der Katze und das ist gut", "de"},
+		{"это предложение написано на русском языке", "ru"},
+		{"هذه الجملة مكتوبة باللغة العربية", "ar"},
+		{"이 문장은 한국어로 작성되었습니다", "ko"},
+		{"αυτή η πρόταση είναι γραμμένη στα ελληνικά", "el"},
+		{"这是一个完全用汉字写成的句子", "zh"},
+		// Japanese mixes Han with kana; kana must win over zh.
+		{"これは日本語の文章です", "ja"},
+		{"12345 67890 !!!", ""},
+		{"", ""},
+	}
+	for _, tc := range cases {
+		if got := DetectLanguage(tc.text); got != tc.want {
+			t.Errorf("DetectLanguage(%q) = %q, want %q", tc.text, got, tc.want)
+		}
+	}
+}
+
+// recordingEmbedder captures the texts each EmbedBatch call receives.
+type recordingEmbedder struct {
+	texts []string
+}
+
+func (e *recordingEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	e.texts = append(e.texts, texts...)
+	vecs := make([][]float32, len(texts))
+	for i := range texts {
+		vecs[i] = []float32{1}
+	}
+	return vecs, nil
+}
+
+// suffixTranslator marks text as translated instead of really translating.
+type suffixTranslator struct{}
+
+func (suffixTranslator) Translate(ctx context.Context, text, targetLang string) (string, error) {
+	return fmt.Sprintf("[%s] %s", targetLang, text), nil
+}
+
+func TestIngestRoutesChunksByLanguage(t *testing.T) {
+	defaultEmb := &recordingEmbedder{}
+	russianEmb := &recordingEmbedder{}
+	up := &fakeUpserter{}
+	ing := NewIngestor(defaultEmb, up, "kb")
+	ing.Languages = map[string]LanguagePolicy{
+		"ru": {Embedder: russianEmb},
+		"es": {Translator: suffixTranslator{}}, // default TargetLang is en
+	}
+
+	russian := "это предложение написано на русском языке"
+	spanish := "el perro corre por la calle que va para el parque"
+	english := "the cat sat on the mat and looked at the dog"
+	doc := &Document{
+		ID:          "doc1",
+		ContentType: "text/plain",
+		Body:        io.NopCloser(strings.NewReader(russian)),
+	}
+	if _, err := ing.IngestDocument(context.Background(), doc); err != nil {
+		t.Fatal(err)
+	}
+	doc = &Document{ID: "doc2", ContentType: "text/plain", Body: io.NopCloser(strings.NewReader(spanish))}
+	if _, err := ing.IngestDocument(context.Background(), doc); err != nil {
+		t.Fatal(err)
+	}
+	doc = &Document{ID: "doc3", ContentType: "text/plain", Body: io.NopCloser(strings.NewReader(english))}
+	if _, err := ing.IngestDocument(context.Background(), doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(russianEmb.texts) != 1 || russianEmb.texts[0] != russian {
+		t.Errorf("russian embedder got %q", russianEmb.texts)
+	}
+	// The Spanish chunk is translated before embedding; the English one
+	// passes straight through. Both use the default embedder.
+	if len(defaultEmb.texts) != 2 {
+		t.Fatalf("default embedder got %q", defaultEmb.texts)
+	}
+	for _, text := range defaultEmb.texts {
+		switch {
+		case text == english:
+		case text == "[en] "+spanish:
+		default:
+			t.Errorf("unexpected text through default embedder: %q", text)
+		}
+	}
+
+	// Records store the original text and tag the detected language.
+	up.mu.Lock()
+	defer up.mu.Unlock()
+	for _, rec := range up.recs {
+		if strings.HasPrefix(rec.Document, "[en]") {
+			t.Errorf("translated text stored as document: %q", rec.Document)
+		}
+		if rec.Metadata["doc_id"] == "doc1" && rec.Metadata["lang"] != "ru" {
+			t.Errorf("doc1 lang = %v", rec.Metadata["lang"])
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SourceStatus reports the sync state of one scheduled source.
+type SourceStatus struct {
+	Source    string
+	Running   bool
+	LastRun   time.Time
+	LastError string
+	NextRun   time.Time
+	// Totals accumulated across runs.
+	Documents int
+	Chunks    int
+	Runs      int
+}
+
+// Scheduler runs periodic incremental syncs of one or more sources
+// through an Ingestor. The first sync of each source is a full ingest;
+// subsequent syncs only pick up changes.
+type Scheduler struct {
+	Ingestor *Ingestor
+	// Interval is the time between syncs of the same source.
+	// Defaults to one hour.
+	Interval time.Duration
+	// Jitter is the fraction of Interval (0..1) by which each run is
+	// randomly delayed, spreading load across sources. Defaults to 0.1.
+	Jitter float64
+	// MaxConcurrent caps how many sources sync at once. Defaults to 2.
+	MaxConcurrent int
+	// Schedule runs syncs on a cron spec instead of the fixed Interval;
+	// see ParseCron.
+	Schedule *CronSpec
+	// LockFile, when set, is created exclusively for the lifetime of
+	// Run so a second scheduler (say, two cron entries racing) refuses
+	// to start instead of double-ingesting.
+	LockFile string
+	// StatusFile, when set, receives a JSON snapshot of every source's
+	// status after each sync cycle, for external monitoring.
+	StatusFile string
+
+	mu      sync.Mutex
+	sources []Source
+	state   map[string]*sourceState
+}
+
+type sourceState struct {
+	status   SourceStatus
+	lastSync time.Time
+}
+
+// Add registers a source with the scheduler. It may be called before or
+// while Run is executing; new sources join the next cycle.
+func (s *Scheduler) Add(src Source) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.state == nil {
+		s.state = make(map[string]*sourceState)
+	}
+	s.sources = append(s.sources, src)
+	s.state[src.Name()] = &sourceState{status: SourceStatus{Source: src.Name()}}
+}
+
+// Status returns a snapshot of every source's sync state.
+func (s *Scheduler) Status() []SourceStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]SourceStatus, 0, len(s.sources))
+	for _, src := range s.sources {
+		out = append(out, s.state[src.Name()].status)
+	}
+	return out
+}
+
+// Run syncs all sources immediately and then on every interval — or, if
+// Schedule is set, at each cron time — until ctx is cancelled. It always
+// returns ctx.Err() unless the lock file could not be acquired.
+func (s *Scheduler) Run(ctx context.Context) error {
+	if s.LockFile != "" {
+		release, err := acquireLock(s.LockFile)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+	interval := s.interval()
+	for {
+		s.syncAll(ctx)
+		if err := s.writeStatus(); err != nil {
+			return err
+		}
+		wait := interval
+		if s.Schedule != nil {
+			wait = time.Until(s.Schedule.Next(time.Now()))
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// acquireLock creates path exclusively with this process's PID inside.
+// The returned func removes it.
+func acquireLock(path string) (func(), error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		if held, readErr := os.ReadFile(path); readErr == nil {
+			return nil, fmt.Errorf("ingest: lock %s held by pid %s", path, strings.TrimSpace(string(held)))
+		}
+		return nil, fmt.Errorf("ingest: acquire lock %s: %w", path, err)
+	}
+	fmt.Fprintln(f, os.Getpid())
+	f.Close()
+	return func() { os.Remove(path) }, nil
+}
+
+// writeStatus atomically replaces the status file with the current
+// snapshot.
+func (s *Scheduler) writeStatus() error {
+	if s.StatusFile == "" {
+		return nil
+	}
+	js, err := json.MarshalIndent(s.Status(), "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.StatusFile + ".tmp"
+	if err := os.WriteFile(tmp, js, 0o644); err != nil {
+		return fmt.Errorf("ingest: write status: %w", err)
+	}
+	return os.Rename(tmp, s.StatusFile)
+}
+
+// syncAll runs one sync of every registered source, bounded by
+// MaxConcurrent, and waits for all of them to finish.
+func (s *Scheduler) syncAll(ctx context.Context) {
+	s.mu.Lock()
+	sources := make([]Source, len(s.sources))
+	copy(sources, s.sources)
+	s.mu.Unlock()
+
+	maxc := s.MaxConcurrent
+	if maxc <= 0 {
+		maxc = 2
+	}
+	sem := make(chan struct{}, maxc)
+	var wg sync.WaitGroup
+	for _, src := range sources {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(src Source) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			s.syncOne(ctx, src)
+		}(src)
+	}
+	wg.Wait()
+}
+
+func (s *Scheduler) syncOne(ctx context.Context, src Source) {
+	if d := s.jitterDelay(); d > 0 {
+		t := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+	}
+
+	s.mu.Lock()
+	st := s.state[src.Name()]
+	since := st.lastSync
+	st.status.Running = true
+	s.mu.Unlock()
+
+	started := time.Now()
+	var stats Stats
+	var err error
+	if since.IsZero() {
+		stats, err = s.Ingestor.IngestSource(ctx, src)
+	} else {
+		stats, err = s.Ingestor.IngestChanges(ctx, src, since)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st.status.Running = false
+	st.status.LastRun = started
+	if s.Schedule != nil {
+		st.status.NextRun = s.Schedule.Next(started)
+	} else {
+		st.status.NextRun = started.Add(s.interval())
+	}
+	st.status.Runs++
+	st.status.Documents += stats.Documents
+	st.status.Chunks += stats.Chunks
+	if err != nil {
+		st.status.LastError = err.Error()
+		return
+	}
+	st.status.LastError = ""
+	st.lastSync = started
+}
+
+func (s *Scheduler) jitterDelay() time.Duration {
+	jitter := s.Jitter
+	if jitter <= 0 {
+		jitter = 0.1
+	}
+	return time.Duration(rand.Float64() * jitter * float64(s.interval()))
+}
+
+// interval returns the time between syncs, applying the one-hour default.
+func (s *Scheduler) interval() time.Duration {
+	if s.Interval > 0 {
+		return s.Interval
+	}
+	return time.Hour
+}
@@ -0,0 +1,194 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// fakeEmbedder returns a unit vector per text.
+type fakeEmbedder struct{}
+
+func (fakeEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	vecs := make([][]float32, len(texts))
+	for i := range texts {
+		vecs[i] = []float32{1}
+	}
+	return vecs, nil
+}
+
+// fakeUpserter records every upserted record.
+type fakeUpserter struct {
+	mu   sync.Mutex
+	recs []codex.VectorRecord
+}
+
+func (f *fakeUpserter) UpsertBatch(ctx context.Context, collection string, recs []codex.VectorRecord) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.recs = append(f.recs, recs...)
+	return nil
+}
+
+// fakeSource serves in-memory plain-text documents and counts fetches.
+type fakeSource struct {
+	name string
+
+	mu      sync.Mutex
+	docs    map[string]string
+	mods    map[string]time.Time
+	fetches map[string]int
+	// failFetch makes Fetch fail for the listed IDs until cleared.
+	failFetch map[string]bool
+}
+
+func newFakeSource(name string, docs map[string]string) *fakeSource {
+	mods := make(map[string]time.Time, len(docs))
+	for id := range docs {
+		mods[id] = time.Now().Add(-time.Hour)
+	}
+	return &fakeSource{name: name, docs: docs, mods: mods, fetches: map[string]int{}, failFetch: map[string]bool{}}
+}
+
+func (s *fakeSource) Name() string { return s.name }
+
+func (s *fakeSource) List(ctx context.Context, fn func(Ref) error) error {
+	s.mu.Lock()
+	refs := make([]Ref, 0, len(s.docs))
+	for id, body := range s.docs {
+		refs = append(refs, Ref{ID: id, Size: int64(len(body)), ModTime: s.mods[id], ContentType: "text/plain"})
+	}
+	s.mu.Unlock()
+	for _, ref := range refs {
+		if err := fn(ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeSource) Fetch(ctx context.Context, id string) (*Document, error) {
+	s.mu.Lock()
+	s.fetches[id]++
+	body, ok := s.docs[id]
+	fail := s.failFetch[id]
+	mod := s.mods[id]
+	s.mu.Unlock()
+	if fail {
+		return nil, fmt.Errorf("simulated fetch failure for %s", id)
+	}
+	if !ok {
+		return nil, fmt.Errorf("no document %s", id)
+	}
+	return &Document{
+		ID:          id,
+		SourceName:  s.name,
+		ContentType: "text/plain",
+		ModTime:     mod,
+		Body:        io.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+func (s *fakeSource) Changes(ctx context.Context, since time.Time, fn func(Ref) error) error {
+	return changesByModTime(ctx, s, since, fn)
+}
+
+func (s *fakeSource) fetchCount(id string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetches[id]
+}
+
+func (s *fakeSource) touch(id, body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.docs[id] = body
+	s.mods[id] = time.Now()
+}
+
+func TestSchedulerFullThenIncrementalSync(t *testing.T) {
+	src := newFakeSource("wiki", map[string]string{
+		"a.txt": "alpha document",
+		"b.txt": "beta document",
+	})
+	ing := NewIngestor(fakeEmbedder{}, &fakeUpserter{}, "kb")
+	statusFile := filepath.Join(t.TempDir(), "status.json")
+	s := &Scheduler{
+		Ingestor:   ing,
+		Interval:   20 * time.Millisecond,
+		Jitter:     1e-9,
+		StatusFile: statusFile,
+	}
+	s.Add(src)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+
+	waitForRuns := func(n int) SourceStatus {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			if st := s.Status(); len(st) == 1 && st[0].Runs >= n {
+				return st[0]
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		t.Fatalf("source never reached %d runs: %+v", n, s.Status())
+		return SourceStatus{}
+	}
+
+	st := waitForRuns(1)
+	if st.Documents != 2 || st.LastError != "" {
+		t.Fatalf("after full sync: %+v", st)
+	}
+	if !st.NextRun.After(st.LastRun) {
+		t.Fatalf("NextRun %v not after LastRun %v", st.NextRun, st.LastRun)
+	}
+
+	// Only the touched document should be refetched on the next cycle.
+	src.touch("a.txt", "alpha document, revised")
+	before := src.fetchCount("b.txt")
+	waitForRuns(st.Runs + 2)
+	if got := src.fetchCount("b.txt"); got != before {
+		t.Errorf("unchanged document refetched: %d -> %d", before, got)
+	}
+	if src.fetchCount("a.txt") < 2 {
+		t.Error("touched document not refetched")
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("Run returned %v", err)
+	}
+
+	status, err := os.ReadFile(statusFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(status), `"Source": "wiki"`) {
+		t.Fatalf("status file: %s", status)
+	}
+}
+
+func TestSchedulerNextRunUsesDefaultInterval(t *testing.T) {
+	src := newFakeSource("wiki", map[string]string{"a.txt": "alpha"})
+	ing := NewIngestor(fakeEmbedder{}, &fakeUpserter{}, "kb")
+	s := &Scheduler{Ingestor: ing, Jitter: 1e-9} // Interval deliberately unset
+	s.Add(src)
+
+	s.syncOne(context.Background(), src)
+
+	st := s.Status()[0]
+	if got := st.NextRun.Sub(st.LastRun); got != time.Hour {
+		t.Fatalf("NextRun - LastRun = %s, want the one-hour default", got)
+	}
+}
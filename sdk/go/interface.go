@@ -0,0 +1,30 @@
+package codex
+
+import "context"
+
+// Codex is the full client surface. Both *Client and *Pool implement it,
+// so services can swap a single client for a pooled one without code
+// changes.
+type Codex interface {
+	Chat(ctx context.Context, messages []Message, opts ...ChatOption) (string, error)
+	ChatCompletion(ctx context.Context, messages []Message, opts ...ChatOption) (*ChatResponse, error)
+	Embed(ctx context.Context, text string) ([]float32, error)
+	EmbedBatch(ctx context.Context, texts []string) ([][]float32, error)
+
+	Upsert(ctx context.Context, collection string, rec VectorRecord) error
+	UpsertBatch(ctx context.Context, collection string, recs []VectorRecord) error
+	Query(ctx context.Context, collection string, vector []float32, topK int, filter Filter) ([]Match, error)
+	Fetch(ctx context.Context, collection string, ids []string) ([]VectorRecord, error)
+	Delete(ctx context.Context, collection string, ids []string) error
+	Scroll(ctx context.Context, collection, cursor string, limit int) ([]VectorRecord, string, error)
+
+	RAGAnswer(ctx context.Context, question string, opts ...RAGOption) (*Result, error)
+
+	ListCollections(ctx context.Context) ([]Collection, error)
+	CreateCollection(ctx context.Context, name string, dimensions int) error
+	DeleteCollection(ctx context.Context, name string) error
+	ListTiers(ctx context.Context) ([]Tier, error)
+	ListModels(ctx context.Context) ([]Model, error)
+}
+
+var _ Codex = (*Client)(nil)
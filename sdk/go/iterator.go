@@ -0,0 +1,69 @@
+package codex
+
+import "context"
+
+// Seq2 is the push-iterator shape of iter.Seq2, declared locally while
+// the module targets Go 1.21. On Go 1.23+ toolchains the returned
+// iterators can be ranged over directly:
+//
+//	for m, err := range c.QueryIter(ctx, "kb", vec, 100, nil) { ... }
+//
+// On older toolchains, call the value with a yield func.
+type Seq2[V, E any] func(yield func(V, E) bool)
+
+const defaultPageSize = 100
+
+// QueryIter returns the records most similar to vector as a lazy
+// iterator, fetching pages of pageSize on demand so consumers can range
+// over arbitrarily deep result sets without choosing a topK up front.
+// On error the iterator yields a zero Match with the error and stops.
+func (c *Client) QueryIter(ctx context.Context, collection string, vector []float32, pageSize int, filter Filter) Seq2[Match, error] {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	return func(yield func(Match, error) bool) {
+		for offset := 0; ; offset += pageSize {
+			matches, err := c.queryPage(ctx, collection, vector, pageSize, offset, filter)
+			if err != nil {
+				yield(Match{}, err)
+				return
+			}
+			for _, m := range matches {
+				if !yield(m, nil) {
+					return
+				}
+			}
+			if len(matches) < pageSize {
+				return
+			}
+		}
+	}
+}
+
+// ScrollIter iterates over every record in collection, driving the
+// scroll cursor internally one page of pageSize at a time. On error the
+// iterator yields a zero record with the error and stops.
+func (c *Client) ScrollIter(ctx context.Context, collection string, pageSize int) Seq2[VectorRecord, error] {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	return func(yield func(VectorRecord, error) bool) {
+		cursor := ""
+		for {
+			recs, next, err := c.Scroll(ctx, collection, cursor, pageSize)
+			if err != nil {
+				yield(VectorRecord{}, err)
+				return
+			}
+			for _, rec := range recs {
+				if !yield(rec, nil) {
+					return
+				}
+			}
+			if next == "" {
+				return
+			}
+			cursor = next
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestQueryIterPagesLazily(t *testing.T) {
+	const total = 5
+	var requests int
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var req queryRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		var matches []Match
+		for i := req.Offset; i < req.Offset+req.TopK && i < total; i++ {
+			matches = append(matches, Match{VectorRecord: VectorRecord{ID: fmt.Sprintf("r%d", i)}})
+		}
+		json.NewEncoder(w).Encode(queryWire{Matches: matches})
+	}))
+
+	var ids []string
+	c.QueryIter(context.Background(), "kb", []float32{1}, 2, nil)(func(m Match, err error) bool {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, m.ID)
+		return true
+	})
+	if len(ids) != total || ids[0] != "r0" || ids[4] != "r4" {
+		t.Fatalf("bad ids: %v", ids)
+	}
+	if requests != 3 {
+		t.Fatalf("expected 3 page fetches, got %d", requests)
+	}
+
+	// Breaking early must not fetch further pages.
+	requests = 0
+	c.QueryIter(context.Background(), "kb", []float32{1}, 2, nil)(func(m Match, err error) bool {
+		return false
+	})
+	if requests != 1 {
+		t.Fatalf("early break fetched %d pages", requests)
+	}
+}
+
+func TestScrollIterFollowsCursor(t *testing.T) {
+	pages := map[string]scrollWire{
+		"":   {Records: []VectorRecord{{ID: "a"}, {ID: "b"}}, NextCursor: "c2"},
+		"c2": {Records: []VectorRecord{{ID: "c"}}},
+	}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req scrollRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		json.NewEncoder(w).Encode(pages[req.Cursor])
+	}))
+
+	var ids []string
+	c.ScrollIter(context.Background(), "kb", 2)(func(rec VectorRecord, err error) bool {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, rec.ID)
+		return true
+	})
+	if len(ids) != 3 || ids[2] != "c" {
+		t.Fatalf("bad ids: %v", ids)
+	}
+}
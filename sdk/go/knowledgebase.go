@@ -0,0 +1,136 @@
+package codex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// KnowledgeBase bundles everything that defines a queryable corpus —
+// the collection, how it is chunked and embedded, the prompt its
+// answers are generated with, and who may read it — as one named
+// server-side resource. Asking WithKnowledgeBase("support-docs") is
+// then self-describing and reproducible, instead of scattering the
+// same settings across every caller.
+type KnowledgeBase struct {
+	Name        string `json:"name"`
+	Collection  string `json:"collection"`
+	Description string `json:"description,omitempty"`
+
+	// ChunkSize and ChunkOverlap are the ingestion chunking settings,
+	// in runes, matching the ingest package's defaults when zero.
+	ChunkSize    int `json:"chunk_size,omitempty"`
+	ChunkOverlap int `json:"chunk_overlap,omitempty"`
+
+	// EmbeddingModel pins which model embeds this corpus, so queries
+	// and documents can never drift apart.
+	EmbeddingModel string `json:"embedding_model,omitempty"`
+
+	// PromptTemplate is the RAG answer prompt, with {context} and
+	// {question} placeholders. Empty uses the server default.
+	PromptTemplate string `json:"prompt_template,omitempty"`
+
+	// Readers restricts who may query the knowledge base, as subject or
+	// group names; empty means unrestricted.
+	Readers []string `json:"readers,omitempty"`
+
+	// Defaults are the retrieval settings queries inherit when they do
+	// not set their own, so ops can retune retrieval by updating the
+	// knowledge base instead of redeploying callers.
+	Defaults *QueryDefaults `json:"defaults,omitempty"`
+}
+
+// QueryDefaults are per-knowledge-base retrieval defaults. The server
+// applies them to WithKnowledgeBase queries that leave the matching
+// option unset; explicitly set options always win.
+type QueryDefaults struct {
+	TopK     int    `json:"top_k,omitempty"`
+	Filter   Filter `json:"filter,omitempty"`
+	Reranker string `json:"reranker,omitempty"`
+	Tier     string `json:"tier,omitempty"`
+}
+
+// Apply fills o's unset retrieval fields from the knowledge base's
+// defaults, for callers that resolve knowledge bases client-side.
+// Filter defaults merge under the query's own keys.
+func (kb *KnowledgeBase) Apply(o *RAGOptions) {
+	if o.Collection == "" {
+		o.Collection = kb.Collection
+	}
+	d := kb.Defaults
+	if d == nil {
+		return
+	}
+	if o.TopK == 0 {
+		o.TopK = d.TopK
+	}
+	if o.Tier == "" {
+		o.Tier = d.Tier
+	}
+	if o.Reranker == "" {
+		o.Reranker = d.Reranker
+	}
+	if len(d.Filter) > 0 {
+		if o.Filter == nil {
+			o.Filter = Filter{}
+		}
+		for k, v := range d.Filter {
+			if _, ok := o.Filter[k]; !ok {
+				o.Filter[k] = v
+			}
+		}
+	}
+}
+
+type knowledgeBasesWire struct {
+	KnowledgeBases []KnowledgeBase `json:"knowledge_bases"`
+}
+
+// CreateKnowledgeBase registers kb on the server. The named collection
+// must already exist.
+func (c *Client) CreateKnowledgeBase(ctx context.Context, kb KnowledgeBase) error {
+	if kb.Name == "" || kb.Collection == "" {
+		return errors.New("codex: knowledge base needs a name and a collection")
+	}
+	return c.do(ctx, http.MethodPost, "/v1/kb", kb, nil)
+}
+
+// UpdateKnowledgeBase replaces the stored definition of kb.Name.
+func (c *Client) UpdateKnowledgeBase(ctx context.Context, kb KnowledgeBase) error {
+	if kb.Name == "" {
+		return errors.New("codex: knowledge base needs a name")
+	}
+	return c.do(ctx, http.MethodPut, "/v1/kb/"+url.PathEscape(kb.Name), kb, nil)
+}
+
+// GetKnowledgeBase fetches one knowledge base by name.
+func (c *Client) GetKnowledgeBase(ctx context.Context, name string) (*KnowledgeBase, error) {
+	var kb KnowledgeBase
+	if err := c.do(ctx, http.MethodGet, "/v1/kb/"+url.PathEscape(name), nil, &kb); err != nil {
+		return nil, err
+	}
+	return &kb, nil
+}
+
+// ListKnowledgeBases returns every knowledge base the caller may see.
+func (c *Client) ListKnowledgeBases(ctx context.Context) ([]KnowledgeBase, error) {
+	var resp knowledgeBasesWire
+	if err := c.do(ctx, http.MethodGet, "/v1/kb", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.KnowledgeBases, nil
+}
+
+// DeleteKnowledgeBase removes the definition. The underlying collection
+// and its records are left alone.
+func (c *Client) DeleteKnowledgeBase(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, "/v1/kb/"+url.PathEscape(name), nil, nil)
+}
+
+// WithKnowledgeBase answers from the named knowledge base: the server
+// resolves its collection, prompt template, and access policy, so the
+// call needs no other retrieval configuration.
+func WithKnowledgeBase(name string) RAGOption {
+	return func(o *RAGOptions) { o.KnowledgeBase = name }
+}
@@ -0,0 +1,110 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestKnowledgeBaseCRUD(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/kb":
+			var kb KnowledgeBase
+			json.NewDecoder(r.Body).Decode(&kb)
+			if kb.Name != "support-docs" || kb.Collection != "support" || kb.ChunkSize != 1000 {
+				t.Errorf("kb: %+v", kb)
+			}
+			w.WriteHeader(http.StatusCreated)
+		case "GET /v1/kb/support-docs":
+			json.NewEncoder(w).Encode(KnowledgeBase{
+				Name: "support-docs", Collection: "support",
+				PromptTemplate: "Answer from {context}: {question}",
+				Readers:        []string{"support-team"},
+			})
+		case "GET /v1/kb":
+			json.NewEncoder(w).Encode(knowledgeBasesWire{KnowledgeBases: []KnowledgeBase{
+				{Name: "support-docs"}, {Name: "eng-wiki"},
+			}})
+		case "DELETE /v1/kb/support-docs":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	ctx := context.Background()
+	err := c.CreateKnowledgeBase(ctx, KnowledgeBase{
+		Name: "support-docs", Collection: "support", ChunkSize: 1000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kb, err := c.GetKnowledgeBase(ctx, "support-docs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kb.PromptTemplate == "" || kb.Readers[0] != "support-team" {
+		t.Fatalf("kb: %+v", kb)
+	}
+	kbs, err := c.ListKnowledgeBases(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kbs) != 2 {
+		t.Fatalf("kbs: %+v", kbs)
+	}
+	if err := c.DeleteKnowledgeBase(ctx, "support-docs"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueryDefaultsApplyPrecedence(t *testing.T) {
+	kb := &KnowledgeBase{
+		Collection: "support",
+		Defaults: &QueryDefaults{
+			TopK:     12,
+			Tier:     "fast",
+			Reranker: "cross-encoder",
+			Filter:   Filter{"lang": "en", "status": "published"},
+		},
+	}
+	o := RAGOptions{TopK: 5, Filter: Filter{"lang": "de"}}
+	kb.Apply(&o)
+	if o.Collection != "support" {
+		t.Fatalf("collection = %q", o.Collection)
+	}
+	if o.TopK != 5 {
+		t.Fatalf("explicit topK overridden: %d", o.TopK)
+	}
+	if o.Tier != "fast" || o.Reranker != "cross-encoder" {
+		t.Fatalf("defaults not applied: %+v", o)
+	}
+	if o.Filter["lang"] != "de" || o.Filter["status"] != "published" {
+		t.Fatalf("filter merge: %+v", o.Filter)
+	}
+}
+
+func TestCreateKnowledgeBaseValidates(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected")
+	}))
+	if err := c.CreateKnowledgeBase(context.Background(), KnowledgeBase{Name: "x"}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestWithKnowledgeBaseOnWire(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ragRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.KnowledgeBase != "support-docs" {
+			t.Errorf("kb = %q", req.KnowledgeBase)
+		}
+		json.NewEncoder(w).Encode(Result{Answer: "ok"})
+	}))
+	if _, err := c.RAGAnswer(context.Background(), "q", WithKnowledgeBase("support-docs")); err != nil {
+		t.Fatal(err)
+	}
+}
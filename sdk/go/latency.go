@@ -0,0 +1,111 @@
+package codex
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// latencyEWMAWeight is how much one new observation moves a tier's
+// moving average; 0.3 adapts within a handful of calls without letting a
+// single slow response flip the routing.
+const latencyEWMAWeight = 0.3
+
+// latencyTracker maintains a per-tier latency EWMA from completed chat
+// calls, feeding WithLatencyBudget routing.
+type latencyTracker struct {
+	mu    sync.Mutex
+	ewma  map[string]time.Duration
+	chain []string // tier names in ascending capability, cached from ListTiers
+}
+
+func (t *latencyTracker) observe(tier string, d time.Duration) {
+	if tier == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.ewma == nil {
+		t.ewma = make(map[string]time.Duration)
+	}
+	prev, ok := t.ewma[tier]
+	if !ok {
+		t.ewma[tier] = d
+		return
+	}
+	t.ewma[tier] = time.Duration(latencyEWMAWeight*float64(d) + (1-latencyEWMAWeight)*float64(prev))
+}
+
+func (t *latencyTracker) average(tier string) (time.Duration, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	d, ok := t.ewma[tier]
+	return d, ok
+}
+
+// TierLatencies returns the current per-tier latency averages observed
+// by latency-budget routing, for dashboards and tuning.
+func (c *Client) TierLatencies() map[string]time.Duration {
+	c.latency.mu.Lock()
+	defer c.latency.mu.Unlock()
+	out := make(map[string]time.Duration, len(c.latency.ewma))
+	for tier, d := range c.latency.ewma {
+		out[tier] = d
+	}
+	return out
+}
+
+// routeByLatency picks the most capable tier whose observed latency fits
+// the budget. Tiers with no observations yet are assumed to fit, so each
+// tier gets sampled before being ruled out; when nothing fits, the
+// fastest known tier is the least-bad answer.
+func (c *Client) routeByLatency(ctx context.Context) string {
+	chain := c.tierChain(ctx)
+	var fastest string
+	var fastestAvg time.Duration
+	for i := len(chain) - 1; i >= 0; i-- {
+		tier := chain[i]
+		if tier == "" {
+			continue
+		}
+		avg, ok := c.latency.average(tier)
+		if !ok || avg <= c.cfg.latencyBudget {
+			return tier
+		}
+		if fastest == "" || avg < fastestAvg {
+			fastest, fastestAvg = tier, avg
+		}
+	}
+	return fastest
+}
+
+// tierChain returns tier names in ascending capability: the configured
+// TierRouter's chain when one is installed, otherwise the server's tier
+// list ordered by price (fetched once and cached).
+func (c *Client) tierChain(ctx context.Context) []string {
+	if r := c.cfg.router; r != nil {
+		return []string{r.Cheap, r.Standard, r.Premium}
+	}
+	c.latency.mu.Lock()
+	chain := c.latency.chain
+	c.latency.mu.Unlock()
+	if chain != nil {
+		return chain
+	}
+	tiers, err := c.ListTiers(ctx)
+	if err != nil {
+		return nil
+	}
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].InputPricePerMTok < tiers[j].InputPricePerMTok
+	})
+	chain = make([]string, len(tiers))
+	for i, tier := range tiers {
+		chain[i] = tier.Name
+	}
+	c.latency.mu.Lock()
+	c.latency.chain = chain
+	c.latency.mu.Unlock()
+	return chain
+}
@@ -0,0 +1,122 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func latencyTestRouter() *TierRouter {
+	return &TierRouter{Cheap: "cheap", Standard: "standard", Premium: "premium"}
+}
+
+func TestLatencyBudgetPicksBestFittingTier(t *testing.T) {
+	var gotTier string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req chatRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		gotTier = req.Tier
+		json.NewEncoder(w).Encode(ChatResponse{Reply: "ok", Tier: req.Tier})
+	}), WithTierRouter(latencyTestRouter()), WithLatencyBudget(time.Second), WithDefaultTier(TierAuto))
+
+	// Premium is known to blow the budget; standard fits.
+	c.latency.observe("premium", 3*time.Second)
+	c.latency.observe("standard", 400*time.Millisecond)
+
+	if _, err := c.Chat(context.Background(), []Message{{Role: "user", Content: "hi"}}); err != nil {
+		t.Fatal(err)
+	}
+	if gotTier != "standard" {
+		t.Fatalf("tier = %q, want standard", gotTier)
+	}
+}
+
+func TestLatencyBudgetAssumesUnsampledTiersFit(t *testing.T) {
+	var gotTier string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req chatRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		gotTier = req.Tier
+		json.NewEncoder(w).Encode(ChatResponse{Reply: "ok", Tier: req.Tier})
+	}), WithTierRouter(latencyTestRouter()), WithLatencyBudget(time.Second), WithDefaultTier(TierAuto))
+
+	if _, err := c.Chat(context.Background(), []Message{{Role: "user", Content: "hi"}}); err != nil {
+		t.Fatal(err)
+	}
+	if gotTier != "premium" {
+		t.Fatalf("tier = %q, want premium before any samples", gotTier)
+	}
+}
+
+func TestLatencyBudgetFallsBackToFastest(t *testing.T) {
+	var gotTier string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req chatRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		gotTier = req.Tier
+		json.NewEncoder(w).Encode(ChatResponse{Reply: "ok", Tier: req.Tier})
+	}), WithTierRouter(latencyTestRouter()), WithLatencyBudget(time.Second), WithDefaultTier(TierAuto))
+
+	c.latency.observe("premium", 5*time.Second)
+	c.latency.observe("standard", 3*time.Second)
+	c.latency.observe("cheap", 2*time.Second)
+
+	if _, err := c.Chat(context.Background(), []Message{{Role: "user", Content: "hi"}}); err != nil {
+		t.Fatal(err)
+	}
+	if gotTier != "cheap" {
+		t.Fatalf("tier = %q, want cheap when nothing fits", gotTier)
+	}
+}
+
+func TestLatencyChainFromServerTiers(t *testing.T) {
+	var gotTier string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/tiers":
+			json.NewEncoder(w).Encode(map[string][]Tier{"tiers": {
+				{Name: "fast", InputPricePerMTok: 0.5},
+				{Name: "smart", InputPricePerMTok: 5},
+			}})
+		case "/v1/chat":
+			var req chatRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			gotTier = req.Tier
+			json.NewEncoder(w).Encode(ChatResponse{Reply: "ok", Tier: req.Tier})
+		default:
+			t.Errorf("path = %s", r.URL.Path)
+		}
+	}), WithLatencyBudget(time.Second), WithDefaultTier(TierAuto))
+
+	c.latency.observe("smart", 4*time.Second)
+
+	if _, err := c.Chat(context.Background(), []Message{{Role: "user", Content: "hi"}}); err != nil {
+		t.Fatal(err)
+	}
+	if gotTier != "fast" {
+		t.Fatalf("tier = %q, want fast", gotTier)
+	}
+}
+
+func TestLatencyObservationsFeedEWMA(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ChatResponse{Reply: "ok", Tier: "standard"})
+	}), WithTierRouter(latencyTestRouter()), WithLatencyBudget(time.Second))
+
+	if _, err := c.Chat(context.Background(), []Message{{Role: "user", Content: "hi"}}, WithTier("standard")); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.TierLatencies()["standard"]; !ok {
+		t.Fatal("no latency recorded for standard tier")
+	}
+
+	tr := &latencyTracker{}
+	tr.observe("x", 1000*time.Millisecond)
+	tr.observe("x", 2000*time.Millisecond)
+	avg, _ := tr.average("x")
+	if avg != 1300*time.Millisecond {
+		t.Fatalf("ewma = %s, want 1.3s", avg)
+	}
+}
@@ -0,0 +1,173 @@
+// Package memory maintains conversation context across turns: a
+// short-term window of verbatim messages, plus long-term memory where
+// older turns are summarized into salient facts, embedded, and stored in
+// a dedicated collection for retrieval on later turns.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// Memory tracks one conversation. It is safe for concurrent use.
+type Memory struct {
+	// Client performs chat (summarization), embedding, and vector calls.
+	Client codex.Codex
+	// Collection is the long-term memory collection.
+	Collection string
+	// SessionID scopes stored facts to this conversation; facts from
+	// other sessions are still retrieved (shared long-term memory) but
+	// tagged distinctly. Required when storing.
+	SessionID string
+	// Window is the number of messages kept verbatim before the oldest
+	// turns are summarized and forgotten. Defaults to 20.
+	Window int
+	// RecallK is how many long-term facts are retrieved per turn.
+	// Defaults to 5.
+	RecallK int
+	// SummarizeTier selects the (typically cheap) tier used to distill
+	// facts from forgotten turns.
+	SummarizeTier string
+
+	mu    sync.Mutex
+	turns []codex.Message
+}
+
+// Observe records one completed exchange. When the short-term window
+// overflows, the oldest turns are summarized into facts, written to
+// long-term memory, and dropped.
+func (m *Memory) Observe(ctx context.Context, user, assistant string) error {
+	m.mu.Lock()
+	m.turns = append(m.turns,
+		codex.Message{Role: codex.RoleUser, Content: user},
+		codex.Message{Role: codex.RoleAssistant, Content: assistant},
+	)
+	window := m.Window
+	if window <= 0 {
+		window = 20
+	}
+	var overflow []codex.Message
+	if len(m.turns) > window {
+		// Forget whole exchanges, keeping the window aligned on pairs.
+		drop := len(m.turns) - window
+		if drop%2 == 1 {
+			drop++
+		}
+		overflow = append([]codex.Message{}, m.turns[:drop]...)
+		m.turns = m.turns[drop:]
+	}
+	m.mu.Unlock()
+
+	if len(overflow) == 0 {
+		return nil
+	}
+	return m.distill(ctx, overflow)
+}
+
+// distill summarizes forgotten turns into facts and stores each one in
+// long-term memory.
+func (m *Memory) distill(ctx context.Context, turns []codex.Message) error {
+	var transcript strings.Builder
+	for _, t := range turns {
+		fmt.Fprintf(&transcript, "%s: %s\n", t.Role, t.Content)
+	}
+	prompt := []codex.Message{
+		{Role: codex.RoleSystem, Content: "Extract the durable facts from this conversation fragment that would be worth remembering in future conversations (user preferences, decisions made, important details). Output one fact per line, no numbering. Output nothing if there is nothing worth remembering."},
+		{Role: codex.RoleUser, Content: transcript.String()},
+	}
+	var opts []codex.ChatOption
+	if m.SummarizeTier != "" {
+		opts = append(opts, codex.WithTier(m.SummarizeTier))
+	}
+	summary, err := m.Client.Chat(ctx, prompt, opts...)
+	if err != nil {
+		return fmt.Errorf("memory: summarize: %w", err)
+	}
+	now := time.Now()
+	for i, line := range strings.Split(summary, "\n") {
+		fact := strings.TrimSpace(line)
+		if fact == "" {
+			continue
+		}
+		vec, err := m.Client.Embed(ctx, fact)
+		if err != nil {
+			return fmt.Errorf("memory: embed fact: %w", err)
+		}
+		err = m.Client.Upsert(ctx, m.Collection, codex.VectorRecord{
+			ID:       fmt.Sprintf("%s-%d-%d", m.SessionID, now.UnixNano(), i),
+			Vector:   vec,
+			Document: fact,
+			Metadata: map[string]any{"session": m.SessionID, "stored_at": now.Format(time.RFC3339)},
+		})
+		if err != nil {
+			return fmt.Errorf("memory: store fact: %w", err)
+		}
+	}
+	return nil
+}
+
+// Messages assembles the prompt for the next turn: a system message with
+// relevant long-term facts (retrieved by similarity to input), the
+// short-term window, and the new user input.
+func (m *Memory) Messages(ctx context.Context, input string) ([]codex.Message, error) {
+	facts, err := m.recall(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	window := append([]codex.Message{}, m.turns...)
+	m.mu.Unlock()
+
+	var messages []codex.Message
+	if len(facts) > 0 {
+		var b strings.Builder
+		b.WriteString("Relevant facts from earlier conversations:\n")
+		for _, f := range facts {
+			b.WriteString("- " + f + "\n")
+		}
+		messages = append(messages, codex.Message{Role: codex.RoleSystem, Content: b.String()})
+	}
+	messages = append(messages, window...)
+	messages = append(messages, codex.Message{Role: codex.RoleUser, Content: input})
+	return messages, nil
+}
+
+func (m *Memory) recall(ctx context.Context, input string) ([]string, error) {
+	if m.Collection == "" {
+		return nil, nil
+	}
+	k := m.RecallK
+	if k <= 0 {
+		k = 5
+	}
+	vec, err := m.Client.Embed(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("memory: embed query: %w", err)
+	}
+	matches, err := m.Client.Query(ctx, m.Collection, vec, k, nil)
+	if err != nil {
+		if codex.IsNotFound(err) {
+			return nil, nil // no memories yet
+		}
+		return nil, fmt.Errorf("memory: recall: %w", err)
+	}
+	facts := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if match.Document != "" {
+			facts = append(facts, match.Document)
+		}
+	}
+	return facts, nil
+}
+
+// Forget clears the short-term window. Long-term memory is untouched.
+func (m *Memory) Forget() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.turns = nil
+}
@@ -0,0 +1,194 @@
+package memory
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// fakeCodex records the memory calls and serves canned summaries and
+// query matches; unused methods panic via the embedded nil interface.
+type fakeCodex struct {
+	codex.Codex
+	chatReply string
+	chats     [][]codex.Message
+	embedded  []string
+	upserts   []codex.VectorRecord
+	matches   []codex.Match
+	queryErr  error
+	queries   int
+}
+
+func (f *fakeCodex) Chat(ctx context.Context, messages []codex.Message, opts ...codex.ChatOption) (string, error) {
+	f.chats = append(f.chats, messages)
+	return f.chatReply, nil
+}
+
+func (f *fakeCodex) Embed(ctx context.Context, text string) ([]float32, error) {
+	f.embedded = append(f.embedded, text)
+	return []float32{1}, nil
+}
+
+func (f *fakeCodex) Upsert(ctx context.Context, collection string, rec codex.VectorRecord) error {
+	f.upserts = append(f.upserts, rec)
+	return nil
+}
+
+func (f *fakeCodex) Query(ctx context.Context, collection string, vector []float32, topK int, filter codex.Filter) ([]codex.Match, error) {
+	f.queries++
+	return f.matches, f.queryErr
+}
+
+func TestObserveDistillsOverflowIntoFacts(t *testing.T) {
+	fake := &fakeCodex{chatReply: "likes tea\n\nworks remotely\n"}
+	m := &Memory{Client: fake, Collection: "mem", SessionID: "s1", Window: 4}
+	ctx := context.Background()
+
+	if err := m.Observe(ctx, "u1", "a1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Observe(ctx, "u2", "a2"); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.chats) != 0 {
+		t.Fatalf("distilled before the window overflowed: %d calls", len(fake.chats))
+	}
+
+	// The third exchange overflows the window; the oldest pair is
+	// summarized and dropped.
+	if err := m.Observe(ctx, "u3", "a3"); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.chats) != 1 {
+		t.Fatalf("chat calls = %d, want 1", len(fake.chats))
+	}
+	transcript := fake.chats[0][len(fake.chats[0])-1].Content
+	if !strings.Contains(transcript, "u1") || !strings.Contains(transcript, "a1") {
+		t.Errorf("transcript missing dropped pair: %q", transcript)
+	}
+	if strings.Contains(transcript, "u2") {
+		t.Errorf("transcript includes retained turns: %q", transcript)
+	}
+
+	// Blank summary lines are skipped; each fact becomes one record.
+	if len(fake.upserts) != 2 {
+		t.Fatalf("upserts = %+v", fake.upserts)
+	}
+	for _, rec := range fake.upserts {
+		if !strings.HasPrefix(rec.ID, "s1-") {
+			t.Errorf("record ID not session-scoped: %q", rec.ID)
+		}
+		if rec.Metadata["session"] != "s1" || rec.Metadata["stored_at"] == "" {
+			t.Errorf("record metadata: %+v", rec.Metadata)
+		}
+	}
+	if fake.upserts[0].Document != "likes tea" || fake.upserts[1].Document != "works remotely" {
+		t.Errorf("facts: %q, %q", fake.upserts[0].Document, fake.upserts[1].Document)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.turns) != 4 || m.turns[0].Content != "u2" {
+		t.Fatalf("window after eviction: %+v", m.turns)
+	}
+}
+
+func TestObserveEvictsWholePairs(t *testing.T) {
+	fake := &fakeCodex{}
+	m := &Memory{Client: fake, Collection: "mem", SessionID: "s1", Window: 3}
+
+	// An odd overflow still drops a whole exchange: four messages over a
+	// window of three evicts both halves of the first pair.
+	if err := m.Observe(context.Background(), "u1", "a1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Observe(context.Background(), "u2", "a2"); err != nil {
+		t.Fatal(err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.turns) != 2 || m.turns[0].Content != "u2" || m.turns[1].Content != "a2" {
+		t.Fatalf("window not pair-aligned: %+v", m.turns)
+	}
+}
+
+func TestMessagesRecallsFacts(t *testing.T) {
+	fake := &fakeCodex{matches: []codex.Match{
+		{VectorRecord: codex.VectorRecord{Document: "likes tea"}, Score: 0.9},
+		{VectorRecord: codex.VectorRecord{}, Score: 0.5}, // no document; skipped
+	}}
+	m := &Memory{Client: fake, Collection: "mem", SessionID: "s1"}
+	ctx := context.Background()
+	if err := m.Observe(ctx, "u1", "a1"); err != nil {
+		t.Fatal(err)
+	}
+
+	messages, err := m.Messages(ctx, "what do I drink?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.embedded) != 1 || fake.embedded[0] != "what do I drink?" {
+		t.Fatalf("recall embedded %q", fake.embedded)
+	}
+	if len(messages) != 4 {
+		t.Fatalf("messages: %+v", messages)
+	}
+	sys := messages[0]
+	if sys.Role != codex.RoleSystem || !strings.Contains(sys.Content, "- likes tea\n") {
+		t.Fatalf("facts message: %+v", sys)
+	}
+	if messages[1].Content != "u1" || messages[2].Content != "a1" {
+		t.Errorf("window not included: %+v", messages[1:3])
+	}
+	if last := messages[3]; last.Role != codex.RoleUser || last.Content != "what do I drink?" {
+		t.Errorf("input not appended: %+v", last)
+	}
+}
+
+func TestMessagesToleratesMissingCollection(t *testing.T) {
+	// A 404 means no memories have been stored yet, not a failure.
+	fake := &fakeCodex{queryErr: &codex.APIError{StatusCode: http.StatusNotFound}}
+	m := &Memory{Client: fake, Collection: "mem", SessionID: "s1"}
+
+	messages, err := m.Messages(context.Background(), "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 1 || messages[0].Role != codex.RoleUser {
+		t.Fatalf("messages: %+v", messages)
+	}
+}
+
+func TestMessagesSkipsRecallWithoutCollection(t *testing.T) {
+	fake := &fakeCodex{}
+	m := &Memory{Client: fake}
+
+	messages, err := m.Messages(context.Background(), "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fake.queries != 0 || len(fake.embedded) != 0 {
+		t.Fatalf("recall attempted without a collection: %d queries", fake.queries)
+	}
+	if len(messages) != 1 || messages[0].Content != "hello" {
+		t.Fatalf("messages: %+v", messages)
+	}
+}
+
+func TestForgetClearsWindow(t *testing.T) {
+	m := &Memory{Client: &fakeCodex{}}
+	if err := m.Observe(context.Background(), "u1", "a1"); err != nil {
+		t.Fatal(err)
+	}
+	m.Forget()
+	messages, err := m.Messages(context.Background(), "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("window survived Forget: %+v", messages)
+	}
+}
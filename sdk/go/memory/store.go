@@ -0,0 +1,252 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// Conversation is one persisted transcript.
+type Conversation struct {
+	ID        string          `json:"id"`
+	Title     string          `json:"title,omitempty"`
+	Metadata  map[string]any  `json:"metadata,omitempty"`
+	Messages  []codex.Message `json:"messages"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// ConversationInfo is a transcript listing entry, without the messages.
+type ConversationInfo struct {
+	ID        string         `json:"id"`
+	Title     string         `json:"title,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+	Messages  int            `json:"messages"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// ErrConversationNotFound is returned by Load for unknown IDs.
+var ErrConversationNotFound = errors.New("memory: conversation not found")
+
+// ConversationStore persists transcripts so CLIs and bots get durable
+// history without designing their own schema.
+type ConversationStore interface {
+	Save(ctx context.Context, conv *Conversation) error
+	Load(ctx context.Context, id string) (*Conversation, error)
+	List(ctx context.Context) ([]ConversationInfo, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// FSStore keeps one JSON file per conversation under Dir.
+type FSStore struct {
+	Dir string
+}
+
+func (s *FSStore) path(id string) string {
+	// IDs are caller-chosen; keep them filesystem-safe.
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, id)
+	return filepath.Join(s.Dir, safe+".json")
+}
+
+// Save implements ConversationStore.
+func (s *FSStore) Save(ctx context.Context, conv *Conversation) error {
+	if conv.ID == "" {
+		return errors.New("memory: conversation ID must not be empty")
+	}
+	if conv.CreatedAt.IsZero() {
+		conv.CreatedAt = time.Now()
+	}
+	conv.UpdatedAt = time.Now()
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(conv, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path(conv.ID) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path(conv.ID))
+}
+
+// Load implements ConversationStore.
+func (s *FSStore) Load(ctx context.Context, id string) (*Conversation, error) {
+	data, err := os.ReadFile(s.path(id))
+	if os.IsNotExist(err) {
+		return nil, ErrConversationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var conv Conversation
+	if err := json.Unmarshal(data, &conv); err != nil {
+		return nil, fmt.Errorf("memory: corrupt conversation %s: %w", id, err)
+	}
+	return &conv, nil
+}
+
+// List implements ConversationStore, most recently updated first.
+func (s *FSStore) List(ctx context.Context) ([]ConversationInfo, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var infos []ConversationInfo
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.Dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		var conv Conversation
+		if err := json.Unmarshal(data, &conv); err != nil {
+			continue
+		}
+		infos = append(infos, ConversationInfo{
+			ID:        conv.ID,
+			Title:     conv.Title,
+			Metadata:  conv.Metadata,
+			Messages:  len(conv.Messages),
+			UpdatedAt: conv.UpdatedAt,
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].UpdatedAt.After(infos[j].UpdatedAt) })
+	return infos, nil
+}
+
+// Delete implements ConversationStore. Deleting an absent conversation is
+// not an error.
+func (s *FSStore) Delete(ctx context.Context, id string) error {
+	err := os.Remove(s.path(id))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// SQLStore persists conversations through database/sql. It is written for
+// SQLite (any driver — mattn/go-sqlite3, modernc.org/sqlite — works; open
+// the *sql.DB in the application and hand it over) but the statements are
+// portable enough for most engines.
+type SQLStore struct {
+	db *sql.DB
+}
+
+// NewSQLStore creates the schema if needed and returns the store.
+func NewSQLStore(db *sql.DB) (*SQLStore, error) {
+	const schema = `CREATE TABLE IF NOT EXISTS conversations (
+		id         TEXT PRIMARY KEY,
+		title      TEXT NOT NULL DEFAULT '',
+		metadata   TEXT NOT NULL DEFAULT '{}',
+		messages   TEXT NOT NULL DEFAULT '[]',
+		created_at TIMESTAMP NOT NULL,
+		updated_at TIMESTAMP NOT NULL
+	)`
+	if _, err := db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("memory: create schema: %w", err)
+	}
+	return &SQLStore{db: db}, nil
+}
+
+// Save implements ConversationStore.
+func (s *SQLStore) Save(ctx context.Context, conv *Conversation) error {
+	if conv.ID == "" {
+		return errors.New("memory: conversation ID must not be empty")
+	}
+	if conv.CreatedAt.IsZero() {
+		conv.CreatedAt = time.Now()
+	}
+	conv.UpdatedAt = time.Now()
+	meta, err := json.Marshal(conv.Metadata)
+	if err != nil {
+		return err
+	}
+	msgs, err := json.Marshal(conv.Messages)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `INSERT INTO conversations (id, title, metadata, messages, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET title=excluded.title, metadata=excluded.metadata,
+			messages=excluded.messages, updated_at=excluded.updated_at`,
+		conv.ID, conv.Title, string(meta), string(msgs), conv.CreatedAt, conv.UpdatedAt)
+	return err
+}
+
+// Load implements ConversationStore.
+func (s *SQLStore) Load(ctx context.Context, id string) (*Conversation, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT id, title, metadata, messages, created_at, updated_at FROM conversations WHERE id = ?`, id)
+	var conv Conversation
+	var meta, msgs string
+	err := row.Scan(&conv.ID, &conv.Title, &meta, &msgs, &conv.CreatedAt, &conv.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrConversationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(meta), &conv.Metadata); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(msgs), &conv.Messages); err != nil {
+		return nil, err
+	}
+	return &conv, nil
+}
+
+// List implements ConversationStore, most recently updated first.
+func (s *SQLStore) List(ctx context.Context) ([]ConversationInfo, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, title, metadata, messages, updated_at FROM conversations ORDER BY updated_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var infos []ConversationInfo
+	for rows.Next() {
+		var info ConversationInfo
+		var meta, msgs string
+		if err := rows.Scan(&info.ID, &info.Title, &meta, &msgs, &info.UpdatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(meta), &info.Metadata); err != nil {
+			return nil, err
+		}
+		var messages []codex.Message
+		if err := json.Unmarshal([]byte(msgs), &messages); err != nil {
+			return nil, err
+		}
+		info.Messages = len(messages)
+		infos = append(infos, info)
+	}
+	return infos, rows.Err()
+}
+
+// Delete implements ConversationStore.
+func (s *SQLStore) Delete(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM conversations WHERE id = ?`, id)
+	return err
+}
@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+func TestFSStoreRoundTrip(t *testing.T) {
+	store := &FSStore{Dir: t.TempDir()}
+	ctx := context.Background()
+
+	conv := &Conversation{
+		ID:    "chat-1",
+		Title: "first chat",
+		Messages: []codex.Message{
+			{Role: codex.RoleUser, Content: "hello"},
+			{Role: codex.RoleAssistant, Content: "hi there"},
+		},
+		Metadata: map[string]any{"channel": "cli"},
+	}
+	if err := store.Save(ctx, conv); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := store.Load(ctx, "chat-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Title != "first chat" || len(loaded.Messages) != 2 || loaded.Messages[1].Content != "hi there" {
+		t.Fatalf("bad round trip: %+v", loaded)
+	}
+
+	infos, err := store.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 1 || infos[0].ID != "chat-1" || infos[0].Messages != 2 {
+		t.Fatalf("bad listing: %+v", infos)
+	}
+
+	if err := store.Delete(ctx, "chat-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Load(ctx, "chat-1"); !errors.Is(err, ErrConversationNotFound) {
+		t.Fatalf("expected not-found, got %v", err)
+	}
+}
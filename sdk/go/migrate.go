@@ -0,0 +1,163 @@
+package codex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MigrateOptions tunes a Migrate run.
+type MigrateOptions struct {
+	// Collection to migrate; defaults to the source client's default.
+	Collection string
+	// Reembed recomputes each document's vector on the destination
+	// (with its default tier) instead of copying the source vector —
+	// required when the destination runs a different embedding model.
+	Reembed bool
+	// BatchSize is how many records move per scroll/upsert round.
+	// Defaults to 100.
+	BatchSize int
+	// Throttle pauses between batches so a live source isn't starved.
+	Throttle time.Duration
+	// VerifySample fetches this many migrated records back from the
+	// destination and checks them; 0 skips verification.
+	VerifySample int
+	// Progress receives updates as batches land.
+	Progress ProgressSink
+}
+
+// MigrateStats summarizes a completed migration.
+type MigrateStats struct {
+	Records    int
+	Verified   int
+	Mismatched int
+}
+
+// Migrate copies a collection from one server to another by scrolling
+// records out of src and upserting them into dst, optionally
+// re-embedding documents on the destination's model along the way. It
+// creates the destination collection if needed and finishes with a
+// verification pass over a sample of migrated records.
+func Migrate(ctx context.Context, src, dst *Client, opts MigrateOptions) (*MigrateStats, error) {
+	collection := opts.Collection
+	if collection == "" {
+		collection = src.cfg.defaultCollection
+	}
+	if collection == "" {
+		return nil, errors.New("codex: migrate: no collection specified")
+	}
+	batch := opts.BatchSize
+	if batch <= 0 {
+		batch = 100
+	}
+
+	if err := ensureCollection(ctx, src, dst, collection); err != nil {
+		return nil, err
+	}
+
+	stats := &MigrateStats{}
+	started := time.Now()
+	var sample []string
+	cursor := ""
+	for {
+		recs, next, err := src.Scroll(ctx, collection, cursor, batch)
+		if err != nil {
+			return stats, fmt.Errorf("codex: migrate: scroll: %w", err)
+		}
+		if len(recs) > 0 {
+			if opts.Reembed {
+				if err := reembedRecords(ctx, dst, recs); err != nil {
+					return stats, err
+				}
+			}
+			if err := dst.UpsertBatch(ctx, collection, recs); err != nil {
+				return stats, fmt.Errorf("codex: migrate: upsert: %w", err)
+			}
+			stats.Records += len(recs)
+			if opts.VerifySample > 0 && len(sample) < opts.VerifySample {
+				sample = append(sample, recs[0].ID)
+			}
+			if opts.Progress != nil {
+				opts.Progress.Progress(Progress{Operation: "migrate", Done: stats.Records, Started: started})
+			}
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+		if opts.Throttle > 0 {
+			select {
+			case <-ctx.Done():
+				return stats, ctx.Err()
+			case <-time.After(opts.Throttle):
+			}
+		}
+	}
+
+	if len(sample) > 0 {
+		got, err := dst.Fetch(ctx, collection, sample)
+		if err != nil {
+			return stats, fmt.Errorf("codex: migrate: verify: %w", err)
+		}
+		found := make(map[string]bool, len(got))
+		for _, rec := range got {
+			found[rec.ID] = len(rec.Vector) > 0
+		}
+		for _, id := range sample {
+			stats.Verified++
+			if !found[id] {
+				stats.Mismatched++
+			}
+		}
+		if stats.Mismatched > 0 {
+			return stats, fmt.Errorf("codex: migrate: verification failed for %d of %d sampled records", stats.Mismatched, stats.Verified)
+		}
+	}
+	return stats, nil
+}
+
+// ensureCollection creates collection on dst with the source's
+// dimensions, tolerating it already existing.
+func ensureCollection(ctx context.Context, src, dst *Client, collection string) error {
+	cols, err := src.ListCollections(ctx)
+	if err != nil {
+		return fmt.Errorf("codex: migrate: list source collections: %w", err)
+	}
+	for _, col := range cols {
+		if col.Name == collection {
+			err := dst.CreateCollection(ctx, collection, col.Dimensions)
+			var ae *APIError
+			if errors.As(err, &ae) && ae.StatusCode == 409 {
+				return nil // already there
+			}
+			return err
+		}
+	}
+	return fmt.Errorf("codex: migrate: collection %q not found on source", collection)
+}
+
+// reembedRecords replaces each record's vector with a fresh embedding of
+// its document computed by dst. Records without document text keep their
+// original vector.
+func reembedRecords(ctx context.Context, dst *Client, recs []VectorRecord) error {
+	var texts []string
+	var idx []int
+	for i, rec := range recs {
+		if rec.Document != "" {
+			texts = append(texts, rec.Document)
+			idx = append(idx, i)
+		}
+	}
+	if len(texts) == 0 {
+		return nil
+	}
+	vecs, err := dst.EmbedBatch(ctx, texts)
+	if err != nil {
+		return fmt.Errorf("codex: migrate: re-embed: %w", err)
+	}
+	for i, v := range vecs {
+		recs[idx[i]].Vector = v
+	}
+	return nil
+}
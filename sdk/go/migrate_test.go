@@ -0,0 +1,76 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	source := map[string][]VectorRecord{
+		"":   {{ID: "a", Vector: []float32{1}, Document: "doc a"}, {ID: "b", Vector: []float32{2}}},
+		"c2": {{ID: "c", Vector: []float32{3}}},
+	}
+	src := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/collections":
+			json.NewEncoder(w).Encode(collectionsWire{Collections: []Collection{{Name: "kb", Dimensions: 1}}})
+		case strings.HasSuffix(r.URL.Path, "/scroll"):
+			var req scrollRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			next := ""
+			if req.Cursor == "" {
+				next = "c2"
+			}
+			json.NewEncoder(w).Encode(scrollWire{Records: source[req.Cursor], NextCursor: next})
+		default:
+			t.Errorf("unexpected source call: %s", r.URL.Path)
+		}
+	}))
+
+	stored := map[string]VectorRecord{}
+	dst := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/collections" && r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+		case strings.HasSuffix(r.URL.Path, "/vectors"):
+			var req upsertRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			for _, rec := range req.Records {
+				stored[rec.ID] = rec
+			}
+		case strings.HasSuffix(r.URL.Path, "/fetch"):
+			var req fetchRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			var recs []VectorRecord
+			for _, id := range req.IDs {
+				if rec, ok := stored[id]; ok {
+					recs = append(recs, rec)
+				}
+			}
+			json.NewEncoder(w).Encode(fetchWire{Records: recs})
+		default:
+			t.Errorf("unexpected destination call: %s", r.URL.Path)
+		}
+	}))
+
+	stats, err := Migrate(context.Background(), src, dst, MigrateOptions{
+		Collection:   "kb",
+		BatchSize:    2,
+		VerifySample: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Records != 3 || len(stored) != 3 {
+		t.Fatalf("migrated %d records, stored %d", stats.Records, len(stored))
+	}
+	if stats.Verified != 2 || stats.Mismatched != 0 {
+		t.Fatalf("verification: %+v", stats)
+	}
+	if stored["a"].Document != "doc a" {
+		t.Fatalf("document lost in flight: %+v", stored["a"])
+	}
+}
@@ -0,0 +1,185 @@
+// Package monitor runs canary queries against a codex server on a fixed
+// cadence and checks the answers against assertions, surfacing silent
+// relevance regressions before users notice. Results are exposed as a
+// healthz endpoint and Prometheus-format metrics.
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// Canary is one watched question and what its answer must look like.
+type Canary struct {
+	Name     string
+	Question string
+	// RAGOptions are applied to the canary's RAGAnswer call.
+	RAGOptions []codex.RAGOption
+	// MustContain are case-insensitive substrings the answer must have.
+	MustContain []string
+	// MustCite requires at least one reference attributed to this
+	// collection (the "collection" metadata key, as set by
+	// multi-collection retrieval).
+	MustCite string
+	// MaxLatency fails the check when the answer takes longer.
+	MaxLatency time.Duration
+}
+
+// CheckResult is the latest outcome for one canary.
+type CheckResult struct {
+	Canary    string        `json:"canary"`
+	Healthy   bool          `json:"healthy"`
+	Latency   time.Duration `json:"latency"`
+	Failures  []string      `json:"failures,omitempty"`
+	CheckedAt time.Time     `json:"checked_at"`
+}
+
+// Monitor periodically runs every canary. Zero value plus Client and
+// Canaries is ready to use.
+type Monitor struct {
+	Client   codex.Codex
+	Canaries []Canary
+	// Interval between rounds; defaults to one minute.
+	Interval time.Duration
+
+	mu      sync.Mutex
+	results map[string]CheckResult
+	rounds  int64
+}
+
+// RunOnce checks every canary once and records the results.
+func (m *Monitor) RunOnce(ctx context.Context) []CheckResult {
+	out := make([]CheckResult, 0, len(m.Canaries))
+	for _, c := range m.Canaries {
+		out = append(out, m.check(ctx, c))
+	}
+	m.mu.Lock()
+	if m.results == nil {
+		m.results = make(map[string]CheckResult)
+	}
+	for _, res := range out {
+		m.results[res.Canary] = res
+	}
+	m.rounds++
+	m.mu.Unlock()
+	return out
+}
+
+// Run checks on every interval until ctx is cancelled. It always returns
+// ctx.Err().
+func (m *Monitor) Run(ctx context.Context) error {
+	interval := m.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		m.RunOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Monitor) check(ctx context.Context, c Canary) CheckResult {
+	res := CheckResult{Canary: c.Name, CheckedAt: time.Now()}
+	started := time.Now()
+	answer, err := m.Client.RAGAnswer(ctx, c.Question, c.RAGOptions...)
+	res.Latency = time.Since(started)
+	if err != nil {
+		res.Failures = append(res.Failures, "error: "+err.Error())
+		return res
+	}
+	lower := strings.ToLower(answer.Answer)
+	for _, want := range c.MustContain {
+		if !strings.Contains(lower, strings.ToLower(want)) {
+			res.Failures = append(res.Failures, "missing: "+want)
+		}
+	}
+	if c.MustCite != "" {
+		cited := false
+		for _, ref := range answer.References {
+			if col, _ := ref.Metadata["collection"].(string); col == c.MustCite {
+				cited = true
+				break
+			}
+		}
+		if !cited {
+			res.Failures = append(res.Failures, "no citation from: "+c.MustCite)
+		}
+	}
+	if c.MaxLatency > 0 && res.Latency > c.MaxLatency {
+		res.Failures = append(res.Failures, fmt.Sprintf("latency %s over budget %s", res.Latency.Round(time.Millisecond), c.MaxLatency))
+	}
+	res.Healthy = len(res.Failures) == 0
+	return res
+}
+
+// snapshot returns results in canary order.
+func (m *Monitor) snapshot() []CheckResult {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]CheckResult, 0, len(m.results))
+	for _, res := range m.results {
+		out = append(out, res)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Canary < out[j].Canary })
+	return out
+}
+
+// Healthz returns a handler answering 200 while every canary is healthy
+// and 503 otherwise, with the latest results as a JSON body either way.
+func (m *Monitor) Healthz() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		results := m.snapshot()
+		healthy := true
+		for _, res := range results {
+			healthy = healthy && res.Healthy
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"healthy": healthy, "canaries": results})
+	})
+}
+
+// Metrics returns a handler exposing the latest results in Prometheus
+// text format: per-canary health, latency, and failure counts.
+func (m *Monitor) Metrics() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		fmt.Fprintln(w, "# TYPE codex_canary_healthy gauge")
+		for _, res := range m.snapshot() {
+			healthy := 0
+			if res.Healthy {
+				healthy = 1
+			}
+			fmt.Fprintf(w, "codex_canary_healthy{canary=%q} %d\n", res.Canary, healthy)
+		}
+		fmt.Fprintln(w, "# TYPE codex_canary_latency_seconds gauge")
+		for _, res := range m.snapshot() {
+			fmt.Fprintf(w, "codex_canary_latency_seconds{canary=%q} %g\n", res.Canary, res.Latency.Seconds())
+		}
+		fmt.Fprintln(w, "# TYPE codex_canary_failures gauge")
+		for _, res := range m.snapshot() {
+			fmt.Fprintf(w, "codex_canary_failures{canary=%q} %d\n", res.Canary, len(res.Failures))
+		}
+		m.mu.Lock()
+		rounds := m.rounds
+		m.mu.Unlock()
+		fmt.Fprintln(w, "# TYPE codex_canary_rounds_total counter")
+		fmt.Fprintf(w, "codex_canary_rounds_total %d\n", rounds)
+	})
+}
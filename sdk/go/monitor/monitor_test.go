@@ -0,0 +1,93 @@
+package monitor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// stubCodex serves one canned result per question; unused methods panic
+// via the embedded nil interface.
+type stubCodex struct {
+	codex.Codex
+	results map[string]*codex.Result
+	delay   time.Duration
+}
+
+func (s *stubCodex) RAGAnswer(ctx context.Context, question string, opts ...codex.RAGOption) (*codex.Result, error) {
+	time.Sleep(s.delay)
+	return s.results[question], nil
+}
+
+func TestRunOnceAssertions(t *testing.T) {
+	stub := &stubCodex{results: map[string]*codex.Result{
+		"refund?": {
+			Answer: "Refunds are accepted within 30 days.",
+			References: []codex.Reference{
+				{ID: "a", Metadata: map[string]any{"collection": "billing"}},
+			},
+		},
+		"shipping?": {Answer: "I don't know."},
+	}}
+	m := &Monitor{Client: stub, Canaries: []Canary{
+		{Name: "refund", Question: "refund?", MustContain: []string{"30 days"}, MustCite: "billing"},
+		{Name: "shipping", Question: "shipping?", MustContain: []string{"5 business days"}, MustCite: "logistics"},
+	}}
+	results := m.RunOnce(context.Background())
+	if !results[0].Healthy {
+		t.Fatalf("refund canary: %+v", results[0])
+	}
+	if results[1].Healthy || len(results[1].Failures) != 2 {
+		t.Fatalf("shipping canary: %+v", results[1])
+	}
+}
+
+func TestLatencyBudget(t *testing.T) {
+	stub := &stubCodex{
+		results: map[string]*codex.Result{"q": {Answer: "ok"}},
+		delay:   20 * time.Millisecond,
+	}
+	m := &Monitor{Client: stub, Canaries: []Canary{
+		{Name: "fast", Question: "q", MaxLatency: time.Millisecond},
+	}}
+	res := m.RunOnce(context.Background())[0]
+	if res.Healthy || !strings.Contains(res.Failures[0], "over budget") {
+		t.Fatalf("result: %+v", res)
+	}
+}
+
+func TestHealthzAndMetrics(t *testing.T) {
+	stub := &stubCodex{results: map[string]*codex.Result{"q": {Answer: "all good"}}}
+	m := &Monitor{Client: stub, Canaries: []Canary{
+		{Name: "basic", Question: "q", MustContain: []string{"good"}},
+	}}
+	m.RunOnce(context.Background())
+
+	rec := httptest.NewRecorder()
+	m.Healthz().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"healthy":true`) {
+		t.Fatalf("healthz: %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	m.Metrics().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	if !strings.Contains(body, `codex_canary_healthy{canary="basic"} 1`) ||
+		!strings.Contains(body, "codex_canary_rounds_total 1") {
+		t.Fatalf("metrics: %s", body)
+	}
+
+	// Break the canary and healthz goes red.
+	stub.results["q"] = &codex.Result{Answer: "nope"}
+	m.RunOnce(context.Background())
+	rec = httptest.NewRecorder()
+	m.Healthz().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("healthz after regression: %d", rec.Code)
+	}
+}
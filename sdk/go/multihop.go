@@ -0,0 +1,110 @@
+package codex
+
+import (
+	"context"
+	"strings"
+)
+
+// WithMultiHop enables iterative retrieval for complex questions: the
+// question is decomposed into at most maxHops sub-queries, each is
+// retrieved and answered on its own, and a final pass synthesizes the
+// answer from the hops. The intermediate queries and answers are
+// returned in the result's trace. Questions the decomposer judges
+// simple fall through to a single ordinary RAG call.
+func WithMultiHop(maxHops int) RAGOption {
+	return func(o *RAGOptions) { o.MultiHop = maxHops }
+}
+
+// Hop is one sub-query of a multi-hop answer, recorded in the trace.
+type Hop struct {
+	Query  string `json:"query"`
+	Answer string `json:"answer,omitempty"`
+}
+
+const decomposeSystem = "You decompose complex questions into independent retrieval queries. " +
+	"If the question needs information from multiple places to answer, reply with one " +
+	"sub-question per line, nothing else. If it can be answered with a single lookup, " +
+	"reply with exactly SINGLE."
+
+const synthesizeSystem = "You synthesize a final answer from the findings below. Answer the " +
+	"original question using only the findings; say so when they are insufficient."
+
+// multiHopAnswer runs the decompose / retrieve-per-hop / synthesize
+// pipeline behind WithMultiHop.
+func (c *Client) multiHopAnswer(ctx context.Context, question string, o RAGOptions, opts []RAGOption) (*Result, error) {
+	var chatOpts []ChatOption
+	if o.Tier != "" {
+		chatOpts = append(chatOpts, WithTier(o.Tier))
+	}
+
+	plan, err := c.Chat(ctx, []Message{
+		{Role: "system", Content: decomposeSystem},
+		{Role: "user", Content: question},
+	}, chatOpts...)
+	if err != nil {
+		return nil, err
+	}
+	queries := splitQueries(plan, o.MultiHop)
+
+	// Per-hop calls reuse the caller's options minus multi-hop, so
+	// collection, filters, and tier all carry through.
+	hopOpts := append(append([]RAGOption{}, opts...), WithMultiHop(0))
+
+	if len(queries) == 0 {
+		return c.RAGAnswer(ctx, question, hopOpts...)
+	}
+
+	final := &Result{Trace: &Trace{}}
+	var findings strings.Builder
+	for _, q := range queries {
+		res, err := c.RAGAnswer(ctx, q, hopOpts...)
+		if err != nil {
+			return nil, err
+		}
+		final.Trace.Hops = append(final.Trace.Hops, Hop{Query: q, Answer: res.Answer})
+		final.References = append(final.References, res.References...)
+		addUsage(&final.Usage, res.Usage)
+		findings.WriteString("Q: " + q + "\nA: " + res.Answer + "\n\n")
+	}
+
+	synth, err := c.ChatCompletion(ctx, []Message{
+		{Role: "system", Content: synthesizeSystem},
+		{Role: "user", Content: "Question: " + question + "\n\nFindings:\n" + findings.String()},
+	}, chatOpts...)
+	if err != nil {
+		return nil, err
+	}
+	final.Answer = synth.Reply
+	addUsage(&final.Usage, synth.Usage)
+	return final, nil
+}
+
+// splitQueries parses the decomposer's reply into at most max
+// sub-queries, returning nil when it declared the question simple.
+func splitQueries(plan string, max int) []string {
+	var out []string
+	for _, line := range strings.Split(plan, "\n") {
+		line = strings.TrimSpace(line)
+		line = strings.TrimLeft(line, "-*0123456789. ")
+		if line == "" {
+			continue
+		}
+		if strings.EqualFold(line, "SINGLE") {
+			return nil
+		}
+		out = append(out, line)
+		if len(out) == max {
+			break
+		}
+	}
+	if len(out) < 2 {
+		return nil
+	}
+	return out
+}
+
+func addUsage(dst *Usage, u Usage) {
+	dst.PromptTokens += u.PromptTokens
+	dst.CompletionTokens += u.CompletionTokens
+	dst.TotalTokens += u.TotalTokens
+}
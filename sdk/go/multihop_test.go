@@ -0,0 +1,97 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func multiHopHandler(t *testing.T) http.Handler {
+	chats := 0
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/chat":
+			var req chatRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			chats++
+			if chats == 1 {
+				json.NewEncoder(w).Encode(ChatResponse{Reply: "1. Who founded X?\n2. When did they retire?"})
+				return
+			}
+			prompt := req.Messages[len(req.Messages)-1].Content
+			if !strings.Contains(prompt, "Q: Who founded X?") || !strings.Contains(prompt, "A: Ada founded X.") {
+				t.Errorf("synthesis prompt missing findings: %s", prompt)
+			}
+			json.NewEncoder(w).Encode(ChatResponse{Reply: "Ada founded X and retired in 1999.", Usage: Usage{TotalTokens: 5}})
+		case "/v1/rag/answer":
+			var req ragRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			answer := "Ada founded X."
+			if strings.Contains(req.Question, "retire") {
+				answer = "They retired in 1999."
+			}
+			json.NewEncoder(w).Encode(Result{
+				Answer:     answer,
+				References: []Reference{{ID: req.Question}},
+				Usage:      Usage{TotalTokens: 10},
+			})
+		default:
+			t.Errorf("path = %s", r.URL.Path)
+		}
+	})
+}
+
+func TestMultiHopDecomposesAndSynthesizes(t *testing.T) {
+	c := newTestClient(t, multiHopHandler(t))
+	res, err := c.RAGAnswer(context.Background(), "Who founded X and when did they retire?", WithMultiHop(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Answer != "Ada founded X and retired in 1999." {
+		t.Fatalf("answer = %q", res.Answer)
+	}
+	if res.Trace == nil || len(res.Trace.Hops) != 2 {
+		t.Fatalf("trace: %+v", res.Trace)
+	}
+	if res.Trace.Hops[0].Query != "Who founded X?" || res.Trace.Hops[1].Answer != "They retired in 1999." {
+		t.Fatalf("hops: %+v", res.Trace.Hops)
+	}
+	if len(res.References) != 2 {
+		t.Fatalf("references: %+v", res.References)
+	}
+	if res.Usage.TotalTokens != 25 {
+		t.Fatalf("usage = %+v", res.Usage)
+	}
+}
+
+func TestMultiHopFallsBackWhenSimple(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/chat":
+			json.NewEncoder(w).Encode(ChatResponse{Reply: "SINGLE"})
+		case "/v1/rag/answer":
+			json.NewEncoder(w).Encode(Result{Answer: "direct"})
+		}
+	}))
+	res, err := c.RAGAnswer(context.Background(), "simple question?", WithMultiHop(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Answer != "direct" {
+		t.Fatalf("answer = %q", res.Answer)
+	}
+}
+
+func TestSplitQueries(t *testing.T) {
+	if q := splitQueries("- a?\n- b?\n- c?", 2); len(q) != 2 || q[1] != "b?" {
+		t.Fatalf("queries: %v", q)
+	}
+	if q := splitQueries("single", 3); q != nil {
+		t.Fatalf("queries: %v", q)
+	}
+	if q := splitQueries("SINGLE", 3); q != nil {
+		t.Fatalf("queries: %v", q)
+	}
+}
@@ -0,0 +1,149 @@
+package codex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// OperationStatus is the lifecycle state of a long-running operation.
+type OperationStatus string
+
+const (
+	// OperationRunning means the server is still working.
+	OperationRunning OperationStatus = "running"
+	// OperationSucceeded means the result is available.
+	OperationSucceeded OperationStatus = "succeeded"
+	// OperationFailed means the operation ended in an error.
+	OperationFailed OperationStatus = "failed"
+)
+
+type operationWire[T any] struct {
+	ID     string          `json:"id"`
+	Status OperationStatus `json:"status"`
+	Error  string          `json:"error,omitempty"`
+	Result *T              `json:"result,omitempty"`
+}
+
+// Operation is a future for a long-running server-side job — an import,
+// a re-index, a bulk delete. Poll checks once; Wait blocks until the
+// operation finishes or the context ends; Done exposes completion as a
+// channel for select loops. All three may be used from any goroutine.
+type Operation[T any] struct {
+	// ID identifies the operation on the server.
+	ID string
+
+	c        *Client
+	interval time.Duration
+
+	mu     sync.Mutex
+	done   chan struct{}
+	result *T
+	err    error
+	ended  bool
+}
+
+// NewOperation wraps a server-side operation ID in a future. interval is
+// the polling cadence used by Wait and Done; zero means one second.
+func NewOperation[T any](c *Client, id string, interval time.Duration) *Operation[T] {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return &Operation[T]{ID: id, c: c, interval: interval}
+}
+
+// Poll checks the operation's state once. finished is false while the
+// server is still working; once it is true, the result and error are
+// final and no further requests are made.
+func (op *Operation[T]) Poll(ctx context.Context) (result *T, finished bool, err error) {
+	op.mu.Lock()
+	if op.ended {
+		defer op.mu.Unlock()
+		return op.result, true, op.err
+	}
+	op.mu.Unlock()
+
+	var resp operationWire[T]
+	path := "/v1/operations/" + url.PathEscape(op.ID)
+	if err := op.c.do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, false, err
+	}
+	switch resp.Status {
+	case OperationSucceeded:
+		op.finish(resp.Result, nil)
+		return resp.Result, true, nil
+	case OperationFailed:
+		ferr := fmt.Errorf("codex: operation %s failed: %s", op.ID, resp.Error)
+		op.finish(nil, ferr)
+		return nil, true, ferr
+	default:
+		return nil, false, nil
+	}
+}
+
+// Wait blocks until the operation finishes, polling at the configured
+// interval, and returns its result. A context cancellation stops the
+// waiting but not the server-side work.
+func (op *Operation[T]) Wait(ctx context.Context) (*T, error) {
+	ticker := time.NewTicker(op.interval)
+	defer ticker.Stop()
+	for {
+		result, finished, err := op.Poll(ctx)
+		if finished || err != nil {
+			return result, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Done returns a channel that closes when the operation finishes. The
+// first call starts a background poller; read the outcome with Result
+// after the channel closes.
+func (op *Operation[T]) Done() <-chan struct{} {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	if op.done == nil {
+		op.done = make(chan struct{})
+		if op.ended {
+			close(op.done)
+		} else {
+			go func() {
+				// Wait records the outcome via finish, which closes
+				// the channel.
+				op.Wait(context.Background()) //nolint:errcheck // recorded in op.err
+			}()
+		}
+	}
+	return op.done
+}
+
+// Result returns the final result and error. It only means something
+// after the operation has finished (Done closed, or Poll/Wait reported
+// finished).
+func (op *Operation[T]) Result() (*T, error) {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	return op.result, op.err
+}
+
+// finish records the terminal state exactly once.
+func (op *Operation[T]) finish(result *T, err error) {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	if op.ended {
+		return
+	}
+	op.ended = true
+	op.result = result
+	op.err = err
+	if op.done != nil {
+		close(op.done)
+	}
+}
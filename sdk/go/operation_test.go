@@ -0,0 +1,71 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type reindexResult struct {
+	Indexed int `json:"indexed"`
+}
+
+func operationHandler(succeedAfter int64) (http.Handler, *atomic.Int64) {
+	var polls atomic.Int64
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if polls.Add(1) < succeedAfter {
+			json.NewEncoder(w).Encode(operationWire[reindexResult]{ID: "op-1", Status: OperationRunning})
+			return
+		}
+		json.NewEncoder(w).Encode(operationWire[reindexResult]{
+			ID: "op-1", Status: OperationSucceeded, Result: &reindexResult{Indexed: 7},
+		})
+	}), &polls
+}
+
+func TestOperationWait(t *testing.T) {
+	h, polls := operationHandler(3)
+	c := newTestClient(t, h)
+	op := NewOperation[reindexResult](c, "op-1", 5*time.Millisecond)
+	res, err := op.Wait(context.Background())
+	if err != nil || res.Indexed != 7 {
+		t.Fatalf("result %+v, err %v", res, err)
+	}
+	if polls.Load() != 3 {
+		t.Fatalf("polled %d times", polls.Load())
+	}
+	// A finished operation answers from memory.
+	if _, finished, _ := op.Poll(context.Background()); !finished || polls.Load() != 3 {
+		t.Fatal("poll after completion hit the server")
+	}
+}
+
+func TestOperationDoneChannel(t *testing.T) {
+	h, _ := operationHandler(2)
+	c := newTestClient(t, h)
+	op := NewOperation[reindexResult](c, "op-1", 5*time.Millisecond)
+	select {
+	case <-op.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("operation never finished")
+	}
+	res, err := op.Result()
+	if err != nil || res.Indexed != 7 {
+		t.Fatalf("result %+v, err %v", res, err)
+	}
+}
+
+func TestOperationFailed(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(operationWire[reindexResult]{
+			ID: "op-2", Status: OperationFailed, Error: "index corrupt",
+		})
+	}))
+	op := NewOperation[reindexResult](c, "op-2", time.Millisecond)
+	if _, err := op.Wait(context.Background()); err == nil {
+		t.Fatal("expected failure")
+	}
+}
@@ -0,0 +1,56 @@
+package codex
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// IndexStatus describes the health of a collection's index.
+type IndexStatus struct {
+	// Fragmentation is the fraction of the index held in unmerged
+	// segments, in [0, 1]; high values slow queries.
+	Fragmentation float64 `json:"fragmentation"`
+	// PendingMerges is how many segment merges are queued.
+	PendingMerges int `json:"pending_merges"`
+	// Segments is the current segment count.
+	Segments int `json:"segments"`
+	// Optimizing is set while a compaction is running.
+	Optimizing bool `json:"optimizing"`
+}
+
+// OptimizeResult is what a finished optimize operation reports.
+type OptimizeResult struct {
+	SegmentsBefore int `json:"segments_before"`
+	SegmentsAfter  int `json:"segments_after"`
+	ReclaimedBytes int64 `json:"reclaimed_bytes"`
+}
+
+type optimizeWire struct {
+	OperationID string `json:"operation_id"`
+}
+
+// Optimize triggers server-side compaction and index rebuild of
+// collection, worth running after heavy delete or re-ingest churn. It
+// returns immediately with an operation handle; Wait on it (or poll)
+// to learn when the rebuild finishes. Queries keep working during the
+// rebuild.
+func (c *Client) Optimize(ctx context.Context, collection string) (*Operation[OptimizeResult], error) {
+	path := "/v1/collections/" + url.PathEscape(c.collection(collection)) + "/optimize"
+	var resp optimizeWire
+	if err := c.do(ctx, http.MethodPost, path, nil, &resp); err != nil {
+		return nil, err
+	}
+	return NewOperation[OptimizeResult](c, resp.OperationID, 0), nil
+}
+
+// IndexStatus reports the collection index's fragmentation and pending
+// merge work, for deciding when Optimize is worth the cost.
+func (c *Client) IndexStatus(ctx context.Context, collection string) (*IndexStatus, error) {
+	path := "/v1/collections/" + url.PathEscape(c.collection(collection)) + "/index"
+	var resp IndexStatus
+	if err := c.do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,59 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestOptimizeReturnsOperationHandle(t *testing.T) {
+	polls := 0
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/collections/kb/optimize":
+			json.NewEncoder(w).Encode(optimizeWire{OperationID: "op-7"})
+		case "/v1/operations/op-7":
+			polls++
+			json.NewEncoder(w).Encode(map[string]any{
+				"id": "op-7", "status": "succeeded",
+				"result": OptimizeResult{SegmentsBefore: 12, SegmentsAfter: 1, ReclaimedBytes: 4096},
+			})
+		default:
+			t.Errorf("path = %s", r.URL.Path)
+		}
+	}))
+	op, err := c.Optimize(context.Background(), "kb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op.ID != "op-7" {
+		t.Fatalf("id = %q", op.ID)
+	}
+	res, err := op.Wait(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.SegmentsAfter != 1 || res.ReclaimedBytes != 4096 {
+		t.Fatalf("result: %+v", res)
+	}
+	if polls == 0 {
+		t.Fatal("operation was never polled")
+	}
+}
+
+func TestIndexStatus(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/collections/kb/index" || r.Method != http.MethodGet {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(IndexStatus{Fragmentation: 0.4, PendingMerges: 3, Segments: 17})
+	}))
+	status, err := c.IndexStatus(context.Background(), "kb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Fragmentation != 0.4 || status.Segments != 17 {
+		t.Fatalf("status: %+v", status)
+	}
+}
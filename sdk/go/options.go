@@ -0,0 +1,163 @@
+package codex
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option configures a Client at construction time.
+type Option func(*clientConfig)
+
+type clientConfig struct {
+	baseURL           string
+	apiKey            string
+	httpClient        *http.Client
+	userAgent         string
+	maxRetries        int
+	retryBaseDelay    time.Duration
+	defaultTier       string
+	defaultCollection string
+	progress          ProgressSink
+	vectorPool        *VectorPool
+	bufferPool        *BufferPool
+	streamRetryBudget *time.Duration
+	keyring           *Keyring
+	encryptedFields   []string
+	schemas           map[string]MetadataSchema
+	timeout           time.Duration
+	profile           string
+	tenant            string
+	router            *TierRouter
+	strictDecoding    bool
+	credentialHelper  []string
+	storedSession     bool
+	onBehalfOf        string
+	priority          Priority
+	admission         *admission
+	latencyBudget     time.Duration
+}
+
+func defaultConfig() clientConfig {
+	return clientConfig{
+		baseURL:        "http://localhost:8080",
+		userAgent:      "codex-go/" + Version,
+		maxRetries:     3,
+		retryBaseDelay: 250 * time.Millisecond,
+	}
+}
+
+// WithBaseURL sets the server base URL (no trailing slash required).
+func WithBaseURL(u string) Option {
+	return func(c *clientConfig) { c.baseURL = u }
+}
+
+// WithAPIKey sets the bearer token sent on every request.
+func WithAPIKey(key string) Option {
+	return func(c *clientConfig) { c.apiKey = key }
+}
+
+// WithHTTPClient substitutes the underlying *http.Client, e.g. to tune
+// transport settings or add instrumentation.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *clientConfig) { c.httpClient = hc }
+}
+
+// WithProfile selects a named profile from the config file when used
+// with FromConfig, so deployment code can stay environment-agnostic:
+//
+//	c, err := codex.FromConfig(path, codex.WithProfile("staging"))
+//
+// It has no effect on New.
+func WithProfile(name string) Option {
+	return func(c *clientConfig) { c.profile = name }
+}
+
+// WithLatencyBudget makes TierAuto chat calls latency-aware: the client
+// tracks a per-tier latency EWMA from its own completed calls and picks
+// the most capable tier still averaging within d, falling back down the
+// tier chain (and, when every tier is over budget, to the fastest one).
+// It takes precedence over complexity routing from WithTierRouter, but
+// borrows that router's tier chain when one is installed.
+func WithLatencyBudget(d time.Duration) Option {
+	return func(c *clientConfig) { c.latencyBudget = d }
+}
+
+// WithAdmissionControl bounds how much concurrent work the client
+// accepts per endpoint: at most maxInFlight requests run at once, at
+// most maxQueued wait behind them for up to queueTimeout each, and
+// anything beyond that fails fast with an error matching
+// ErrClientOverloaded instead of piling up goroutines during a load
+// spike. A maxInFlight of zero or less disables admission control.
+func WithAdmissionControl(maxInFlight, maxQueued int, queueTimeout time.Duration) Option {
+	return func(c *clientConfig) {
+		if maxInFlight <= 0 {
+			c.admission = nil
+			return
+		}
+		if maxQueued < 0 {
+			maxQueued = 0
+		}
+		c.admission = &admission{maxInFlight: maxInFlight, maxQueued: maxQueued, timeout: queueTimeout}
+	}
+}
+
+// WithPriority sets the default QoS class for every request the client
+// makes; see Priority for the classes and their client-side scheduling.
+// Override per call with ContextWithPriority.
+func WithPriority(p Priority) Option {
+	return func(c *clientConfig) { c.priority = p }
+}
+
+// WithOnBehalfOf sets the delegation header sent on every request, for
+// service accounts acting for a single end user: the client still
+// authenticates with its own credentials, but the server attributes
+// usage and applies ACL filtering as the named user. Per-request
+// delegation belongs in the context instead (see ContextWithOnBehalfOf).
+func WithOnBehalfOf(userID string) Option {
+	return func(c *clientConfig) { c.onBehalfOf = userID }
+}
+
+// WithStoredSession makes the client pick up a login stored by
+// `codexctl login` (see DefaultSessionPath) when no API key was
+// configured otherwise. An expired session is renewed through its
+// refresh token; a missing or unusable session file leaves the client
+// unauthenticated rather than failing construction.
+func WithStoredSession() Option {
+	return func(c *clientConfig) { c.storedSession = true }
+}
+
+// WithTenant sets the tenant identifier sent on every request, for
+// servers that partition data and quotas per tenant.
+func WithTenant(id string) Option {
+	return func(c *clientConfig) { c.tenant = id }
+}
+
+// WithTimeout sets the overall per-request timeout of the default HTTP
+// client. It is ignored when WithHTTPClient supplies a custom client.
+func WithTimeout(d time.Duration) Option {
+	return func(c *clientConfig) { c.timeout = d }
+}
+
+// WithMaxRetries sets how many times transient failures (429, 5xx, network
+// errors) are retried before giving up. Zero disables retries.
+func WithMaxRetries(n int) Option {
+	return func(c *clientConfig) { c.maxRetries = n }
+}
+
+// WithDefaultTier sets the tier used by chat and embedding calls that do
+// not specify one explicitly.
+func WithDefaultTier(tier string) Option {
+	return func(c *clientConfig) { c.defaultTier = tier }
+}
+
+// WithProgressSink registers a sink that receives Progress updates from
+// bulk operations (EmbedBatch, UpsertBatch).
+func WithProgressSink(sink ProgressSink) Option {
+	return func(c *clientConfig) { c.progress = sink }
+}
+
+// WithDefaultCollection sets the collection used by vector and RAG calls
+// that do not specify one explicitly.
+func WithDefaultCollection(name string) Option {
+	return func(c *clientConfig) { c.defaultCollection = name }
+}
@@ -0,0 +1,122 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// PageOptions controls one page of a paged list call. The zero value
+// asks for the first page at the server's default size.
+type PageOptions struct {
+	// Cursor continues from a previous page's NextCursor.
+	Cursor string
+	// Limit caps the page size; zero leaves it to the server.
+	Limit int
+}
+
+// Page is one page of a paged list call. An empty NextCursor means the
+// listing is complete.
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+}
+
+func (o PageOptions) query() string {
+	q := url.Values{}
+	if o.Cursor != "" {
+		q.Set("cursor", o.Cursor)
+	}
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if len(q) == 0 {
+		return ""
+	}
+	return "?" + q.Encode()
+}
+
+// listPage fetches one page from a list endpoint whose items live under
+// the given JSON key.
+func listPage[T any](ctx context.Context, c *Client, path, key string, opts PageOptions) (*Page[T], error) {
+	var wire map[string]json.RawMessage
+	if err := c.do(ctx, http.MethodGet, path+opts.query(), nil, &wire); err != nil {
+		return nil, err
+	}
+	page := &Page[T]{}
+	if data, ok := wire[key]; ok {
+		if err := json.Unmarshal(data, &page.Items); err != nil {
+			return nil, err
+		}
+	}
+	if data, ok := wire["next_cursor"]; ok {
+		if err := json.Unmarshal(data, &page.NextCursor); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// ListCollectionsPage returns one page of collections; pass the returned
+// cursor in the next call's PageOptions to continue.
+func (c *Client) ListCollectionsPage(ctx context.Context, opts PageOptions) (*Page[Collection], error) {
+	return listPage[Collection](ctx, c, "/v1/collections", "collections", opts)
+}
+
+// ListModelsPage returns one page of models.
+func (c *Client) ListModelsPage(ctx context.Context, opts PageOptions) (*Page[Model], error) {
+	return listPage[Model](ctx, c, "/v1/models", "models", opts)
+}
+
+// ListTiersPage returns one page of tiers.
+func (c *Client) ListTiersPage(ctx context.Context, opts PageOptions) (*Page[Tier], error) {
+	return listPage[Tier](ctx, c, "/v1/tiers", "tiers", opts)
+}
+
+// AllCollections iterates every collection, driving the page cursor
+// internally; see Seq2 for how to range over it. A pageSize of zero
+// uses the default.
+func (c *Client) AllCollections(ctx context.Context, pageSize int) Seq2[Collection, error] {
+	return allPages(ctx, pageSize, c.ListCollectionsPage)
+}
+
+// AllModels iterates every model the server offers.
+func (c *Client) AllModels(ctx context.Context, pageSize int) Seq2[Model, error] {
+	return allPages(ctx, pageSize, c.ListModelsPage)
+}
+
+// AllTiers iterates every tier the server offers.
+func (c *Client) AllTiers(ctx context.Context, pageSize int) Seq2[Tier, error] {
+	return allPages(ctx, pageSize, c.ListTiersPage)
+}
+
+// allPages turns a paged fetch into an iterator that hides cursor
+// management. On a fetch error it yields the zero item with the error
+// and stops.
+func allPages[T any](ctx context.Context, pageSize int, fetch func(context.Context, PageOptions) (*Page[T], error)) Seq2[T, error] {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	return func(yield func(T, error) bool) {
+		cursor := ""
+		for {
+			page, err := fetch(ctx, PageOptions{Cursor: cursor, Limit: pageSize})
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if page.NextCursor == "" {
+				return
+			}
+			cursor = page.NextCursor
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func pagedModelsHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		resp := map[string]any{}
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			resp["models"] = []Model{{ID: "m1"}, {ID: "m2"}}
+			resp["next_cursor"] = "p2"
+		case "p2":
+			resp["models"] = []Model{{ID: "m3"}}
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+}
+
+func TestListModelsPage(t *testing.T) {
+	c := newTestClient(t, pagedModelsHandler(t))
+	page, err := c.ListModelsPage(context.Background(), PageOptions{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Items) != 2 || page.NextCursor != "p2" {
+		t.Fatalf("page: %+v", page)
+	}
+	page, err = c.ListModelsPage(context.Background(), PageOptions{Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Items) != 1 || page.NextCursor != "" {
+		t.Fatalf("page: %+v", page)
+	}
+}
+
+func TestAllModelsWalksEveryPage(t *testing.T) {
+	c := newTestClient(t, pagedModelsHandler(t))
+	var ids []string
+	c.AllModels(context.Background(), 2)(func(m Model, err error) bool {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, m.ID)
+		return true
+	})
+	if len(ids) != 3 || ids[0] != "m1" || ids[2] != "m3" {
+		t.Fatalf("ids: %v", ids)
+	}
+}
+
+func TestAllCollectionsEarlyBreak(t *testing.T) {
+	pages := 0
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		json.NewEncoder(w).Encode(map[string]any{
+			"collections": []Collection{{Name: "a"}, {Name: "b"}},
+			"next_cursor": "more",
+		})
+	}))
+	seen := 0
+	c.AllCollections(context.Background(), 2)(func(col Collection, err error) bool {
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen++
+		return false
+	})
+	if seen != 1 || pages != 1 {
+		t.Fatalf("seen=%d pages=%d", seen, pages)
+	}
+}
@@ -0,0 +1,182 @@
+package codex
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// WithParentExpansion asks the server to widen each retrieved chunk to
+// a window of n neighbouring chunks on either side before answering, so
+// small chunks can be indexed for recall while answers see their
+// surrounding document. It relies on the doc_id and ordinal metadata
+// the ingestion pipeline writes on every chunk.
+func WithParentExpansion(n int) RAGOption {
+	return func(o *RAGOptions) { o.ParentExpansion = n }
+}
+
+// ExpandParents widens query matches client-side: each match whose
+// chunk carries doc_id and ordinal metadata is replaced by a window of
+// n neighbouring chunks on either side, fetched by their well-known IDs
+// (doc_id#ordinal). Overlapping windows from the same document merge
+// into one match, keeping the best score. Matches without chunk
+// metadata pass through unchanged.
+func (c *Client) ExpandParents(ctx context.Context, collection string, matches []Match, n int) ([]Match, error) {
+	if n <= 0 {
+		return matches, nil
+	}
+
+	// Collect the windows and the sibling IDs they need.
+	type window struct {
+		doc      string
+		from, to int
+		score    float32
+		order    int
+	}
+	var windows []*window
+	byDoc := map[string][]*window{}
+	var out []Match
+	for i, m := range matches {
+		key := chunkKey(m)
+		if key.ordinal < 0 {
+			out = append(out, m)
+			continue
+		}
+		from := key.ordinal - n
+		if from < 0 {
+			from = 0
+		}
+		w := &window{doc: key.doc, from: from, to: key.ordinal + n, score: m.Score, order: i}
+		windows = append(windows, w)
+		byDoc[w.doc] = append(byDoc[w.doc], w)
+	}
+
+	// Merge overlapping windows within each document.
+	var merged []*window
+	for _, ws := range byDoc {
+		sort.Slice(ws, func(i, j int) bool { return ws[i].from < ws[j].from })
+		cur := ws[0]
+		for _, w := range ws[1:] {
+			if w.from <= cur.to+1 {
+				if w.to > cur.to {
+					cur.to = w.to
+				}
+				if w.score > cur.score {
+					cur.score = w.score
+				}
+				if w.order < cur.order {
+					cur.order = w.order
+				}
+				continue
+			}
+			merged = append(merged, cur)
+			cur = w
+		}
+		merged = append(merged, cur)
+	}
+
+	var ids []string
+	for _, w := range merged {
+		for ord := w.from; ord <= w.to; ord++ {
+			ids = append(ids, fmt.Sprintf("%s#%d", w.doc, ord))
+		}
+	}
+	recs, err := c.Fetch(ctx, collection, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]VectorRecord, len(recs))
+	for _, rec := range recs {
+		byID[rec.ID] = rec
+	}
+
+	for _, w := range merged {
+		var parts []string
+		var first *VectorRecord
+		for ord := w.from; ord <= w.to; ord++ {
+			rec, ok := byID[fmt.Sprintf("%s#%d", w.doc, ord)]
+			if !ok {
+				continue
+			}
+			if first == nil {
+				r := rec
+				first = &r
+			}
+			parts = append(parts, rec.Document)
+		}
+		if first == nil {
+			continue
+		}
+		expanded := *first
+		expanded.Document = strings.Join(parts, "\n")
+		out = append(out, Match{VectorRecord: expanded, Score: w.score})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	return out, nil
+}
+
+// QueryWindow is Query followed by sentence-window expansion: each hit's
+// document text is widened with window neighbouring chunks on either
+// side (located through the doc_id and ordinal metadata), so answers are
+// not assembled from context-free fragments. Unlike ExpandParents, hits
+// stay separate matches — overlapping windows are not merged — so scores
+// and ranking survive for downstream assembly. Hits without chunk
+// metadata pass through unchanged.
+func (c *Client) QueryWindow(ctx context.Context, collection string, vector []float32, topK int, filter Filter, window int) ([]Match, error) {
+	matches, err := c.Query(ctx, collection, vector, topK, filter)
+	if err != nil || window <= 0 {
+		return matches, err
+	}
+
+	seen := map[string]bool{}
+	var ids []string
+	for _, m := range matches {
+		key := chunkKey(m)
+		if key.ordinal < 0 {
+			continue
+		}
+		for ord := key.ordinal - window; ord <= key.ordinal+window; ord++ {
+			if ord < 0 || ord == key.ordinal {
+				continue
+			}
+			id := fmt.Sprintf("%s#%d", key.doc, ord)
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return matches, nil
+	}
+	recs, err := c.Fetch(ctx, collection, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]VectorRecord, len(recs))
+	for _, rec := range recs {
+		byID[rec.ID] = rec
+	}
+
+	out := make([]Match, len(matches))
+	for i, m := range matches {
+		out[i] = m
+		key := chunkKey(m)
+		if key.ordinal < 0 {
+			continue
+		}
+		var parts []string
+		for ord := key.ordinal - window; ord <= key.ordinal+window; ord++ {
+			if ord == key.ordinal {
+				parts = append(parts, m.Document)
+				continue
+			}
+			if rec, ok := byID[fmt.Sprintf("%s#%d", key.doc, ord)]; ok {
+				parts = append(parts, rec.Document)
+			}
+		}
+		out[i].Document = strings.Join(parts, "\n")
+	}
+	return out, nil
+}
@@ -0,0 +1,118 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func parentHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/collections/kb/fetch" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		var req fetchRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		var recs []VectorRecord
+		for _, id := range req.IDs {
+			var doc string
+			var ord int
+			if _, err := fmt.Sscanf(id, "doc#%d", &ord); err == nil {
+				doc = "doc"
+			} else {
+				continue
+			}
+			if ord > 4 { // document has 5 chunks
+				continue
+			}
+			recs = append(recs, VectorRecord{
+				ID:       id,
+				Document: fmt.Sprintf("chunk %d", ord),
+				Metadata: map[string]any{"doc_id": doc, "ordinal": ord},
+			})
+		}
+		json.NewEncoder(w).Encode(fetchWire{Records: recs})
+	})
+}
+
+func TestExpandParentsMergesOverlappingWindows(t *testing.T) {
+	c := newTestClient(t, parentHandler(t))
+	matches := []Match{
+		chunkMatch("doc", 1, "chunk 1", 0.9),
+		chunkMatch("doc", 3, "chunk 3", 0.7),
+	}
+	out, err := c.ExpandParents(context.Background(), "kb", matches, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 1 {
+		t.Fatalf("matches: %+v", out)
+	}
+	want := "chunk 0\nchunk 1\nchunk 2\nchunk 3\nchunk 4"
+	if out[0].Document != want {
+		t.Fatalf("document = %q", out[0].Document)
+	}
+	if out[0].Score != 0.9 {
+		t.Fatalf("score = %v", out[0].Score)
+	}
+}
+
+func TestExpandParentsPassesThroughPlainMatches(t *testing.T) {
+	c := newTestClient(t, parentHandler(t))
+	plain := Match{VectorRecord: VectorRecord{ID: "standalone", Document: "no chunk metadata"}, Score: 0.5}
+	out, err := c.ExpandParents(context.Background(), "kb", []Match{plain}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 1 || out[0].ID != "standalone" {
+		t.Fatalf("matches: %+v", out)
+	}
+}
+
+func TestQueryWindowExpandsEachHit(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/collections/kb/query":
+			json.NewEncoder(w).Encode(queryWire{Matches: []Match{
+				chunkMatch("doc", 1, "chunk 1", 0.9),
+				chunkMatch("doc", 3, "chunk 3", 0.7),
+			}})
+		case "/v1/collections/kb/fetch":
+			parentHandler(t).ServeHTTP(w, r)
+		default:
+			t.Errorf("path = %s", r.URL.Path)
+		}
+	}))
+	out, err := c.QueryWindow(context.Background(), "kb", []float32{1}, 2, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 2 {
+		t.Fatalf("matches: %+v", out)
+	}
+	if out[0].Document != "chunk 0\nchunk 1\nchunk 2" {
+		t.Fatalf("first window = %q", out[0].Document)
+	}
+	if out[1].Document != "chunk 2\nchunk 3\nchunk 4" {
+		t.Fatalf("second window = %q", out[1].Document)
+	}
+	if out[0].Score != 0.9 || out[1].Score != 0.7 {
+		t.Fatalf("scores: %v %v", out[0].Score, out[1].Score)
+	}
+}
+
+func TestWithParentExpansionOnWire(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ragRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.ParentExpansion != 2 {
+			t.Errorf("parent_expansion = %d", req.ParentExpansion)
+		}
+		json.NewEncoder(w).Encode(Result{Answer: "ok"})
+	}))
+	if _, err := c.RAGAnswer(context.Background(), "q", WithParentExpansion(2)); err != nil {
+		t.Fatal(err)
+	}
+}
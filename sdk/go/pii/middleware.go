@@ -0,0 +1,99 @@
+package pii
+
+import (
+	"context"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// Middleware wraps a client, scrubbing PII from outgoing chat messages,
+// embedding inputs, and upserted documents before they leave the
+// process. It satisfies codex.Codex and can be dropped in wherever the
+// raw client is used — including as the Ingestor's embedder/upserter, so
+// ingested documents are scrubbed too.
+type Middleware struct {
+	codex.Codex
+	Scrubber *Scrubber
+	// RestoreReplies substitutes original values back into chat and RAG
+	// replies (Tokenize mode only) for trusted destinations.
+	RestoreReplies bool
+}
+
+var _ codex.Codex = (*Middleware)(nil)
+
+func (m *Middleware) scrubMessages(messages []codex.Message) []codex.Message {
+	out := make([]codex.Message, len(messages))
+	for i, msg := range messages {
+		msg.Content = m.Scrubber.Scrub(msg.Content)
+		out[i] = msg
+	}
+	return out
+}
+
+func (m *Middleware) restore(text string) string {
+	if m.RestoreReplies {
+		return m.Scrubber.Restore(text)
+	}
+	return text
+}
+
+func (m *Middleware) Chat(ctx context.Context, messages []codex.Message, opts ...codex.ChatOption) (string, error) {
+	reply, err := m.Codex.Chat(ctx, m.scrubMessages(messages), opts...)
+	return m.restore(reply), err
+}
+
+func (m *Middleware) ChatCompletion(ctx context.Context, messages []codex.Message, opts ...codex.ChatOption) (*codex.ChatResponse, error) {
+	resp, err := m.Codex.ChatCompletion(ctx, m.scrubMessages(messages), opts...)
+	if resp != nil {
+		resp.Reply = m.restore(resp.Reply)
+	}
+	return resp, err
+}
+
+func (m *Middleware) Embed(ctx context.Context, text string) ([]float32, error) {
+	return m.Codex.Embed(ctx, m.Scrubber.Scrub(text))
+}
+
+func (m *Middleware) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	scrubbed := make([]string, len(texts))
+	for i, t := range texts {
+		scrubbed[i] = m.Scrubber.Scrub(t)
+	}
+	return m.Codex.EmbedBatch(ctx, scrubbed)
+}
+
+func (m *Middleware) scrubRecord(rec codex.VectorRecord) codex.VectorRecord {
+	rec.Document = m.Scrubber.Scrub(rec.Document)
+	if rec.Metadata != nil {
+		meta := make(map[string]any, len(rec.Metadata))
+		for k, v := range rec.Metadata {
+			if s, ok := v.(string); ok {
+				meta[k] = m.Scrubber.Scrub(s)
+			} else {
+				meta[k] = v
+			}
+		}
+		rec.Metadata = meta
+	}
+	return rec
+}
+
+func (m *Middleware) Upsert(ctx context.Context, collection string, rec codex.VectorRecord) error {
+	return m.Codex.Upsert(ctx, collection, m.scrubRecord(rec))
+}
+
+func (m *Middleware) UpsertBatch(ctx context.Context, collection string, recs []codex.VectorRecord) error {
+	scrubbed := make([]codex.VectorRecord, len(recs))
+	for i, rec := range recs {
+		scrubbed[i] = m.scrubRecord(rec)
+	}
+	return m.Codex.UpsertBatch(ctx, collection, scrubbed)
+}
+
+func (m *Middleware) RAGAnswer(ctx context.Context, question string, opts ...codex.RAGOption) (*codex.Result, error) {
+	result, err := m.Codex.RAGAnswer(ctx, m.Scrubber.Scrub(question), opts...)
+	if result != nil {
+		result.Answer = m.restore(result.Answer)
+	}
+	return result, err
+}
@@ -0,0 +1,103 @@
+// Package pii detects and scrubs personally identifiable information
+// from text before it leaves the process, with an optional reversible
+// token mapping so trusted outputs can be restored.
+package pii
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// Pattern is one named PII matcher.
+type Pattern struct {
+	Name   string
+	Regexp *regexp.Regexp
+}
+
+// DefaultPatterns covers the common identifier formats. Custom patterns
+// (employee IDs, account numbers, NER output converted to regexes) are
+// appended by the caller.
+func DefaultPatterns() []Pattern {
+	return []Pattern{
+		{Name: "email", Regexp: regexp.MustCompile(`\b[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}\b`)},
+		{Name: "phone", Regexp: regexp.MustCompile(`\b(?:\+?1[-. ]?)?\(?\d{3}\)?[-. ]\d{3}[-. ]\d{4}\b`)},
+		{Name: "ssn", Regexp: regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`)},
+		{Name: "credit_card", Regexp: regexp.MustCompile(`\b(?:\d[ -]?){13,16}\b`)},
+	}
+}
+
+// Mode selects what scrubbing writes in place of a match.
+type Mode int
+
+const (
+	// Redact replaces matches with "[name]", irreversibly.
+	Redact Mode = iota
+	// Tokenize replaces matches with stable placeholder tokens and
+	// keeps the reverse mapping for Restore.
+	Tokenize
+)
+
+// Scrubber replaces PII in text. It is safe for concurrent use; in
+// Tokenize mode the same value always maps to the same token within one
+// Scrubber, so references stay consistent across a conversation.
+type Scrubber struct {
+	Patterns []Pattern
+	Mode     Mode
+
+	mu      sync.Mutex
+	tokens  map[string]string // token -> original
+	byValue map[string]string // original -> token
+	counter int
+}
+
+// New returns a Scrubber with the default patterns.
+func New(mode Mode) *Scrubber {
+	return &Scrubber{Patterns: DefaultPatterns(), Mode: mode}
+}
+
+// Scrub replaces every PII match in text according to the mode.
+func (s *Scrubber) Scrub(text string) string {
+	for _, p := range s.Patterns {
+		text = p.Regexp.ReplaceAllStringFunc(text, func(match string) string {
+			if s.Mode == Redact {
+				return "[" + p.Name + "]"
+			}
+			return s.token(p.Name, match)
+		})
+	}
+	return text
+}
+
+func (s *Scrubber) token(kind, value string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.byValue == nil {
+		s.byValue = map[string]string{}
+		s.tokens = map[string]string{}
+	}
+	if tok, ok := s.byValue[value]; ok {
+		return tok
+	}
+	s.counter++
+	tok := fmt.Sprintf("<pii:%s:%d>", kind, s.counter)
+	s.byValue[value] = tok
+	s.tokens[tok] = value
+	return tok
+}
+
+var tokenPattern = regexp.MustCompile(`<pii:[a-z_]+:\d+>`)
+
+// Restore substitutes original values back into text containing tokens,
+// for outputs going to trusted destinations. Unknown tokens are left as
+// they are.
+func (s *Scrubber) Restore(text string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return tokenPattern.ReplaceAllStringFunc(text, func(tok string) string {
+		if original, ok := s.tokens[tok]; ok {
+			return original
+		}
+		return tok
+	})
+}
@@ -0,0 +1,35 @@
+package pii
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScrubRedacts(t *testing.T) {
+	s := New(Redact)
+	in := "Email bob@example.com or call 555-123-4567, SSN 123-45-6789."
+	out := s.Scrub(in)
+	for _, leaked := range []string{"bob@example.com", "555-123-4567", "123-45-6789"} {
+		if strings.Contains(out, leaked) {
+			t.Fatalf("%q leaked into %q", leaked, out)
+		}
+	}
+	if !strings.Contains(out, "[email]") || !strings.Contains(out, "[phone]") {
+		t.Fatalf("missing redaction markers: %q", out)
+	}
+}
+
+func TestTokenizeIsReversibleAndStable(t *testing.T) {
+	s := New(Tokenize)
+	out := s.Scrub("bob@example.com wrote to bob@example.com")
+	if strings.Contains(out, "bob@example.com") {
+		t.Fatalf("email leaked: %q", out)
+	}
+	parts := strings.Fields(out)
+	if parts[0] != parts[3] {
+		t.Fatalf("same value mapped to different tokens: %q", out)
+	}
+	if got := s.Restore(out); got != "bob@example.com wrote to bob@example.com" {
+		t.Fatalf("restore failed: %q", got)
+	}
+}
@@ -0,0 +1,230 @@
+package codex
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// endpoint classes used for pool concurrency limits.
+const (
+	endpointChat   = "chat"
+	endpointEmbed  = "embed"
+	endpointVector = "vector"
+	endpointRAG    = "rag"
+	endpointAdmin  = "admin"
+)
+
+// PoolOptions configures a Pool.
+type PoolOptions struct {
+	// Size is the number of underlying clients (each with its own
+	// transport). Defaults to 4.
+	Size int
+	// MaxPerEndpoint caps concurrent in-flight calls per endpoint class
+	// (chat, embed, vector, rag, admin) across the whole pool. Zero
+	// means unlimited.
+	MaxPerEndpoint int
+}
+
+// Pool fans calls out over several clients, bounding per-endpoint
+// concurrency. A single *http.Client's transport saturates under heavy
+// mixed load; a pool spreads connections and keeps interactive endpoints
+// responsive while bulk work queues fairly (FIFO) for its slot.
+type Pool struct {
+	clients []*Client
+	next    atomic.Uint64
+	limits  map[string]chan struct{}
+}
+
+var _ Codex = (*Pool)(nil)
+
+// NewPool builds a pool of opts.Size clients, each constructed with
+// clientOpts.
+func NewPool(opts PoolOptions, clientOpts ...Option) (*Pool, error) {
+	size := opts.Size
+	if size <= 0 {
+		size = 4
+	}
+	p := &Pool{clients: make([]*Client, size)}
+	for i := range p.clients {
+		c, err := New(clientOpts...)
+		if err != nil {
+			return nil, err
+		}
+		p.clients[i] = c
+	}
+	if opts.MaxPerEndpoint > 0 {
+		p.limits = make(map[string]chan struct{})
+		for _, ep := range []string{endpointChat, endpointEmbed, endpointVector, endpointRAG, endpointAdmin} {
+			p.limits[ep] = make(chan struct{}, opts.MaxPerEndpoint)
+		}
+	}
+	return p, nil
+}
+
+// pick returns the next client round-robin.
+func (p *Pool) pick() *Client {
+	if len(p.clients) == 0 {
+		panic("codex: use NewPool to construct a Pool")
+	}
+	n := p.next.Add(1)
+	return p.clients[int(n)%len(p.clients)]
+}
+
+// acquire takes an in-flight slot for the endpoint class, waiting in FIFO
+// order, or returns early when ctx is done.
+func (p *Pool) acquire(ctx context.Context, endpoint string) (release func(), err error) {
+	sem, ok := p.limits[endpoint]
+	if !ok {
+		return func() {}, nil
+	}
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (p *Pool) Chat(ctx context.Context, messages []Message, opts ...ChatOption) (string, error) {
+	release, err := p.acquire(ctx, endpointChat)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+	return p.pick().Chat(ctx, messages, opts...)
+}
+
+func (p *Pool) ChatCompletion(ctx context.Context, messages []Message, opts ...ChatOption) (*ChatResponse, error) {
+	release, err := p.acquire(ctx, endpointChat)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return p.pick().ChatCompletion(ctx, messages, opts...)
+}
+
+func (p *Pool) Embed(ctx context.Context, text string) ([]float32, error) {
+	release, err := p.acquire(ctx, endpointEmbed)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return p.pick().Embed(ctx, text)
+}
+
+func (p *Pool) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	release, err := p.acquire(ctx, endpointEmbed)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return p.pick().EmbedBatch(ctx, texts)
+}
+
+func (p *Pool) Upsert(ctx context.Context, collection string, rec VectorRecord) error {
+	release, err := p.acquire(ctx, endpointVector)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return p.pick().Upsert(ctx, collection, rec)
+}
+
+func (p *Pool) UpsertBatch(ctx context.Context, collection string, recs []VectorRecord) error {
+	release, err := p.acquire(ctx, endpointVector)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return p.pick().UpsertBatch(ctx, collection, recs)
+}
+
+func (p *Pool) Query(ctx context.Context, collection string, vector []float32, topK int, filter Filter) ([]Match, error) {
+	release, err := p.acquire(ctx, endpointVector)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return p.pick().Query(ctx, collection, vector, topK, filter)
+}
+
+func (p *Pool) Fetch(ctx context.Context, collection string, ids []string) ([]VectorRecord, error) {
+	release, err := p.acquire(ctx, endpointVector)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return p.pick().Fetch(ctx, collection, ids)
+}
+
+func (p *Pool) Delete(ctx context.Context, collection string, ids []string) error {
+	release, err := p.acquire(ctx, endpointVector)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return p.pick().Delete(ctx, collection, ids)
+}
+
+func (p *Pool) Scroll(ctx context.Context, collection, cursor string, limit int) ([]VectorRecord, string, error) {
+	release, err := p.acquire(ctx, endpointVector)
+	if err != nil {
+		return nil, "", err
+	}
+	defer release()
+	return p.pick().Scroll(ctx, collection, cursor, limit)
+}
+
+func (p *Pool) RAGAnswer(ctx context.Context, question string, opts ...RAGOption) (*Result, error) {
+	release, err := p.acquire(ctx, endpointRAG)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return p.pick().RAGAnswer(ctx, question, opts...)
+}
+
+func (p *Pool) ListCollections(ctx context.Context) ([]Collection, error) {
+	release, err := p.acquire(ctx, endpointAdmin)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return p.pick().ListCollections(ctx)
+}
+
+func (p *Pool) CreateCollection(ctx context.Context, name string, dimensions int) error {
+	release, err := p.acquire(ctx, endpointAdmin)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return p.pick().CreateCollection(ctx, name, dimensions)
+}
+
+func (p *Pool) DeleteCollection(ctx context.Context, name string) error {
+	release, err := p.acquire(ctx, endpointAdmin)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return p.pick().DeleteCollection(ctx, name)
+}
+
+func (p *Pool) ListTiers(ctx context.Context) ([]Tier, error) {
+	release, err := p.acquire(ctx, endpointAdmin)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return p.pick().ListTiers(ctx)
+}
+
+func (p *Pool) ListModels(ctx context.Context) ([]Model, error) {
+	release, err := p.acquire(ctx, endpointAdmin)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return p.pick().ListModels(ctx)
+}
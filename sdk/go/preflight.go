@@ -0,0 +1,125 @@
+package codex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PreflightCheck is one verified aspect of the client's configuration.
+type PreflightCheck struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// PreflightReport is the outcome of Preflight: one entry per check, in
+// the order they ran. Skipped checks (for unset configuration) are
+// omitted.
+type PreflightReport struct {
+	Checks []PreflightCheck `json:"checks"`
+}
+
+// OK reports whether every check passed.
+func (r *PreflightReport) OK() bool {
+	for _, c := range r.Checks {
+		if !c.OK {
+			return false
+		}
+	}
+	return true
+}
+
+// Err returns nil when everything passed, or one error naming every
+// failed check, suitable for aborting service startup.
+func (r *PreflightReport) Err() error {
+	var failed []string
+	for _, c := range r.Checks {
+		if !c.OK {
+			failed = append(failed, fmt.Sprintf("%s: %s", c.Name, c.Detail))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return errors.New("codex: preflight failed: " + strings.Join(failed, "; "))
+}
+
+// Preflight verifies the client can actually work before the service
+// takes traffic: connectivity and auth, that the configured tier exists,
+// that the configured collection exists, and that the embedding model's
+// dimensionality matches the collection's. Call it from service init and
+// abort on report.Err() so misconfiguration fails fast with a clear
+// message. The returned error is only non-nil when the report itself
+// could not be assembled.
+func (c *Client) Preflight(ctx context.Context) (*PreflightReport, error) {
+	report := &PreflightReport{}
+	add := func(name string, ok bool, detail string) {
+		report.Checks = append(report.Checks, PreflightCheck{Name: name, OK: ok, Detail: detail})
+	}
+
+	tiers, err := c.ListTiers(ctx)
+	if err != nil {
+		var ae *APIError
+		switch {
+		case errors.As(err, &ae) && (ae.StatusCode == 401 || ae.StatusCode == 403):
+			add("auth", false, "server rejected the API key: "+ae.Message)
+		default:
+			add("connectivity", false, err.Error())
+		}
+		// Nothing else can be checked without a reachable server.
+		return report, nil
+	}
+	add("connectivity", true, "")
+	add("auth", true, "")
+
+	if tier := c.cfg.defaultTier; tier != "" && tier != TierAuto {
+		found := false
+		names := make([]string, 0, len(tiers))
+		for _, t := range tiers {
+			names = append(names, t.Name)
+			found = found || t.Name == tier
+		}
+		if found {
+			add("tier", true, "")
+		} else {
+			add("tier", false, fmt.Sprintf("tier %q not offered (server has: %s)", tier, strings.Join(names, ", ")))
+		}
+	}
+
+	collection := c.cfg.defaultCollection
+	if collection == "" {
+		return report, nil
+	}
+	cols, err := c.ListCollections(ctx)
+	if err != nil {
+		add("collection", false, "list collections: "+err.Error())
+		return report, nil
+	}
+	var col *Collection
+	for i := range cols {
+		if cols[i].Name == collection {
+			col = &cols[i]
+			break
+		}
+	}
+	if col == nil {
+		add("collection", false, fmt.Sprintf("collection %q does not exist", collection))
+		return report, nil
+	}
+	add("collection", true, "")
+
+	vec, err := c.Embed(ctx, "preflight probe")
+	if err != nil {
+		add("embedding", false, err.Error())
+		return report, nil
+	}
+	if len(vec) != col.Dimensions {
+		add("embedding", false, fmt.Sprintf("embedding model produces %d dimensions, collection %q holds %d",
+			len(vec), collection, col.Dimensions))
+		return report, nil
+	}
+	add("embedding", true, "")
+	return report, nil
+}
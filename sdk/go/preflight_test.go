@@ -0,0 +1,71 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func preflightHandler(dims int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/tiers":
+			json.NewEncoder(w).Encode(tiersWire{Tiers: []Tier{{Name: "fast"}, {Name: "smart"}}})
+		case "/v1/collections":
+			json.NewEncoder(w).Encode(collectionsWire{Collections: []Collection{{Name: "kb", Dimensions: 3}}})
+		case "/v1/embed":
+			vec := make([]float32, dims)
+			json.NewEncoder(w).Encode(embedWire{Vectors: [][]float32{vec}})
+		}
+	})
+}
+
+func TestPreflightAllGreen(t *testing.T) {
+	c := newTestClient(t, preflightHandler(3),
+		WithDefaultTier("fast"), WithDefaultCollection("kb"))
+	report, err := c.Preflight(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.OK() || report.Err() != nil {
+		t.Fatalf("report: %+v", report)
+	}
+	if len(report.Checks) != 5 {
+		t.Fatalf("checks: %+v", report.Checks)
+	}
+}
+
+func TestPreflightCatchesMisconfiguration(t *testing.T) {
+	c := newTestClient(t, preflightHandler(768),
+		WithDefaultTier("gigantic"), WithDefaultCollection("kb"))
+	report, err := c.Preflight(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.OK() {
+		t.Fatal("expected failures")
+	}
+	msg := report.Err().Error()
+	if !strings.Contains(msg, `tier "gigantic" not offered`) {
+		t.Fatalf("tier failure missing: %s", msg)
+	}
+	if !strings.Contains(msg, "768 dimensions") {
+		t.Fatalf("dimension failure missing: %s", msg)
+	}
+}
+
+func TestPreflightBadAuthStopsEarly(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(APIError{Message: "bad key"})
+	}), WithDefaultCollection("kb"))
+	report, err := c.Preflight(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Checks) != 1 || report.Checks[0].Name != "auth" || report.Checks[0].OK {
+		t.Fatalf("checks: %+v", report.Checks)
+	}
+}
@@ -0,0 +1,124 @@
+package codex
+
+import (
+	"context"
+	"sync"
+)
+
+// Priority is a request QoS class, sent to the server in the
+// X-Codex-Priority header and honored client-side: while an interactive
+// request is in flight on a client, queued batch and background requests
+// on the same client wait their turn.
+type Priority string
+
+const (
+	// PriorityInteractive is for latency-sensitive calls with a user
+	// waiting on the other end, e.g. chat. Unmarked requests behave as
+	// interactive so existing callers are never held back.
+	PriorityInteractive Priority = "interactive"
+	// PriorityBatch is for bulk work that tolerates queueing, e.g.
+	// embedding a corpus.
+	PriorityBatch Priority = "batch"
+	// PriorityBackground is for maintenance work that should only run
+	// when nothing else wants the client.
+	PriorityBackground Priority = "background"
+)
+
+const (
+	rankBackground = iota
+	rankBatch
+	rankInteractive
+	rankCount
+)
+
+// rank orders priorities for the client-side gate. Unknown values rank
+// as interactive rather than silently deprioritizing a typo.
+func (p Priority) rank() int {
+	switch p {
+	case PriorityBatch:
+		return rankBatch
+	case PriorityBackground:
+		return rankBackground
+	default:
+		return rankInteractive
+	}
+}
+
+type priorityKey struct{}
+
+// ContextWithPriority attaches a QoS class to ctx, overriding the
+// client's configured WithPriority for calls made with the returned
+// context — so one client can serve interactive chat and background
+// embedding side by side.
+func ContextWithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFromContext returns the QoS class attached with
+// ContextWithPriority, if any.
+func PriorityFromContext(ctx context.Context) (Priority, bool) {
+	p, ok := ctx.Value(priorityKey{}).(Priority)
+	return p, ok
+}
+
+// requestPriority resolves the effective QoS class for a request:
+// context first, then the client default, else unset.
+func (c *Client) requestPriority(ctx context.Context) Priority {
+	if p, ok := PriorityFromContext(ctx); ok {
+		return p
+	}
+	return c.cfg.priority
+}
+
+// qosGate serializes lower-priority requests behind higher-priority ones
+// sharing the client. The zero value is ready to use. Starvation under a
+// sustained interactive load is by design: that is what preemption means
+// here, and batch callers still make progress through their context
+// deadlines and retries.
+type qosGate struct {
+	mu      sync.Mutex
+	active  [rankCount]int
+	changed chan struct{}
+}
+
+// acquire blocks until no request of a strictly higher rank is in
+// flight, then registers the caller. It returns early when ctx is done.
+func (g *qosGate) acquire(ctx context.Context, rank int) error {
+	g.mu.Lock()
+	for {
+		blocked := false
+		for r := rank + 1; r < rankCount; r++ {
+			if g.active[r] > 0 {
+				blocked = true
+				break
+			}
+		}
+		if !blocked {
+			g.active[rank]++
+			g.mu.Unlock()
+			return nil
+		}
+		if g.changed == nil {
+			g.changed = make(chan struct{})
+		}
+		ch := g.changed
+		g.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ch:
+		}
+		g.mu.Lock()
+	}
+}
+
+// release deregisters a request admitted by acquire and wakes waiters.
+func (g *qosGate) release(rank int) {
+	g.mu.Lock()
+	g.active[rank]--
+	if g.changed != nil {
+		close(g.changed)
+		g.changed = nil
+	}
+	g.mu.Unlock()
+}
@@ -0,0 +1,109 @@
+package codex
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPriorityHeader(t *testing.T) {
+	var got string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("X-Codex-Priority")
+		w.Write([]byte(`{}`))
+	}), WithPriority(PriorityBatch))
+
+	if _, err := c.ListCollections(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got != "batch" {
+		t.Fatalf("priority = %q", got)
+	}
+
+	ctx := ContextWithPriority(context.Background(), PriorityInteractive)
+	if _, err := c.ListCollections(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got != "interactive" {
+		t.Fatalf("context priority not propagated: %q", got)
+	}
+}
+
+func TestInteractivePreemptsBatch(t *testing.T) {
+	arrived := make(chan struct{})
+	hold := make(chan struct{})
+	var batchSeen atomic.Bool
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Codex-Priority") == "interactive" {
+			close(arrived)
+			<-hold
+		} else {
+			batchSeen.Store(true)
+		}
+		w.Write([]byte(`{}`))
+	}))
+
+	interactiveDone := make(chan error, 1)
+	go func() {
+		ctx := ContextWithPriority(context.Background(), PriorityInteractive)
+		_, err := c.ListCollections(ctx)
+		interactiveDone <- err
+	}()
+	<-arrived
+
+	batchDone := make(chan error, 1)
+	go func() {
+		ctx := ContextWithPriority(context.Background(), PriorityBatch)
+		_, err := c.ListCollections(ctx)
+		batchDone <- err
+	}()
+
+	// The batch call must queue behind the in-flight interactive one.
+	time.Sleep(50 * time.Millisecond)
+	if batchSeen.Load() {
+		t.Fatal("batch request ran while interactive request was in flight")
+	}
+
+	close(hold)
+	if err := <-interactiveDone; err != nil {
+		t.Fatal(err)
+	}
+	if err := <-batchDone; err != nil {
+		t.Fatal(err)
+	}
+	if !batchSeen.Load() {
+		t.Fatal("batch request never ran")
+	}
+}
+
+func TestQOSGateRespectsContext(t *testing.T) {
+	var g qosGate
+	if err := g.acquire(context.Background(), rankInteractive); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := g.acquire(ctx, rankBatch); err == nil {
+		t.Fatal("expected context error")
+	}
+	g.release(rankInteractive)
+	if err := g.acquire(context.Background(), rankBatch); err != nil {
+		t.Fatal(err)
+	}
+	g.release(rankBatch)
+}
+
+func TestBackgroundWaitsForBatch(t *testing.T) {
+	var g qosGate
+	if err := g.acquire(context.Background(), rankBatch); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := g.acquire(ctx, rankBackground); err == nil {
+		t.Fatal("background should queue behind batch")
+	}
+	g.release(rankBatch)
+}
@@ -0,0 +1,54 @@
+package codex
+
+import "time"
+
+// Progress is one update from a bulk operation. Total is zero when the
+// item count is not known up front (e.g. streaming listings).
+type Progress struct {
+	// Operation is "embed", "upsert", or "ingest".
+	Operation string
+	Done      int
+	Total     int
+	Bytes     int64
+	Failed    int
+	Started   time.Time
+}
+
+// Rate returns the processing rate in items per second since Started.
+func (p Progress) Rate() float64 {
+	elapsed := time.Since(p.Started).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(p.Done) / elapsed
+}
+
+// ETA estimates the remaining time at the current rate, or zero when the
+// total is unknown.
+func (p Progress) ETA() time.Duration {
+	rate := p.Rate()
+	if p.Total <= 0 || rate <= 0 || p.Done >= p.Total {
+		return 0
+	}
+	return time.Duration(float64(p.Total-p.Done) / rate * float64(time.Second))
+}
+
+// ProgressSink receives updates from bulk operations. Implementations
+// must be safe for concurrent use; updates may arrive from multiple
+// goroutines.
+type ProgressSink interface {
+	Progress(p Progress)
+}
+
+// ProgressFunc adapts a function to the ProgressSink interface.
+type ProgressFunc func(Progress)
+
+// Progress implements ProgressSink.
+func (f ProgressFunc) Progress(p Progress) { f(p) }
+
+// report sends p to the configured sink, if any.
+func (c *Client) report(p Progress) {
+	if c.cfg.progress != nil {
+		c.cfg.progress.Progress(p)
+	}
+}
@@ -0,0 +1,165 @@
+// Package prompt builds chat prompts from named templates instead of
+// hand-concatenated strings: variables are declared and checked, shared
+// fragments live in partials, few-shot examples render as proper message
+// pairs, and a token budget trims the prompt predictably.
+package prompt
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// Example is one few-shot demonstration, rendered as a user/assistant
+// message pair ahead of the real input.
+type Example struct {
+	Input  string
+	Output string
+}
+
+// Template is a named chat prompt template. Template text uses
+// text/template syntax; partials registered with Partial are available
+// via {{template "name" .}}.
+type Template struct {
+	name     string
+	system   string
+	user     string
+	partials map[string]string
+	examples []Example
+	required []string
+	budget   int
+}
+
+// New creates an empty template with the given name.
+func New(name string) *Template {
+	return &Template{name: name, partials: map[string]string{}}
+}
+
+// Name returns the template's name.
+func (t *Template) Name() string { return t.name }
+
+// System sets the system message template.
+func (t *Template) System(text string) *Template {
+	t.system = text
+	return t
+}
+
+// User sets the user message template.
+func (t *Template) User(text string) *Template {
+	t.user = text
+	return t
+}
+
+// Partial registers a named fragment usable from the system and user
+// templates via {{template "name" .}}.
+func (t *Template) Partial(name, text string) *Template {
+	t.partials[name] = text
+	return t
+}
+
+// Examples appends few-shot examples. Under token pressure, examples are
+// the first thing dropped (from the end of the list).
+func (t *Template) Examples(examples ...Example) *Template {
+	t.examples = append(t.examples, examples...)
+	return t
+}
+
+// Require declares variables that must be present (and non-nil) in the
+// data passed to Render.
+func (t *Template) Require(vars ...string) *Template {
+	t.required = append(t.required, vars...)
+	return t
+}
+
+// TokenBudget caps the estimated token count of the rendered prompt.
+// Zero means unbounded.
+func (t *Template) TokenBudget(n int) *Template {
+	t.budget = n
+	return t
+}
+
+// Render executes the template with vars and returns the prompt as chat
+// messages: system (if set), few-shot example pairs, then the user
+// message. If the estimated size exceeds the token budget, examples are
+// dropped from the end; if it still overflows, the user message tail is
+// truncated.
+func (t *Template) Render(vars map[string]any) ([]codex.Message, error) {
+	var missing []string
+	for _, name := range t.required {
+		if v, ok := vars[name]; !ok || v == nil {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("prompt %s: missing variables: %s", t.name, strings.Join(missing, ", "))
+	}
+
+	system, err := t.execute("system", t.system, vars)
+	if err != nil {
+		return nil, err
+	}
+	user, err := t.execute("user", t.user, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	examples := t.examples
+	messages := assemble(system, examples, user)
+	if t.budget > 0 {
+		for codex.EstimateMessageTokens(messages) > t.budget && len(examples) > 0 {
+			examples = examples[:len(examples)-1]
+			messages = assemble(system, examples, user)
+		}
+		if over := codex.EstimateMessageTokens(messages) - t.budget; over > 0 {
+			keep := len(user) - over*4
+			if keep < 0 {
+				keep = 0
+			}
+			user = user[:keep]
+			messages = assemble(system, examples, user)
+		}
+	}
+	return messages, nil
+}
+
+func (t *Template) execute(part, text string, vars map[string]any) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	tmpl := template.New(t.name + ":" + part).Option("missingkey=error")
+	for name, body := range t.partials {
+		if _, err := tmpl.New(name).Parse(body); err != nil {
+			return "", fmt.Errorf("prompt %s: partial %s: %w", t.name, name, err)
+		}
+	}
+	tmpl, err := tmpl.Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("prompt %s: parse %s: %w", t.name, part, err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, vars); err != nil {
+		return "", fmt.Errorf("prompt %s: render %s: %w", t.name, part, err)
+	}
+	return b.String(), nil
+}
+
+func assemble(system string, examples []Example, user string) []codex.Message {
+	var messages []codex.Message
+	if system != "" {
+		messages = append(messages, codex.Message{Role: codex.RoleSystem, Content: system})
+	}
+	for _, ex := range examples {
+		messages = append(messages,
+			codex.Message{Role: codex.RoleUser, Content: ex.Input},
+			codex.Message{Role: codex.RoleAssistant, Content: ex.Output},
+		)
+	}
+	if user != "" {
+		messages = append(messages, codex.Message{Role: codex.RoleUser, Content: user})
+	}
+	return messages
+}
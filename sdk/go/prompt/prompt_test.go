@@ -0,0 +1,56 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+func TestRenderWithPartialsAndExamples(t *testing.T) {
+	tmpl := New("support").
+		System(`You answer questions about {{.product}}. {{template "tone" .}}`).
+		Partial("tone", "Be concise.").
+		Examples(Example{Input: "How do I log in?", Output: "Use the login page."}).
+		User("{{.question}}").
+		Require("product", "question")
+
+	msgs, err := tmpl.Render(map[string]any{"product": "Widget", "question": "How do I reset?"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 4 {
+		t.Fatalf("got %d messages", len(msgs))
+	}
+	if msgs[0].Role != codex.RoleSystem || !strings.Contains(msgs[0].Content, "Be concise.") {
+		t.Fatalf("bad system message: %+v", msgs[0])
+	}
+	if msgs[3].Content != "How do I reset?" {
+		t.Fatalf("bad user message: %+v", msgs[3])
+	}
+}
+
+func TestRenderMissingVariable(t *testing.T) {
+	tmpl := New("t").User("{{.question}}").Require("question")
+	if _, err := tmpl.Render(map[string]any{}); err == nil {
+		t.Fatal("expected missing-variable error")
+	}
+}
+
+func TestRenderDropsExamplesUnderBudget(t *testing.T) {
+	big := strings.Repeat("example text ", 100)
+	tmpl := New("t").
+		User("{{.q}}").
+		Examples(Example{Input: big, Output: big}, Example{Input: big, Output: big}).
+		TokenBudget(400)
+	msgs, err := tmpl.Render(map[string]any{"q": "short question"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if codex.EstimateMessageTokens(msgs) > 400 {
+		t.Fatalf("budget exceeded: %d tokens", codex.EstimateMessageTokens(msgs))
+	}
+	if msgs[len(msgs)-1].Content != "short question" {
+		t.Fatalf("user message lost: %+v", msgs)
+	}
+}
@@ -0,0 +1,88 @@
+package prompt
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	codex "github.com/hackall360/codex-extended/sdk/go"
+)
+
+// Selector picks few-shot examples dynamically: labeled examples are
+// stored (embedded by their input) in a dedicated collection, and the k
+// nearest to the live input are selected at prompt-build time.
+type Selector struct {
+	Client     codex.Codex
+	Collection string
+	// K is how many examples to select. Defaults to 3.
+	K int
+	// Filter optionally restricts selection, e.g. to one label set.
+	Filter codex.Filter
+}
+
+// Add stores a labeled example in the selector's collection. labels may
+// be nil.
+func (s *Selector) Add(ctx context.Context, ex Example, labels map[string]any) error {
+	vec, err := s.Client.Embed(ctx, ex.Input)
+	if err != nil {
+		return err
+	}
+	meta := map[string]any{"input": ex.Input, "output": ex.Output}
+	for k, v := range labels {
+		meta[k] = v
+	}
+	sum := sha256.Sum256([]byte(ex.Input))
+	return s.Client.Upsert(ctx, s.Collection, codex.VectorRecord{
+		ID:       "example-" + hex.EncodeToString(sum[:8]),
+		Vector:   vec,
+		Document: ex.Input,
+		Metadata: meta,
+	})
+}
+
+// Select returns the examples nearest to input, most similar first.
+func (s *Selector) Select(ctx context.Context, input string) ([]Example, error) {
+	k := s.K
+	if k <= 0 {
+		k = 3
+	}
+	vec, err := s.Client.Embed(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := s.Client.Query(ctx, s.Collection, vec, k, s.Filter)
+	if err != nil {
+		return nil, err
+	}
+	examples := make([]Example, 0, len(matches))
+	for _, m := range matches {
+		in, _ := m.Metadata["input"].(string)
+		out, _ := m.Metadata["output"].(string)
+		if in == "" && m.Document != "" {
+			in = m.Document
+		}
+		if in == "" || out == "" {
+			continue
+		}
+		examples = append(examples, Example{Input: in, Output: out})
+	}
+	return examples, nil
+}
+
+// RenderDynamic renders the template with examples selected for input
+// appended after any statically configured ones. The input itself is
+// available to the user template as {{.input}} unless vars overrides it.
+func (t *Template) RenderDynamic(ctx context.Context, sel *Selector, input string, vars map[string]any) ([]codex.Message, error) {
+	selected, err := sel.Select(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("prompt %s: select examples: %w", t.name, err)
+	}
+	merged := map[string]any{"input": input}
+	for k, v := range vars {
+		merged[k] = v
+	}
+	clone := *t
+	clone.examples = append(append([]Example{}, t.examples...), selected...)
+	return clone.Render(merged)
+}
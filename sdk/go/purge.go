@@ -0,0 +1,68 @@
+package codex
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// DeletionReport proves a purge-by-subject completed: what was destroyed
+// where, when, and a server signature over those facts. Keep it — it is
+// the artifact auditors ask for.
+type DeletionReport struct {
+	SubjectID string `json:"subject_id"`
+	// RecordsPurged counts destroyed vector records per collection.
+	RecordsPurged       map[string]int64 `json:"records_purged,omitempty"`
+	ConversationsPurged int64            `json:"conversations_purged,omitempty"`
+	CachedAnswersPurged int64            `json:"cached_answers_purged,omitempty"`
+	CompletedAt         time.Time        `json:"completed_at"`
+	// Signature is the server's Ed25519 signature (base64) over the
+	// report JSON with this field empty.
+	Signature string `json:"signature,omitempty"`
+}
+
+// signedPayload is the canonical byte form the signature covers.
+func (r DeletionReport) signedPayload() ([]byte, error) {
+	unsigned := r
+	unsigned.Signature = ""
+	return json.Marshal(unsigned)
+}
+
+// Verify checks the report's signature against the server's public key.
+func (r DeletionReport) Verify(pub ed25519.PublicKey) error {
+	sig, err := base64.StdEncoding.DecodeString(r.Signature)
+	if err != nil {
+		return errors.New("codex: deletion report signature is not valid base64")
+	}
+	payload, err := r.signedPayload()
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(pub, payload, sig) {
+		return errors.New("codex: deletion report signature does not verify")
+	}
+	return nil
+}
+
+type purgeSubjectRequest struct {
+	SubjectID string `json:"subject_id"`
+}
+
+// PurgeBySubject permanently deletes every record, stored conversation,
+// and cached answer associated with the data-subject identifier across
+// all collections, and returns the server's signed deletion report. This
+// bypasses the soft-delete grace period and is irreversible.
+func (c *Client) PurgeBySubject(ctx context.Context, subjectID string) (*DeletionReport, error) {
+	if subjectID == "" {
+		return nil, errors.New("codex: subject ID must not be empty")
+	}
+	var report DeletionReport
+	if err := c.do(ctx, http.MethodPost, "/v1/purge-subject", purgeSubjectRequest{SubjectID: subjectID}, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
@@ -0,0 +1,35 @@
+package codex
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestDeletionReportVerify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := DeletionReport{
+		SubjectID:           "user-7",
+		RecordsPurged:       map[string]int64{"kb": 12},
+		ConversationsPurged: 3,
+		CompletedAt:         time.Date(2026, 8, 30, 10, 0, 0, 0, time.UTC),
+	}
+	payload, err := report.signedPayload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	report.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(priv, payload))
+
+	if err := report.Verify(pub); err != nil {
+		t.Fatalf("valid report rejected: %v", err)
+	}
+	tampered := report
+	tampered.RecordsPurged = map[string]int64{"kb": 0}
+	if err := tampered.Verify(pub); err == nil {
+		t.Fatal("tampered report verified")
+	}
+}
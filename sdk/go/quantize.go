@@ -0,0 +1,158 @@
+package codex
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// Quantization names a vector storage scheme.
+type Quantization string
+
+const (
+	// QuantInt8 stores each component as a signed byte with a shared
+	// scale; roughly 4x smaller with little recall loss.
+	QuantInt8 Quantization = "int8"
+	// QuantBinary stores one sign bit per component; 32x smaller, for
+	// very large collections where coarse recall is acceptable.
+	QuantBinary Quantization = "binary"
+)
+
+// QuantizedVector is a compressed vector as stored on the wire.
+type QuantizedVector struct {
+	Scheme Quantization `json:"scheme"`
+	Data   []byte       `json:"data"`
+	// Scale maps int8 values back to floats (value = int8 * Scale);
+	// unused for binary.
+	Scale float32 `json:"scale,omitempty"`
+}
+
+// Normalize returns a new unit-length (L2) copy of v. A zero vector is
+// returned unchanged.
+func Normalize(v []float32) []float32 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	out := make([]float32, len(v))
+	if sum == 0 {
+		copy(out, v)
+		return out
+	}
+	inv := 1 / math.Sqrt(sum)
+	for i, x := range v {
+		out[i] = float32(float64(x) * inv)
+	}
+	return out
+}
+
+// QuantizeInt8 compresses v to one signed byte per component, scaled so
+// the largest magnitude maps to 127.
+func QuantizeInt8(v []float32) *QuantizedVector {
+	var maxAbs float32
+	for _, x := range v {
+		if a := float32(math.Abs(float64(x))); a > maxAbs {
+			maxAbs = a
+		}
+	}
+	scale := maxAbs / 127
+	data := make([]byte, len(v))
+	for i, x := range v {
+		q := int8(0)
+		if scale > 0 {
+			q = int8(math.Round(float64(x / scale)))
+		}
+		data[i] = byte(q)
+	}
+	return &QuantizedVector{Scheme: QuantInt8, Data: data, Scale: scale}
+}
+
+// QuantizeBinary compresses v to one sign bit per component, packed
+// most-significant bit first.
+func QuantizeBinary(v []float32) *QuantizedVector {
+	data := make([]byte, (len(v)+7)/8)
+	for i, x := range v {
+		if x > 0 {
+			data[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	return &QuantizedVector{Scheme: QuantBinary, Data: data}
+}
+
+// Dequantize reconstructs an approximate float vector. Int8 applies the
+// stored scale; binary yields ±1 per bit. dims bounds the binary output
+// since packing loses the exact length; pass 0 for int8.
+func (q *QuantizedVector) Dequantize(dims int) []float32 {
+	switch q.Scheme {
+	case QuantInt8:
+		out := make([]float32, len(q.Data))
+		for i, b := range q.Data {
+			out[i] = float32(int8(b)) * q.Scale
+		}
+		return out
+	case QuantBinary:
+		if dims == 0 || dims > len(q.Data)*8 {
+			dims = len(q.Data) * 8
+		}
+		out := make([]float32, dims)
+		for i := range out {
+			if q.Data[i/8]&(1<<(7-i%8)) != 0 {
+				out[i] = 1
+			} else {
+				out[i] = -1
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// UpsertQuantized quantizes each record's vector with scheme and upserts
+// the compressed form, after checking that the server advertises the
+// scheme for the collection. Records already quantized pass through
+// untouched.
+func (c *Client) UpsertQuantized(ctx context.Context, collection string, records []VectorRecord, scheme Quantization) error {
+	collection = c.collection(collection)
+	ok, err := c.supportsQuantization(ctx, collection, scheme)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("codex: collection %q does not advertise %s quantization", collection, scheme)
+	}
+	out := make([]VectorRecord, len(records))
+	for i, rec := range records {
+		if rec.Quantized == nil && len(rec.Vector) > 0 {
+			switch scheme {
+			case QuantInt8:
+				rec.Quantized = QuantizeInt8(rec.Vector)
+			case QuantBinary:
+				rec.Quantized = QuantizeBinary(rec.Vector)
+			default:
+				return fmt.Errorf("codex: unknown quantization scheme %q", scheme)
+			}
+			rec.Vector = nil
+		}
+		out[i] = rec
+	}
+	return c.UpsertBatch(ctx, collection, out)
+}
+
+// supportsQuantization checks the collection's advertised schemes.
+func (c *Client) supportsQuantization(ctx context.Context, collection string, scheme Quantization) (bool, error) {
+	cols, err := c.ListCollections(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, col := range cols {
+		if col.Name != collection {
+			continue
+		}
+		for _, q := range col.Quantization {
+			if q == scheme {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
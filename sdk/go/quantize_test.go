@@ -0,0 +1,78 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	v := Normalize([]float32{3, 4})
+	if math.Abs(float64(v[0])-0.6) > 1e-6 || math.Abs(float64(v[1])-0.8) > 1e-6 {
+		t.Fatalf("got %v", v)
+	}
+	if z := Normalize([]float32{0, 0}); z[0] != 0 || z[1] != 0 {
+		t.Fatalf("zero vector: %v", z)
+	}
+}
+
+func TestQuantizeInt8RoundTrip(t *testing.T) {
+	in := []float32{0.5, -1, 0.25, 0}
+	q := QuantizeInt8(in)
+	if q.Scheme != QuantInt8 || len(q.Data) != 4 {
+		t.Fatalf("bad quantized form: %+v", q)
+	}
+	out := q.Dequantize(0)
+	for i := range in {
+		if math.Abs(float64(out[i]-in[i])) > 0.01 {
+			t.Fatalf("component %d: %v -> %v", i, in[i], out[i])
+		}
+	}
+}
+
+func TestQuantizeBinary(t *testing.T) {
+	q := QuantizeBinary([]float32{1, -1, 0.5, -0.5, 1, 1, -1, -1, 1})
+	if len(q.Data) != 2 {
+		t.Fatalf("packed to %d bytes", len(q.Data))
+	}
+	out := q.Dequantize(9)
+	want := []float32{1, -1, 1, -1, 1, 1, -1, -1, 1}
+	for i := range want {
+		if out[i] != want[i] {
+			t.Fatalf("bit %d: got %v want %v", i, out[i], want[i])
+		}
+	}
+}
+
+func TestUpsertQuantized(t *testing.T) {
+	var got upsertRequest
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/collections":
+			json.NewEncoder(w).Encode(collectionsWire{Collections: []Collection{
+				{Name: "kb", Dimensions: 2, Quantization: []Quantization{QuantInt8}},
+			}})
+		case strings.HasSuffix(r.URL.Path, "/vectors"):
+			json.NewDecoder(r.Body).Decode(&got)
+		}
+	}))
+	err := c.UpsertQuantized(context.Background(), "kb", []VectorRecord{
+		{ID: "a", Vector: []float32{1, -1}},
+	}, QuantInt8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := got.Records[0]
+	if rec.Vector != nil || rec.Quantized == nil || rec.Quantized.Scheme != QuantInt8 {
+		t.Fatalf("record not quantized: %+v", rec)
+	}
+
+	// Binary is not advertised for this collection.
+	err = c.UpsertQuantized(context.Background(), "kb", []VectorRecord{{ID: "a", Vector: []float32{1}}}, QuantBinary)
+	if err == nil {
+		t.Fatal("expected unsupported-scheme error")
+	}
+}
@@ -0,0 +1,136 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// These tests exist to run under the race detector: they exercise one
+// client (and one pool) from many goroutines across queries, retried
+// requests, and streams.
+
+func TestClientConcurrentUse(t *testing.T) {
+	var hits atomic.Int64
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail every fifth request to exercise the retry path.
+		if hits.Add(1)%5 == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/chat":
+			fmt.Fprint(w, `{"reply":"ok","finish_reason":"stop"}`)
+		default:
+			json.NewEncoder(w).Encode(queryWire{Matches: []Match{{VectorRecord: VectorRecord{ID: "a"}}}})
+		}
+	})
+	// A generous retry budget keeps the 1-in-5 failure injection from
+	// sinking a request whose every attempt happens to draw a failure.
+	c := newTestClient(t, handler, WithMaxRetries(6),
+		WithVectorPool(&VectorPool{}), WithBufferPool(&BufferPool{}))
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				if _, err := c.Query(ctx, "kb", []float32{1}, 1, nil); err != nil {
+					t.Error(err)
+					return
+				}
+				if _, err := c.Chat(ctx, []Message{{Role: RoleUser, Content: "hi"}}); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConcurrentStreams(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"token\":\"a\"}\n\ndata: {\"token\":\"b\"}\n\nevent: done\ndata: {}\n\n")
+	}))
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stream, err := c.ChatStream(ctx, []Message{{Role: RoleUser, Content: "hi"}})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer stream.Close()
+			for {
+				if _, err := stream.Recv(); err != nil {
+					if err != io.EOF {
+						t.Error(err)
+					}
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestPoolConcurrentUse(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(collectionsWire{})
+	}))
+	p, err := NewPool(PoolOptions{Size: 4, MaxPerEndpoint: 2}, WithBaseURL(c.BaseURL()), WithMaxRetries(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				if _, err := p.ListCollections(ctx); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}), WithDefaultCollection("kb"), WithMetadataSchema("kb", articleSchema))
+
+	clone, err := c.Clone(WithDefaultCollection("other"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clone.cfg.defaultCollection != "other" || c.cfg.defaultCollection != "kb" {
+		t.Fatalf("clone config leaked: %q %q", clone.cfg.defaultCollection, c.cfg.defaultCollection)
+	}
+	if _, ok := clone.schema("kb"); !ok {
+		t.Fatal("clone lost registered schema")
+	}
+	// Schemas registered on the parent after cloning stay local to it.
+	c.schemas.Store("late", MetadataSchema{})
+	if _, ok := clone.schema("late"); ok {
+		t.Fatal("clone aliases parent schema map")
+	}
+}
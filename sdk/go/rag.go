@@ -0,0 +1,172 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// RAGOptions tunes a retrieval-augmented answer request.
+type RAGOptions struct {
+	Collection string `json:"collection,omitempty"`
+	Tier       string `json:"tier,omitempty"`
+	TopK       int    `json:"top_k,omitempty"`
+	Filter     Filter `json:"filter,omitempty"`
+	// Language restricts retrieval to chunks ingested in this language
+	// (matching the "lang" metadata written by the ingest package).
+	Language string `json:"language,omitempty"`
+	// AnswerLanguage asks the model to answer in this language even when
+	// the retrieved context is in another, for cross-language corpora.
+	AnswerLanguage string `json:"answer_language,omitempty"`
+	// Actor scopes retrieval to records this subject may see; see WithActor.
+	Actor *Actor `json:"actor,omitempty"`
+	// Explain asks the server for retrieval internals; see WithExplain.
+	Explain bool `json:"explain,omitempty"`
+	// Trace asks the server for a full retrieval trace; see WithTrace.
+	Trace bool `json:"trace,omitempty"`
+	// ParentExpansion widens retrieved chunks to a window of this many
+	// neighbours on either side; see WithParentExpansion.
+	ParentExpansion int `json:"parent_expansion,omitempty"`
+	// KnowledgeBase answers from a named knowledge base instead of a
+	// bare collection; see WithKnowledgeBase.
+	KnowledgeBase string `json:"kb,omitempty"`
+	// Reranker names the server-side reranker to order candidates with;
+	// see WithReranker.
+	Reranker string `json:"reranker,omitempty"`
+	// RecencyHalfLifeSeconds decays retrieval scores by document age;
+	// see WithRecencyBoost.
+	RecencyHalfLifeSeconds int64 `json:"recency_half_life_seconds,omitempty"`
+	// LatestOnly and PinnedVersions control which document versions are
+	// retrievable; see WithLatestOnly and WithPinnedVersions.
+	LatestOnly     bool           `json:"latest_only,omitempty"`
+	PinnedVersions map[string]int `json:"pinned_versions,omitempty"`
+	// GroundingCheck, GroundingStrip, and GroundingTier configure the
+	// client-side verification pass; see WithGroundingCheck. They are
+	// not sent to the server.
+	GroundingCheck bool   `json:"-"`
+	GroundingStrip bool   `json:"-"`
+	GroundingTier  string `json:"-"`
+	// MinConfidence is the client-side answerability floor; see
+	// WithMinConfidence.
+	MinConfidence float64 `json:"-"`
+	// MultiHop is the sub-query budget for iterative retrieval; see
+	// WithMultiHop.
+	MultiHop int `json:"-"`
+}
+
+// RAGOption mutates RAGOptions.
+type RAGOption func(*RAGOptions)
+
+// WithCollection selects the collection to retrieve from.
+func WithCollection(name string) RAGOption {
+	return func(o *RAGOptions) { o.Collection = name }
+}
+
+// WithRAGTier selects the model tier that generates the answer.
+func WithRAGTier(tier string) RAGOption {
+	return func(o *RAGOptions) { o.Tier = tier }
+}
+
+// WithTopK sets how many chunks are retrieved as context.
+func WithTopK(k int) RAGOption {
+	return func(o *RAGOptions) { o.TopK = k }
+}
+
+// WithReranker orders retrieval candidates with the named server-side
+// reranker before assembly.
+func WithReranker(name string) RAGOption {
+	return func(o *RAGOptions) { o.Reranker = name }
+}
+
+// WithFilter restricts retrieval to records matching filter.
+func WithFilter(f Filter) RAGOption {
+	return func(o *RAGOptions) { o.Filter = f }
+}
+
+// WithLanguage restricts retrieval to chunks in the given language.
+func WithLanguage(lang string) RAGOption {
+	return func(o *RAGOptions) { o.Language = lang }
+}
+
+// WithAnswerLanguage asks for the answer in the given language regardless
+// of the language of the retrieved context.
+func WithAnswerLanguage(lang string) RAGOption {
+	return func(o *RAGOptions) { o.AnswerLanguage = lang }
+}
+
+// Reference is one retrieved chunk that contributed to an answer.
+type Reference struct {
+	ID       string         `json:"id"`
+	Document string         `json:"document,omitempty"`
+	Score    float32        `json:"score"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+	// URL, Title, and the offset fields locate the cited passage in its
+	// source. Servers may set them directly; when absent they are
+	// resolved from metadata (see SourceURL and SourceTitle).
+	URL       string `json:"url,omitempty"`
+	Title     string `json:"title,omitempty"`
+	StartByte int    `json:"start_byte,omitempty"`
+	EndByte   int    `json:"end_byte,omitempty"`
+	StartLine int    `json:"start_line,omitempty"`
+	EndLine   int    `json:"end_line,omitempty"`
+}
+
+// Result is a retrieval-augmented answer with its supporting references.
+type Result struct {
+	Answer     string      `json:"answer"`
+	References []Reference `json:"references,omitempty"`
+	Usage      Usage       `json:"usage"`
+	// Explain is only set when the question was asked WithExplain.
+	Explain *Explain `json:"explain,omitempty"`
+	// Trace is only set when the question was asked WithTrace.
+	Trace *Trace `json:"trace,omitempty"`
+	// Variant names the experiment arm that served this answer, when the
+	// call went through an Experiment.
+	Variant string `json:"variant,omitempty"`
+	// Grounding is only set when the question was asked
+	// WithGroundingCheck.
+	Grounding *GroundingReport `json:"grounding,omitempty"`
+	// Confidence is the server's answerability score in [0, 1]: how well
+	// the retrieved context supports answering at all. Zero on servers
+	// that do not score answerability.
+	Confidence float64 `json:"confidence,omitempty"`
+	// Extra holds response fields this client version does not know
+	// about, preserved in lenient decoding mode.
+	Extra json.RawMessage `json:"-"`
+}
+
+type ragRequest struct {
+	Question string `json:"question"`
+	RAGOptions
+}
+
+func (c *Client) ragOptions(opts []RAGOption) RAGOptions {
+	o := RAGOptions{Collection: c.cfg.defaultCollection, Tier: c.cfg.defaultTier}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// RAGAnswer retrieves relevant chunks for question and asks the model to
+// answer grounded in them.
+func (c *Client) RAGAnswer(ctx context.Context, question string, opts ...RAGOption) (*Result, error) {
+	o := c.ragOptions(opts)
+	if o.MultiHop > 1 {
+		return c.multiHopAnswer(ctx, question, o, opts)
+	}
+	req := ragRequest{Question: question, RAGOptions: o}
+	var resp Result
+	if err := c.do(ctx, http.MethodPost, "/v1/rag/answer", req, &resp); err != nil {
+		return nil, err
+	}
+	if o.GroundingCheck {
+		if err := c.verifyGrounding(ctx, &resp, o); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkConfidence(&resp, o); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
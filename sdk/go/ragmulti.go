@@ -0,0 +1,131 @@
+package codex
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// rrfK is the standard reciprocal-rank-fusion damping constant.
+const rrfK = 60
+
+// RAGAnswerMulti answers question using context retrieved from several
+// collections at once. The collections are queried concurrently, the
+// per-collection rankings are fused with reciprocal rank fusion and
+// deduplicated, and the top fused chunks ground a single generation.
+// Each reference carries a "collection" metadata key naming its source.
+func (c *Client) RAGAnswerMulti(ctx context.Context, question string, collections []string, opts ...RAGOption) (*Result, error) {
+	if len(collections) == 0 {
+		return nil, fmt.Errorf("codex: RAGAnswerMulti needs at least one collection")
+	}
+	o := c.ragOptions(opts)
+	topK := o.TopK
+	if topK <= 0 {
+		topK = 5
+	}
+
+	vec, err := c.Embed(ctx, question)
+	if err != nil {
+		return nil, err
+	}
+
+	rankings := make([][]Match, len(collections))
+	errs := make([]error, len(collections))
+	var wg sync.WaitGroup
+	for i, name := range collections {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			matches, err := c.Query(ctx, name, vec, topK, o.Filter)
+			if err == nil && o.Actor != nil {
+				matches = o.Actor.FilterMatches(matches)
+			}
+			rankings[i], errs[i] = matches, err
+		}(i, name)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	refs := fuseRankings(collections, rankings, topK)
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("codex: no context retrieved for question from %d collections", len(collections))
+	}
+	return c.answerFromReferences(ctx, question, refs, o)
+}
+
+// fuseRankings merges per-collection rankings with reciprocal rank
+// fusion, deduplicating chunks that appear in more than one collection
+// (same document text, or same ID within a collection).
+func fuseRankings(collections []string, rankings [][]Match, topK int) []Reference {
+	type fused struct {
+		ref   Reference
+		score float32
+	}
+	byKey := map[string]*fused{}
+	var order []string
+	for i, matches := range rankings {
+		for rank, m := range matches {
+			key := m.Document
+			if key == "" {
+				key = collections[i] + "/" + m.ID
+			}
+			f, ok := byKey[key]
+			if !ok {
+				meta := make(map[string]any, len(m.Metadata)+1)
+				for k, v := range m.Metadata {
+					meta[k] = v
+				}
+				meta["collection"] = collections[i]
+				f = &fused{ref: Reference{ID: m.ID, Document: m.Document, Metadata: meta}}
+				byKey[key] = f
+				order = append(order, key)
+			}
+			f.score += 1 / float32(rrfK+rank+1)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return byKey[order[a]].score > byKey[order[b]].score
+	})
+	if len(order) > topK {
+		order = order[:topK]
+	}
+	refs := make([]Reference, len(order))
+	for i, key := range order {
+		f := byKey[key]
+		f.ref.Score = f.score
+		refs[i] = f.ref
+	}
+	return refs
+}
+
+// answerFromReferences asks the model to answer question grounded in the
+// given references, mirroring what the server does for single-collection
+// RAG.
+func (c *Client) answerFromReferences(ctx context.Context, question string, refs []Reference, o RAGOptions) (*Result, error) {
+	var sb strings.Builder
+	sb.WriteString("Answer the question using only the numbered context passages below. Cite passages by number.\n")
+	if o.AnswerLanguage != "" {
+		fmt.Fprintf(&sb, "Answer in %s.\n", o.AnswerLanguage)
+	}
+	for i, ref := range refs {
+		fmt.Fprintf(&sb, "\n[%d] %s", i+1, ref.Document)
+	}
+	var chatOpts []ChatOption
+	if o.Tier != "" {
+		chatOpts = append(chatOpts, WithTier(o.Tier))
+	}
+	resp, err := c.ChatCompletion(ctx, []Message{
+		{Role: RoleSystem, Content: sb.String()},
+		{Role: RoleUser, Content: question},
+	}, chatOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Answer: resp.Reply, References: refs, Usage: resp.Usage}, nil
+}
@@ -0,0 +1,36 @@
+package codex
+
+import "testing"
+
+func TestFuseRankings(t *testing.T) {
+	collections := []string{"kb1", "kb2"}
+	rankings := [][]Match{
+		{
+			{VectorRecord: VectorRecord{ID: "a", Document: "shared doc"}},
+			{VectorRecord: VectorRecord{ID: "b", Document: "only kb1"}},
+		},
+		{
+			{VectorRecord: VectorRecord{ID: "x", Document: "shared doc"}},
+			{VectorRecord: VectorRecord{ID: "y", Document: "only kb2"}},
+		},
+	}
+	refs := fuseRankings(collections, rankings, 10)
+	if len(refs) != 3 {
+		t.Fatalf("expected dedup to 3 refs, got %d: %+v", len(refs), refs)
+	}
+	// The chunk ranked first in both collections must fuse to the top.
+	if refs[0].Document != "shared doc" {
+		t.Fatalf("expected shared doc first, got %+v", refs[0])
+	}
+	if refs[0].Score <= refs[1].Score {
+		t.Fatalf("fused score should exceed single-list scores: %+v", refs)
+	}
+	if refs[0].Metadata["collection"] != "kb1" {
+		t.Fatalf("missing source attribution: %+v", refs[0].Metadata)
+	}
+
+	refs = fuseRankings(collections, rankings, 2)
+	if len(refs) != 2 {
+		t.Fatalf("topK trim failed: %d", len(refs))
+	}
+}
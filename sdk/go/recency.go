@@ -0,0 +1,55 @@
+package codex
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// WithRecencyBoost decays retrieval scores by document age with the
+// given half-life: a document one half-life old competes with half its
+// raw score, so newer documents win when scores are close. Meant for
+// news and changelog corpora where a stale answer is worse than none.
+// The decay reads the mod_time metadata the ingest package writes.
+func WithRecencyBoost(halfLife time.Duration) RAGOption {
+	return func(o *RAGOptions) { o.RecencyHalfLifeSeconds = int64(halfLife / time.Second) }
+}
+
+// RescoreByRecency applies the same timestamp decay client-side: each
+// match's score is multiplied by 2^(-age/halfLife) and the matches are
+// re-sorted. Matches without a parseable mod_time keep their raw score.
+func RescoreByRecency(matches []Match, halfLife time.Duration, now time.Time) []Match {
+	if halfLife <= 0 {
+		return matches
+	}
+	out := make([]Match, len(matches))
+	copy(out, matches)
+	for i := range out {
+		ts, ok := recordTime(out[i].Metadata)
+		if !ok {
+			continue
+		}
+		age := now.Sub(ts)
+		if age <= 0 {
+			continue
+		}
+		out[i].Score *= float32(math.Exp2(-age.Hours() / halfLife.Hours()))
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	return out
+}
+
+// recordTime reads a record's mod_time metadata, accepting RFC 3339
+// strings (how time.Time round-trips through JSON) and Unix seconds.
+func recordTime(meta map[string]any) (time.Time, bool) {
+	switch v := meta["mod_time"].(type) {
+	case string:
+		ts, err := time.Parse(time.RFC3339, v)
+		return ts, err == nil
+	case float64:
+		return time.Unix(int64(v), 0), true
+	case time.Time:
+		return v, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,50 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRescoreByRecency(t *testing.T) {
+	now := time.Date(2026, 8, 30, 12, 0, 0, 0, time.UTC)
+	fresh := Match{VectorRecord: VectorRecord{
+		ID:       "fresh",
+		Metadata: map[string]any{"mod_time": now.Add(-time.Hour).Format(time.RFC3339)},
+	}, Score: 0.80}
+	stale := Match{VectorRecord: VectorRecord{
+		ID:       "stale",
+		Metadata: map[string]any{"mod_time": now.Add(-30 * 24 * time.Hour).Format(time.RFC3339)},
+	}, Score: 0.85}
+	plain := Match{VectorRecord: VectorRecord{ID: "undated"}, Score: 0.5}
+
+	out := RescoreByRecency([]Match{stale, fresh, plain}, 7*24*time.Hour, now)
+	if out[0].ID != "fresh" {
+		t.Fatalf("order: %v %v %v", out[0].ID, out[1].ID, out[2].ID)
+	}
+	if out[1].ID != "undated" || out[1].Score != 0.5 {
+		t.Fatalf("undated match rescored: %+v", out[1])
+	}
+	// 30 days at a 7-day half-life is a bit over 4 halvings.
+	for _, m := range out {
+		if m.ID == "stale" && m.Score > 0.85/16 {
+			t.Fatalf("stale score = %v", m.Score)
+		}
+	}
+}
+
+func TestWithRecencyBoostOnWire(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ragRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.RecencyHalfLifeSeconds != 7*24*3600 {
+			t.Errorf("half life = %d", req.RecencyHalfLifeSeconds)
+		}
+		json.NewEncoder(w).Encode(Result{Answer: "ok"})
+	}))
+	if _, err := c.RAGAnswer(context.Background(), "q", WithRecencyBoost(7*24*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+}
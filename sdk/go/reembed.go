@@ -0,0 +1,174 @@
+package codex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type aliasRequest struct {
+	Target string `json:"target"`
+}
+
+// SwapAlias atomically repoints the collection name alias at target, so
+// readers switch to a rebuilt collection without a gap.
+func (c *Client) SwapAlias(ctx context.Context, alias, target string) error {
+	path := "/v1/collections/" + url.PathEscape(alias) + "/alias"
+	return c.do(ctx, http.MethodPost, path, aliasRequest{Target: target}, nil)
+}
+
+// ReembedOptions tunes a Reembed run.
+type ReembedOptions struct {
+	// BatchSize is how many documents move per round; defaults to 100.
+	BatchSize int
+	// ShadowSuffix names the shadow collection (collection + suffix);
+	// defaults to "-reembed".
+	ShadowSuffix string
+	// SampleQueries are representative search texts used to verify the
+	// new embeddings before the swap: each is run against both the old
+	// and the new collection and the top results are compared.
+	SampleQueries []string
+	// MinRecall is the fraction of sample queries whose top result must
+	// agree for the swap to proceed; defaults to 0.8.
+	MinRecall float64
+	// Progress receives updates as batches are re-embedded.
+	Progress ProgressSink
+}
+
+// ReembedStats summarizes a Reembed run.
+type ReembedStats struct {
+	Documents int
+	Shadow    string
+	Recall    float64
+	Swapped   bool
+}
+
+// Reembed rebuilds collection's vectors with a new embedding model: it
+// streams every document out of the store, embeds it on newTier into a
+// shadow collection, verifies retrieval quality against the sample
+// queries, and — if recall holds up — swaps the collection alias to the
+// shadow. On a failed verification the shadow is left in place for
+// inspection and an error is returned.
+func (c *Client) Reembed(ctx context.Context, collection, newTier string, opts ReembedOptions) (*ReembedStats, error) {
+	collection = c.collection(collection)
+	if collection == "" {
+		return nil, errors.New("codex: reembed: no collection specified")
+	}
+	batch := opts.BatchSize
+	if batch <= 0 {
+		batch = 100
+	}
+	suffix := opts.ShadowSuffix
+	if suffix == "" {
+		suffix = "-reembed"
+	}
+	minRecall := opts.MinRecall
+	if minRecall == 0 {
+		minRecall = 0.8
+	}
+
+	// A derived client embeds on the new tier; everything else matches.
+	newModel, err := c.Clone(WithDefaultTier(newTier))
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &ReembedStats{Shadow: collection + suffix}
+	started := time.Now()
+	created := false
+	cursor := ""
+	for {
+		recs, next, err := c.Scroll(ctx, collection, cursor, batch)
+		if err != nil {
+			return stats, fmt.Errorf("codex: reembed: scroll: %w", err)
+		}
+		var texts []string
+		var keep []VectorRecord
+		for _, rec := range recs {
+			if rec.Document == "" {
+				continue // nothing to re-embed from
+			}
+			texts = append(texts, rec.Document)
+			keep = append(keep, rec)
+		}
+		if len(texts) > 0 {
+			vecs, err := newModel.EmbedBatch(ctx, texts)
+			if err != nil {
+				return stats, fmt.Errorf("codex: reembed: embed: %w", err)
+			}
+			if !created {
+				if err := c.CreateCollection(ctx, stats.Shadow, len(vecs[0])); err != nil {
+					var ae *APIError
+					if !errors.As(err, &ae) || ae.StatusCode != 409 {
+						return stats, fmt.Errorf("codex: reembed: create shadow: %w", err)
+					}
+				}
+				created = true
+			}
+			for i := range keep {
+				keep[i].Vector = vecs[i]
+			}
+			if err := c.UpsertBatch(ctx, stats.Shadow, keep); err != nil {
+				return stats, fmt.Errorf("codex: reembed: upsert shadow: %w", err)
+			}
+			stats.Documents += len(keep)
+			if opts.Progress != nil {
+				opts.Progress.Progress(Progress{Operation: "reembed", Done: stats.Documents, Started: started})
+			}
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	if len(opts.SampleQueries) > 0 {
+		recall, err := c.compareRecall(ctx, newModel, collection, stats.Shadow, opts.SampleQueries)
+		if err != nil {
+			return stats, err
+		}
+		stats.Recall = recall
+		if recall < minRecall {
+			return stats, fmt.Errorf("codex: reembed: recall %.2f below required %.2f; shadow %q kept for inspection",
+				recall, minRecall, stats.Shadow)
+		}
+	}
+
+	if err := c.SwapAlias(ctx, collection, stats.Shadow); err != nil {
+		return stats, fmt.Errorf("codex: reembed: swap alias: %w", err)
+	}
+	stats.Swapped = true
+	return stats, nil
+}
+
+// compareRecall runs each query against the old and new collections
+// (embedding with the respective model) and reports the fraction whose
+// top results agree.
+func (c *Client) compareRecall(ctx context.Context, newModel *Client, oldCol, newCol string, queries []string) (float64, error) {
+	agree := 0
+	for _, q := range queries {
+		oldVec, err := c.Embed(ctx, q)
+		if err != nil {
+			return 0, fmt.Errorf("codex: reembed: verify embed: %w", err)
+		}
+		newVec, err := newModel.Embed(ctx, q)
+		if err != nil {
+			return 0, fmt.Errorf("codex: reembed: verify embed: %w", err)
+		}
+		oldTop, err := c.Query(ctx, oldCol, oldVec, 1, nil)
+		if err != nil {
+			return 0, fmt.Errorf("codex: reembed: verify query: %w", err)
+		}
+		newTop, err := c.Query(ctx, newCol, newVec, 1, nil)
+		if err != nil {
+			return 0, fmt.Errorf("codex: reembed: verify query: %w", err)
+		}
+		if len(oldTop) > 0 && len(newTop) > 0 && oldTop[0].ID == newTop[0].ID {
+			agree++
+		}
+	}
+	return float64(agree) / float64(len(queries)), nil
+}
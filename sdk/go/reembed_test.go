@@ -0,0 +1,111 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestReembed(t *testing.T) {
+	records := []VectorRecord{
+		{ID: "a", Vector: []float32{1}, Document: "doc a"},
+		{ID: "b", Vector: []float32{2}, Document: "doc b"},
+	}
+	upserted := map[string]VectorRecord{}
+	swapped := ""
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/scroll"):
+			json.NewEncoder(w).Encode(scrollWire{Records: records})
+		case r.URL.Path == "/v1/embed":
+			var req embedRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			vecs := make([][]float32, len(req.Inputs))
+			for i := range vecs {
+				// The new tier produces wider vectors, so a copied
+				// vector would be caught immediately.
+				if req.Tier == "embed-v2" {
+					vecs[i] = []float32{1, 2}
+				} else {
+					vecs[i] = []float32{1}
+				}
+			}
+			json.NewEncoder(w).Encode(embedWire{Vectors: vecs})
+		case r.URL.Path == "/v1/collections" && r.Method == http.MethodPost:
+			var req createCollectionRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Name != "kb-reembed" || req.Dimensions != 2 {
+				t.Errorf("bad shadow collection: %+v", req)
+			}
+			w.WriteHeader(http.StatusCreated)
+		case strings.HasSuffix(r.URL.Path, "/vectors"):
+			var req upsertRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			for _, rec := range req.Records {
+				upserted[rec.ID] = rec
+			}
+		case strings.HasSuffix(r.URL.Path, "/query"):
+			// Both collections agree on the top result.
+			json.NewEncoder(w).Encode(queryWire{Matches: []Match{{VectorRecord: VectorRecord{ID: "a"}}}})
+		case strings.HasSuffix(r.URL.Path, "/alias"):
+			var req aliasRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			swapped = req.Target
+		default:
+			t.Errorf("unexpected call: %s", r.URL.Path)
+		}
+	}))
+
+	stats, err := c.Reembed(context.Background(), "kb", "embed-v2", ReembedOptions{
+		SampleQueries: []string{"what is a?"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Documents != 2 || !stats.Swapped || stats.Recall != 1 {
+		t.Fatalf("bad stats: %+v", stats)
+	}
+	if len(upserted["a"].Vector) != 2 {
+		t.Fatalf("vector not re-embedded: %+v", upserted["a"])
+	}
+	if swapped != "kb-reembed" {
+		t.Fatalf("alias swapped to %q", swapped)
+	}
+}
+
+func TestReembedLowRecallKeepsShadow(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/scroll"):
+			json.NewEncoder(w).Encode(scrollWire{Records: []VectorRecord{{ID: "a", Document: "doc a"}}})
+		case r.URL.Path == "/v1/embed":
+			json.NewEncoder(w).Encode(embedWire{Vectors: [][]float32{{1}}})
+		case r.URL.Path == "/v1/collections":
+			w.WriteHeader(http.StatusCreated)
+		case strings.HasSuffix(r.URL.Path, "/vectors"):
+		case strings.HasSuffix(r.URL.Path, "/query/") || strings.HasSuffix(r.URL.Path, "/query"):
+			// Old and new collections disagree on every query.
+			id := "old"
+			if strings.Contains(r.URL.Path, "-reembed") {
+				id = "new"
+			}
+			json.NewEncoder(w).Encode(queryWire{Matches: []Match{{VectorRecord: VectorRecord{ID: id}}}})
+		case strings.HasSuffix(r.URL.Path, "/alias"):
+			t.Error("alias must not be swapped on low recall")
+		default:
+			t.Errorf("unexpected call: %s", r.URL.Path)
+		}
+	}))
+
+	stats, err := c.Reembed(context.Background(), "kb", "embed-v2", ReembedOptions{
+		SampleQueries: []string{"q1", "q2"},
+	})
+	if err == nil {
+		t.Fatal("expected recall failure")
+	}
+	if stats.Swapped || stats.Recall != 0 {
+		t.Fatalf("bad stats: %+v", stats)
+	}
+}
@@ -0,0 +1,42 @@
+package codex
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+type requestIDKey struct{}
+
+// ContextWithRequestID attaches a caller-chosen request ID to ctx. Calls
+// made with the returned context send it as X-Request-ID instead of a
+// generated one, letting services reuse their own correlation IDs.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID attached with
+// ContextWithRequestID, if any.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+// newRequestID generates a fresh correlation ID.
+func newRequestID() string {
+	var b [12]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "req_0"
+	}
+	return "req_" + hex.EncodeToString(b[:])
+}
+
+// requestID resolves the ID to send for a call: the context-provided one
+// or a freshly generated one. The same ID is reused across retries of one
+// logical call so server logs group the attempts.
+func requestID(ctx context.Context) string {
+	if id, ok := RequestIDFromContext(ctx); ok && id != "" {
+		return id
+	}
+	return newRequestID()
+}
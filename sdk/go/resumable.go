@@ -0,0 +1,148 @@
+package codex
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ResumableUpload is an in-progress chunked upload. Uploads survive
+// disconnects: re-create the handle with ResumeUpload and call Send
+// again, and it continues from the server's confirmed offset instead of
+// from zero.
+type ResumableUpload struct {
+	// ID identifies the upload on the server; persist it to resume
+	// across process restarts.
+	ID string
+	// Offset is how many bytes the server has confirmed.
+	Offset int64
+	// ChunkSize is the per-request chunk size. Defaults to 8 MiB.
+	ChunkSize int64
+
+	c *Client
+}
+
+type createUploadRequest struct {
+	Filename string `json:"filename"`
+	Size     int64  `json:"size"`
+}
+
+type uploadWire struct {
+	ID     string `json:"id"`
+	Offset int64  `json:"offset"`
+}
+
+// CreateUpload registers a resumable upload of size bytes and returns
+// its handle. Send the bytes with Send, then finish with Complete.
+func (c *Client) CreateUpload(ctx context.Context, filename string, size int64) (*ResumableUpload, error) {
+	var resp uploadWire
+	if err := c.do(ctx, http.MethodPost, "/v1/uploads", createUploadRequest{Filename: filename, Size: size}, &resp); err != nil {
+		return nil, err
+	}
+	return &ResumableUpload{ID: resp.ID, c: c}, nil
+}
+
+// ResumeUpload reattaches to an earlier upload by ID, asking the server
+// how far it got.
+func (c *Client) ResumeUpload(ctx context.Context, id string) (*ResumableUpload, error) {
+	u := &ResumableUpload{ID: id, c: c}
+	if err := u.sync(ctx); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// sync refreshes Offset from the server.
+func (u *ResumableUpload) sync(ctx context.Context) error {
+	var resp uploadWire
+	if err := u.c.do(ctx, http.MethodGet, "/v1/uploads/"+url.PathEscape(u.ID), nil, &resp); err != nil {
+		return err
+	}
+	u.Offset = resp.Offset
+	return nil
+}
+
+// Send uploads r chunk by chunk from the current offset. Each chunk
+// carries its SHA-256 so corruption is caught per chunk, and an offset
+// conflict (another writer, or a chunk the server already has) re-syncs
+// and continues rather than failing. r must support seeking so resumed
+// uploads can skip what the server already holds.
+func (u *ResumableUpload) Send(ctx context.Context, r io.ReadSeeker) error {
+	size := u.ChunkSize
+	if size <= 0 {
+		size = 8 << 20
+	}
+	buf := make([]byte, size)
+	for {
+		if _, err := r.Seek(u.Offset, io.SeekStart); err != nil {
+			return fmt.Errorf("codex: seek to upload offset: %w", err)
+		}
+		n, err := io.ReadFull(r, buf)
+		if err == io.EOF {
+			return nil // everything confirmed
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return fmt.Errorf("codex: read upload chunk: %w", err)
+		}
+		if err := u.sendChunk(ctx, buf[:n]); err != nil {
+			var ae *APIError
+			if errors.As(err, &ae) && ae.StatusCode == http.StatusConflict {
+				// Offset drifted; trust the server and continue from its.
+				if err := u.sync(ctx); err != nil {
+					return err
+				}
+				continue
+			}
+			return err
+		}
+	}
+}
+
+// sendChunk PATCHes one chunk at the current offset and advances it.
+func (u *ResumableUpload) sendChunk(ctx context.Context, chunk []byte) error {
+	sum := sha256.Sum256(chunk)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch,
+		u.c.cfg.baseURL+"/v1/uploads/"+url.PathEscape(u.ID), bytes.NewReader(chunk))
+	if err != nil {
+		return fmt.Errorf("codex: build request: %w", err)
+	}
+	u.c.setHeaders(req)
+	req.Header.Set("Content-Type", "application/offset+octet-stream")
+	req.Header.Set("X-Upload-Offset", strconv.FormatInt(u.Offset, 10))
+	req.Header.Set("X-Chunk-SHA256", hex.EncodeToString(sum[:]))
+	resp, err := u.c.httpc.Do(req)
+	if err != nil {
+		return fmt.Errorf("codex: PATCH /v1/uploads/%s: %w", u.ID, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errorFromResponse(resp)
+	}
+	io.Copy(io.Discard, resp.Body)
+	u.Offset += int64(len(chunk))
+	return nil
+}
+
+// Complete finalises the upload and hands the file to server-side
+// ingestion, returning the same job handle as UploadAndIngest. The
+// options mirror UploadAndIngest's.
+func (u *ResumableUpload) Complete(ctx context.Context, opts UploadOptions) (*Operation[IngestReport], error) {
+	body := struct {
+		Collection string         `json:"collection,omitempty"`
+		Metadata   map[string]any `json:"metadata,omitempty"`
+	}{Collection: u.c.collection(opts.Collection), Metadata: opts.Metadata}
+	var resp struct {
+		OperationID string `json:"operation_id"`
+	}
+	if err := u.c.do(ctx, http.MethodPost, "/v1/uploads/"+url.PathEscape(u.ID)+"/complete", body, &resp); err != nil {
+		return nil, err
+	}
+	return NewOperation[IngestReport](u.c, resp.OperationID, 0), nil
+}
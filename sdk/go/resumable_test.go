@@ -0,0 +1,113 @@
+package codex
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// uploadServer holds one upload's state across requests.
+type uploadServer struct {
+	t        *testing.T
+	received bytes.Buffer
+	dropped  bool
+}
+
+func (s *uploadServer) handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/uploads":
+			json.NewEncoder(w).Encode(uploadWire{ID: "up-1"})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/uploads/up-1":
+			json.NewEncoder(w).Encode(uploadWire{ID: "up-1", Offset: int64(s.received.Len())})
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/uploads/up-1":
+			body, _ := io.ReadAll(r.Body)
+			sum := sha256.Sum256(body)
+			if r.Header.Get("X-Chunk-SHA256") != hex.EncodeToString(sum[:]) {
+				s.t.Error("chunk checksum mismatch")
+			}
+			offset, _ := strconv.ParseInt(r.Header.Get("X-Upload-Offset"), 10, 64)
+			if offset != int64(s.received.Len()) {
+				w.WriteHeader(http.StatusConflict)
+				json.NewEncoder(w).Encode(APIError{Message: "offset mismatch"})
+				return
+			}
+			// Accept the chunk but "drop the connection" once by
+			// answering 409 after storing it, forcing a re-sync.
+			s.received.Write(body)
+			if !s.dropped {
+				s.dropped = true
+				w.WriteHeader(http.StatusConflict)
+				json.NewEncoder(w).Encode(APIError{Message: "please re-sync"})
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/uploads/up-1/complete":
+			json.NewEncoder(w).Encode(map[string]string{"operation_id": "ing-9"})
+		case r.URL.Path == "/v1/operations/ing-9":
+			json.NewEncoder(w).Encode(map[string]any{
+				"id": "ing-9", "status": "succeeded", "result": IngestReport{Documents: 1, Chunks: 3},
+			})
+		default:
+			s.t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+	})
+}
+
+func TestResumableUploadSurvivesOffsetDrift(t *testing.T) {
+	srv := &uploadServer{t: t}
+	c := newTestClient(t, srv.handler())
+	content := strings.Repeat("0123456789", 10)
+
+	up, err := c.CreateUpload(context.Background(), "big.log", int64(len(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	up.ChunkSize = 32
+	if err := up.Send(context.Background(), strings.NewReader(content)); err != nil {
+		t.Fatal(err)
+	}
+	if srv.received.String() != content {
+		t.Fatalf("server has %d bytes, want %d", srv.received.Len(), len(content))
+	}
+
+	job, err := up.Complete(context.Background(), UploadOptions{Collection: "kb"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := job.Wait(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Chunks != 3 {
+		t.Fatalf("report: %+v", report)
+	}
+}
+
+func TestResumeUploadPicksUpOffset(t *testing.T) {
+	srv := &uploadServer{t: t, dropped: true}
+	srv.received.WriteString("already here ")
+	c := newTestClient(t, srv.handler())
+
+	up, err := c.ResumeUpload(context.Background(), "up-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if up.Offset != int64(len("already here ")) {
+		t.Fatalf("offset = %d", up.Offset)
+	}
+	content := "already here and the rest"
+	if err := up.Send(context.Background(), strings.NewReader(content)); err != nil {
+		t.Fatal(err)
+	}
+	if srv.received.String() != content {
+		t.Fatalf("server has %q", srv.received.String())
+	}
+}
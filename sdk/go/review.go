@@ -0,0 +1,82 @@
+package codex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ReviewItem is a model output sitting in the human review queue.
+type ReviewItem struct {
+	ID string `json:"id,omitempty"`
+	// RequestID links back to the original request that produced the
+	// output.
+	RequestID string `json:"request_id,omitempty"`
+	Question  string `json:"question,omitempty"`
+	Answer    string `json:"answer"`
+	// Reason says why the item was enqueued, e.g. "low_confidence",
+	// "user_flagged", "safety_filter".
+	Reason     string    `json:"reason,omitempty"`
+	EnqueuedAt time.Time `json:"enqueued_at,omitempty"`
+}
+
+// Label is a reviewer's verdict on a queued item.
+type Label struct {
+	// Verdict is the reviewer's call, e.g. "approve" or "reject".
+	Verdict string `json:"verdict"`
+	// Corrected is the answer as it should have been, for training data.
+	Corrected string   `json:"corrected,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Notes     string   `json:"notes,omitempty"`
+}
+
+type queueReviewWire struct {
+	ID string `json:"id"`
+}
+
+// QueueForReview enqueues a model output for human review and returns
+// the queue item's ID.
+func (c *Client) QueueForReview(ctx context.Context, item ReviewItem) (string, error) {
+	if item.Answer == "" {
+		return "", errors.New("codex: review item needs an answer")
+	}
+	var resp queueReviewWire
+	if err := c.do(ctx, http.MethodPost, "/v1/review/queue", item, &resp); err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+type pendingWire struct {
+	Items []ReviewItem `json:"items"`
+}
+
+// ListPending returns up to limit items awaiting review, oldest first;
+// limit <= 0 leaves the page size to the server.
+func (c *Client) ListPending(ctx context.Context, limit int) ([]ReviewItem, error) {
+	path := "/v1/review/pending"
+	if limit > 0 {
+		path += "?limit=" + strconv.Itoa(limit)
+	}
+	var resp pendingWire
+	if err := c.do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// SubmitLabel records a reviewer's label for a queued item, which
+// removes it from the pending queue.
+func (c *Client) SubmitLabel(ctx context.Context, itemID string, label Label) error {
+	if itemID == "" {
+		return errors.New("codex: label needs a review item ID")
+	}
+	if label.Verdict == "" {
+		return errors.New("codex: label needs a verdict")
+	}
+	path := "/v1/review/" + url.PathEscape(itemID) + "/label"
+	return c.do(ctx, http.MethodPost, path, label, nil)
+}
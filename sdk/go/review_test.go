@@ -0,0 +1,55 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestReviewQueueFlow(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/review/queue":
+			var item ReviewItem
+			json.NewDecoder(r.Body).Decode(&item)
+			if item.Answer != "maybe 41" || item.Reason != "low_confidence" {
+				t.Errorf("item: %+v", item)
+			}
+			json.NewEncoder(w).Encode(queueReviewWire{ID: "rev-1"})
+		case "/v1/review/pending":
+			if r.URL.Query().Get("limit") != "10" {
+				t.Errorf("limit: %q", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode(pendingWire{Items: []ReviewItem{{ID: "rev-1", Answer: "maybe 41"}}})
+		case "/v1/review/rev-1/label":
+			var label Label
+			json.NewDecoder(r.Body).Decode(&label)
+			if label.Verdict != "reject" || label.Corrected != "42" {
+				t.Errorf("label: %+v", label)
+			}
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+
+	ctx := context.Background()
+	id, err := c.QueueForReview(ctx, ReviewItem{Answer: "maybe 41", Reason: "low_confidence"})
+	if err != nil || id != "rev-1" {
+		t.Fatalf("id %q, err %v", id, err)
+	}
+	items, err := c.ListPending(ctx, 10)
+	if err != nil || len(items) != 1 || items[0].ID != "rev-1" {
+		t.Fatalf("pending: %v, err %v", items, err)
+	}
+	if err := c.SubmitLabel(ctx, "rev-1", Label{Verdict: "reject", Corrected: "42"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.QueueForReview(ctx, ReviewItem{}); err == nil {
+		t.Fatal("expected error for empty answer")
+	}
+	if err := c.SubmitLabel(ctx, "rev-1", Label{}); err == nil {
+		t.Fatal("expected error for missing verdict")
+	}
+}
@@ -0,0 +1,123 @@
+package codex
+
+import (
+	"strconv"
+	"strings"
+)
+
+// TierAuto, used as a tier value (WithTier(TierAuto) or the client
+// default), asks the configured TierRouter to pick the tier per request.
+const TierAuto = "auto"
+
+// RouterPolicy biases which tier the router picks for a given
+// complexity.
+type RouterPolicy int
+
+const (
+	// RouteBalanced maps simple prompts to the cheap tier, moderate
+	// ones to standard, and complex ones to premium.
+	RouteBalanced RouterPolicy = iota
+	// RouteCostSaver shifts every decision one tier down; premium is
+	// never chosen.
+	RouteCostSaver
+	// RouteQuality shifts every decision one tier up; cheap is never
+	// chosen.
+	RouteQuality
+)
+
+// TierRouter picks a tier per request from the prompt's apparent
+// complexity — length, presence of code, and reasoning demands — under a
+// cost/latency policy. Wire it up with WithTierRouter and request
+// routing with TierAuto.
+type TierRouter struct {
+	// Cheap, Standard, Premium name the tiers in ascending capability.
+	Cheap    string
+	Standard string
+	Premium  string
+	Policy   RouterPolicy
+	// Logf, when set, receives one line per routing decision.
+	Logf func(format string, args ...any)
+}
+
+// WithTierRouter installs a router consulted whenever a call's tier
+// resolves to TierAuto.
+func WithTierRouter(r *TierRouter) Option {
+	return func(c *clientConfig) { c.router = r }
+}
+
+// Route picks a tier for messages and reports why.
+func (r *TierRouter) Route(messages []Message) string {
+	level, reason := promptComplexity(messages)
+	switch r.Policy {
+	case RouteCostSaver:
+		if level > 0 {
+			level--
+		}
+	case RouteQuality:
+		if level < 2 {
+			level++
+		}
+	}
+	tier := [...]string{r.Cheap, r.Standard, r.Premium}[level]
+	if r.Logf != nil {
+		r.Logf("codex: routed to tier %q (%s)", tier, reason)
+	}
+	return tier
+}
+
+var reasoningMarkers = []string{
+	"why ", "explain", "prove", "step by step", "analyze", "compare",
+	"reason", "derive", "trade-off", "tradeoff",
+}
+
+var codeMarkers = []string{"```", "func ", "def ", "class ", "#include", "SELECT "}
+
+// promptComplexity scores messages 0 (simple), 1 (moderate), or
+// 2 (complex) and explains the verdict.
+func promptComplexity(messages []Message) (int, string) {
+	tokens := EstimateMessageTokens(messages)
+	var text strings.Builder
+	for _, m := range messages {
+		text.WriteString(m.Content)
+		text.WriteByte('\n')
+	}
+	lower := strings.ToLower(text.String())
+
+	var hasCode bool
+	for _, marker := range codeMarkers {
+		if strings.Contains(text.String(), marker) {
+			hasCode = true
+			break
+		}
+	}
+	var needsReasoning bool
+	for _, marker := range reasoningMarkers {
+		if strings.Contains(lower, marker) {
+			needsReasoning = true
+			break
+		}
+	}
+
+	switch {
+	case needsReasoning || tokens > 2000:
+		return 2, reasonString(tokens, hasCode, needsReasoning)
+	case hasCode || tokens > 500:
+		return 1, reasonString(tokens, hasCode, needsReasoning)
+	default:
+		return 0, reasonString(tokens, hasCode, needsReasoning)
+	}
+}
+
+func reasonString(tokens int, hasCode, needsReasoning bool) string {
+	var parts []string
+	if needsReasoning {
+		parts = append(parts, "reasoning prompt")
+	}
+	if hasCode {
+		parts = append(parts, "contains code")
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "plain prompt")
+	}
+	return strings.Join(parts, ", ") + ", ~" + strconv.Itoa(tokens) + " tokens"
+}
@@ -0,0 +1,70 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func testRouter() *TierRouter {
+	return &TierRouter{Cheap: "cheap", Standard: "standard", Premium: "premium"}
+}
+
+func TestTierRouterRoute(t *testing.T) {
+	r := testRouter()
+	cases := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"short plain prompt", "what time is it?", "cheap"},
+		{"code prompt", "fix this:\n```go\nfunc main() {}\n```", "standard"},
+		{"reasoning prompt", "explain the trade-offs between these designs", "premium"},
+		{"long prompt", strings.Repeat("context ", 1200), "premium"},
+	}
+	for _, tc := range cases {
+		got := r.Route([]Message{{Role: RoleUser, Content: tc.content}})
+		if got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestTierRouterPolicies(t *testing.T) {
+	msgs := []Message{{Role: RoleUser, Content: "fix this:\n```go\nfunc main() {}\n```"}}
+	saver := testRouter()
+	saver.Policy = RouteCostSaver
+	if got := saver.Route(msgs); got != "cheap" {
+		t.Fatalf("cost saver: %q", got)
+	}
+	quality := testRouter()
+	quality.Policy = RouteQuality
+	if got := quality.Route(msgs); got != "premium" {
+		t.Fatalf("quality: %q", got)
+	}
+}
+
+func TestAutoTierResolvesThroughRouter(t *testing.T) {
+	var gotTier string
+	var logged []string
+	r := testRouter()
+	r.Logf = func(format string, args ...any) { logged = append(logged, format) }
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body chatRequest
+		json.NewDecoder(req.Body).Decode(&body)
+		gotTier = body.Tier
+		json.NewEncoder(w).Encode(ChatResponse{Reply: "ok", FinishReason: "stop"})
+	}), WithTierRouter(r), WithDefaultTier(TierAuto))
+
+	if _, err := c.Chat(context.Background(), []Message{{Role: RoleUser, Content: "hi"}}); err != nil {
+		t.Fatal(err)
+	}
+	if gotTier != "cheap" {
+		t.Fatalf("auto tier not routed: %q", gotTier)
+	}
+	if len(logged) != 1 {
+		t.Fatalf("decision not logged: %v", logged)
+	}
+}
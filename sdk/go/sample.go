@@ -0,0 +1,32 @@
+package codex
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type sampleRequest struct {
+	N      int    `json:"n"`
+	Filter Filter `json:"filter,omitempty"`
+}
+
+type sampleWire struct {
+	Records []VectorRecord `json:"records"`
+}
+
+// Sample returns n records drawn uniformly at random from collection,
+// optionally restricted by filter (may be nil), so reviewers can
+// spot-check chunking and metadata without exporting everything.
+// Collections smaller than n return every matching record.
+func (c *Client) Sample(ctx context.Context, collection string, n int, filter Filter) ([]VectorRecord, error) {
+	path := "/v1/collections/" + url.PathEscape(c.collection(collection)) + "/sample"
+	var resp sampleWire
+	if err := c.do(ctx, http.MethodPost, path, sampleRequest{N: n, Filter: filter}, &resp); err != nil {
+		return nil, err
+	}
+	if err := c.decryptRecords(resp.Records); err != nil {
+		return nil, err
+	}
+	return resp.Records, nil
+}
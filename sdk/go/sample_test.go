@@ -0,0 +1,31 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestSample(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/collections/kb/sample" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		var req sampleRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.N != 3 || req.Filter["lang"] != "en" {
+			t.Errorf("request: %+v", req)
+		}
+		json.NewEncoder(w).Encode(sampleWire{Records: []VectorRecord{
+			{ID: "a"}, {ID: "b"}, {ID: "c"},
+		}})
+	}))
+	recs, err := c.Sample(context.Background(), "kb", 3, Filter{"lang": "en"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 3 || recs[2].ID != "c" {
+		t.Fatalf("records: %+v", recs)
+	}
+}
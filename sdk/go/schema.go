@@ -0,0 +1,153 @@
+package codex
+
+import (
+	"fmt"
+	"time"
+)
+
+// FieldType enumerates the value types a metadata field may hold.
+type FieldType string
+
+const (
+	FieldString FieldType = "string"
+	FieldNumber FieldType = "number"
+	FieldBool   FieldType = "bool"
+	// FieldTime values are stored as RFC 3339 strings on the wire.
+	FieldTime FieldType = "time"
+)
+
+// MetadataField declares one field of a collection's metadata schema.
+type MetadataField struct {
+	Name     string    `json:"name"`
+	Type     FieldType `json:"type"`
+	Indexed  bool      `json:"indexed,omitempty"`
+	Required bool      `json:"required,omitempty"`
+}
+
+// MetadataSchema declares the metadata fields a collection accepts. A
+// schema is advisory on the server but enforced client-side: upserts into
+// a collection with a registered schema fail fast on missing required
+// fields, unknown fields, or type mismatches.
+type MetadataSchema []MetadataField
+
+// Validate checks rec's metadata against the schema.
+func (s MetadataSchema) Validate(rec VectorRecord) error {
+	byName := make(map[string]MetadataField, len(s))
+	for _, f := range s {
+		byName[f.Name] = f
+		if f.Required {
+			if _, ok := rec.Metadata[f.Name]; !ok {
+				return fmt.Errorf("codex: record %s: missing required metadata field %q", rec.ID, f.Name)
+			}
+		}
+	}
+	for name, value := range rec.Metadata {
+		field, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("codex: record %s: metadata field %q is not in the collection schema", rec.ID, name)
+		}
+		if err := checkFieldType(field.Type, value); err != nil {
+			return fmt.Errorf("codex: record %s: metadata field %q: %w", rec.ID, name, err)
+		}
+	}
+	return nil
+}
+
+func checkFieldType(ft FieldType, value any) error {
+	switch ft {
+	case FieldString:
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("want string, got %T", value)
+		}
+	case FieldNumber:
+		if _, ok := asNumber(value); !ok {
+			return fmt.Errorf("want number, got %T", value)
+		}
+	case FieldBool:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("want bool, got %T", value)
+		}
+	case FieldTime:
+		switch v := value.(type) {
+		case time.Time:
+		case string:
+			if _, err := time.Parse(time.RFC3339, v); err != nil {
+				return fmt.Errorf("want RFC 3339 timestamp: %w", err)
+			}
+		default:
+			return fmt.Errorf("want time.Time or RFC 3339 string, got %T", value)
+		}
+	default:
+		return fmt.Errorf("unknown field type %q", ft)
+	}
+	return nil
+}
+
+// asNumber widens the numeric types callers plausibly put in metadata to
+// float64, which is also what JSON decoding produces.
+func asNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// StringField returns the named metadata field as a string.
+func (r VectorRecord) StringField(name string) (string, bool) {
+	s, ok := r.Metadata[name].(string)
+	return s, ok
+}
+
+// NumberField returns the named metadata field as a float64, widening
+// any numeric type it finds.
+func (r VectorRecord) NumberField(name string) (float64, bool) {
+	return asNumber(r.Metadata[name])
+}
+
+// BoolField returns the named metadata field as a bool.
+func (r VectorRecord) BoolField(name string) (bool, bool) {
+	b, ok := r.Metadata[name].(bool)
+	return b, ok
+}
+
+// TimeField returns the named metadata field as a time.Time, parsing the
+// RFC 3339 wire form if needed.
+func (r VectorRecord) TimeField(name string) (time.Time, bool) {
+	switch v := r.Metadata[name].(type) {
+	case time.Time:
+		return v, true
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// WithMetadataSchema registers a schema for collection, enabling
+// client-side validation of upserts into it. Repeat for each collection.
+func WithMetadataSchema(collection string, schema MetadataSchema) Option {
+	return func(c *clientConfig) {
+		if c.schemas == nil {
+			c.schemas = map[string]MetadataSchema{}
+		}
+		c.schemas[collection] = schema
+	}
+}
+
+// schema returns the registered schema for collection, if any.
+func (c *Client) schema(collection string) (MetadataSchema, bool) {
+	if v, ok := c.schemas.Load(collection); ok {
+		return v.(MetadataSchema), true
+	}
+	return nil, false
+}
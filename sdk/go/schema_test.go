@@ -0,0 +1,90 @@
+package codex
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+var articleSchema = MetadataSchema{
+	{Name: "author", Type: FieldString, Required: true},
+	{Name: "views", Type: FieldNumber, Indexed: true},
+	{Name: "published", Type: FieldTime},
+}
+
+func TestMetadataSchemaValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		meta    map[string]any
+		wantErr string
+	}{
+		{"valid", map[string]any{"author": "alice", "views": 7, "published": time.Now()}, ""},
+		{"missing required", map[string]any{"views": 7}, "missing required"},
+		{"unknown field", map[string]any{"author": "alice", "tags": "x"}, "not in the collection schema"},
+		{"wrong type", map[string]any{"author": 42}, "want string"},
+		{"time as string", map[string]any{"author": "a", "published": "2026-08-30T12:00:00Z"}, ""},
+		{"bad time string", map[string]any{"author": "a", "published": "yesterday"}, "RFC 3339"},
+	}
+	for _, tc := range cases {
+		err := articleSchema.Validate(VectorRecord{ID: "r", Metadata: tc.meta})
+		if tc.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tc.name, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("%s: got %v, want error containing %q", tc.name, err, tc.wantErr)
+		}
+	}
+}
+
+func TestUpsertValidatesRegisteredSchema(t *testing.T) {
+	var hits int
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+	}), WithMetadataSchema("articles", articleSchema))
+
+	err := c.Upsert(context.Background(), "articles", VectorRecord{
+		ID:       "bad",
+		Metadata: map[string]any{"views": "many"},
+	})
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	if hits != 0 {
+		t.Fatalf("invalid record reached the server (%d requests)", hits)
+	}
+	err = c.Upsert(context.Background(), "articles", VectorRecord{
+		ID:       "good",
+		Metadata: map[string]any{"author": "alice", "views": 3},
+	})
+	if err != nil || hits != 1 {
+		t.Fatalf("valid upsert: err=%v hits=%d", err, hits)
+	}
+}
+
+func TestTypedAccessors(t *testing.T) {
+	rec := VectorRecord{Metadata: map[string]any{
+		"author":    "alice",
+		"views":     float64(12), // as JSON decoding produces
+		"draft":     true,
+		"published": "2026-08-30T12:00:00Z",
+	}}
+	if s, ok := rec.StringField("author"); !ok || s != "alice" {
+		t.Fatalf("StringField: %q %v", s, ok)
+	}
+	if n, ok := rec.NumberField("views"); !ok || n != 12 {
+		t.Fatalf("NumberField: %v %v", n, ok)
+	}
+	if b, ok := rec.BoolField("draft"); !ok || !b {
+		t.Fatalf("BoolField: %v %v", b, ok)
+	}
+	ts, ok := rec.TimeField("published")
+	if !ok || ts.UTC().Hour() != 12 {
+		t.Fatalf("TimeField: %v %v", ts, ok)
+	}
+	if _, ok := rec.StringField("missing"); ok {
+		t.Fatal("missing field should not be ok")
+	}
+}
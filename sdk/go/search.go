@@ -0,0 +1,31 @@
+package codex
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type searchRequest struct {
+	Query  string `json:"query"`
+	TopK   int    `json:"top_k"`
+	Filter Filter `json:"filter,omitempty"`
+}
+
+// SearchText runs a lexical (BM25) search over collection, for queries
+// where exact term matching matters — error codes, SKUs, identifiers —
+// and embeddings would blur the terms away. Results share the Match
+// type with Query, so downstream assembly code works unchanged;
+// scores are BM25 scores, not cosine similarities, and are not
+// comparable across the two.
+func (c *Client) SearchText(ctx context.Context, collection, query string, topK int, filter Filter) ([]Match, error) {
+	path := "/v1/collections/" + url.PathEscape(c.collection(collection)) + "/search"
+	var resp queryWire
+	if err := c.do(ctx, http.MethodPost, path, searchRequest{Query: query, TopK: topK, Filter: filter}, &resp); err != nil {
+		return nil, err
+	}
+	if err := c.decryptMatches(resp.Matches); err != nil {
+		return nil, err
+	}
+	return resp.Matches, nil
+}
@@ -0,0 +1,34 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestSearchText(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/collections/kb/search" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		var req searchRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Query != "ERR_CONN_RESET" || req.TopK != 5 {
+			t.Errorf("request: %+v", req)
+		}
+		if req.Filter["source"] != "runbooks" {
+			t.Errorf("filter: %+v", req.Filter)
+		}
+		json.NewEncoder(w).Encode(queryWire{Matches: []Match{
+			{VectorRecord: VectorRecord{ID: "doc#1", Document: "ERR_CONN_RESET means..."}, Score: 12.4},
+		}})
+	}))
+	matches, err := c.SearchText(context.Background(), "kb", "ERR_CONN_RESET", 5, Filter{"source": "runbooks"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].ID != "doc#1" {
+		t.Fatalf("matches: %+v", matches)
+	}
+}
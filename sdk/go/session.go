@@ -0,0 +1,209 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Session is a stored login obtained through the device-code flow. The
+// access token authenticates requests like an API key; the refresh token
+// lets an expired session be renewed without re-prompting the user.
+type Session struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at,omitempty"`
+	// Server records which base URL the session was issued for, so a
+	// stored session is not replayed against a different server.
+	Server string `json:"server,omitempty"`
+}
+
+// Expired reports whether the session's access token has expired (with a
+// small margin so a token is not used in its final seconds). Sessions
+// without an expiry never report expired.
+func (s *Session) Expired() bool {
+	if s.ExpiresAt.IsZero() {
+		return false
+	}
+	return time.Now().Add(30 * time.Second).After(s.ExpiresAt)
+}
+
+// DeviceAuth is an in-progress device-code login: the user visits
+// VerificationURI and enters UserCode while the client polls with
+// DeviceCode until the server reports approval.
+type DeviceAuth struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	// Interval is the polling cadence in seconds (0 means 5).
+	Interval int `json:"interval,omitempty"`
+	// ExpiresIn bounds how long the codes stay valid, in seconds.
+	ExpiresIn int `json:"expires_in,omitempty"`
+}
+
+type deviceTokenRequest struct {
+	DeviceCode string `json:"device_code"`
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// sessionWire is the token endpoint response, OAuth-style: a relative
+// expiry rather than a timestamp.
+type sessionWire struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+func (w *sessionWire) session(server string) *Session {
+	s := &Session{AccessToken: w.AccessToken, RefreshToken: w.RefreshToken, Server: server}
+	if w.ExpiresIn > 0 {
+		s.ExpiresAt = time.Now().Add(time.Duration(w.ExpiresIn) * time.Second)
+	}
+	return s
+}
+
+// StartDeviceLogin begins a device-code login. Display the returned
+// verification URI and user code, then call WaitDeviceLogin to block
+// until the user approves.
+func (c *Client) StartDeviceLogin(ctx context.Context) (*DeviceAuth, error) {
+	var auth DeviceAuth
+	if err := c.do(ctx, "POST", "/v1/auth/device", nil, &auth); err != nil {
+		return nil, err
+	}
+	return &auth, nil
+}
+
+// WaitDeviceLogin polls the token endpoint until the user approves the
+// device code, the codes expire, or ctx is done. It honors the server's
+// polling interval, backing off further when told to slow down.
+func (c *Client) WaitDeviceLogin(ctx context.Context, auth *DeviceAuth) (*Session, error) {
+	interval := time.Duration(auth.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	if auth.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(auth.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+	for {
+		var wire sessionWire
+		err := c.do(ctx, "POST", "/v1/auth/token", deviceTokenRequest{DeviceCode: auth.DeviceCode}, &wire)
+		if err == nil {
+			return wire.session(c.cfg.baseURL), nil
+		}
+		var ae *APIError
+		if !errors.As(err, &ae) {
+			return nil, err
+		}
+		switch ae.Code {
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			return nil, err
+		}
+		t := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// RefreshSession exchanges the session's refresh token for a fresh access
+// token. The returned session keeps the old refresh token when the server
+// does not rotate it.
+func (c *Client) RefreshSession(ctx context.Context, s *Session) (*Session, error) {
+	if s.RefreshToken == "" {
+		return nil, errors.New("codex: session has no refresh token")
+	}
+	var wire sessionWire
+	if err := c.do(ctx, "POST", "/v1/auth/refresh", refreshRequest{RefreshToken: s.RefreshToken}, &wire); err != nil {
+		return nil, err
+	}
+	next := wire.session(s.Server)
+	if next.RefreshToken == "" {
+		next.RefreshToken = s.RefreshToken
+	}
+	return next, nil
+}
+
+// DefaultSessionPath returns where sessions are stored: the file named by
+// CODEX_SESSION_FILE when set, otherwise codex/session.json under the
+// user config directory.
+func DefaultSessionPath() (string, error) {
+	if path := os.Getenv("CODEX_SESSION_FILE"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("codex: session path: %w", err)
+	}
+	return filepath.Join(dir, "codex", "session.json"), nil
+}
+
+// SaveSession writes the session to path, creating parent directories and
+// keeping the file readable only by the current user.
+func SaveSession(path string, s *Session) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("codex: save session: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("codex: save session: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("codex: save session: %w", err)
+	}
+	return nil
+}
+
+// LoadSession reads a session previously written by SaveSession. A
+// missing file yields an error satisfying errors.Is(err, os.ErrNotExist).
+func LoadSession(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("codex: load session %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// pickUpStoredSession is the automatic-login half of WithStoredSession:
+// it loads the stored session and, when the access token has expired,
+// renews it through the refresh token and writes the result back. All
+// failures degrade to an unauthenticated client — a stale or corrupt
+// session file should never stop construction.
+func (c *Client) pickUpStoredSession() {
+	path, err := DefaultSessionPath()
+	if err != nil {
+		return
+	}
+	s, err := LoadSession(path)
+	if err != nil || s.AccessToken == "" {
+		return
+	}
+	if s.Server != "" && s.Server != c.cfg.baseURL {
+		return
+	}
+	if s.Expired() {
+		if s, err = c.RefreshSession(context.Background(), s); err != nil {
+			return
+		}
+		SaveSession(path, s)
+	}
+	c.cfg.apiKey = s.AccessToken
+}
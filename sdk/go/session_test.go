@@ -0,0 +1,185 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDeviceLoginFlow(t *testing.T) {
+	polls := 0
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/device":
+			json.NewEncoder(w).Encode(DeviceAuth{
+				DeviceCode:      "dev-1",
+				UserCode:        "WXYZ-1234",
+				VerificationURI: "https://example.com/activate",
+				Interval:        1,
+				ExpiresIn:       60,
+			})
+		case "/v1/auth/token":
+			var req deviceTokenRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.DeviceCode != "dev-1" {
+				t.Errorf("device code = %q", req.DeviceCode)
+			}
+			polls++
+			if polls == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":{"code":"authorization_pending","message":"pending"}}`))
+				return
+			}
+			json.NewEncoder(w).Encode(sessionWire{
+				AccessToken:  "at-1",
+				RefreshToken: "rt-1",
+				ExpiresIn:    3600,
+			})
+		default:
+			t.Errorf("path = %s", r.URL.Path)
+		}
+	}))
+	auth, err := c.StartDeviceLogin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if auth.UserCode != "WXYZ-1234" {
+		t.Fatalf("auth: %+v", auth)
+	}
+	sess, err := c.WaitDeviceLogin(context.Background(), auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sess.AccessToken != "at-1" || sess.RefreshToken != "rt-1" {
+		t.Fatalf("session: %+v", sess)
+	}
+	if sess.Expired() {
+		t.Error("fresh session reports expired")
+	}
+	if polls != 2 {
+		t.Errorf("polls = %d", polls)
+	}
+}
+
+func TestWaitDeviceLoginStopsOnDenial(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error":{"code":"access_denied","message":"denied"}}`))
+	}))
+	_, err := c.WaitDeviceLogin(context.Background(), &DeviceAuth{DeviceCode: "dev-1", Interval: 1})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestSessionRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "session.json")
+	want := &Session{
+		AccessToken:  "at-1",
+		RefreshToken: "rt-1",
+		ExpiresAt:    time.Now().Add(time.Hour).UTC().Truncate(time.Second),
+		Server:       "https://codex.internal:8080",
+	}
+	if err := SaveSession(path, want); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("session file mode = %o", perm)
+	}
+	got, err := LoadSession(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.AccessToken != want.AccessToken || got.RefreshToken != want.RefreshToken ||
+		!got.ExpiresAt.Equal(want.ExpiresAt) || got.Server != want.Server {
+		t.Fatalf("loaded %+v, want %+v", got, want)
+	}
+}
+
+func TestStoredSessionPickup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	t.Setenv("CODEX_SESSION_FILE", path)
+	if err := SaveSession(path, &Session{AccessToken: "at-stored"}); err != nil {
+		t.Fatal(err)
+	}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer at-stored" {
+			t.Errorf("authorization = %q", got)
+		}
+		w.Write([]byte("{}"))
+	}), WithStoredSession())
+	if _, err := c.ListTiers(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStoredSessionRefreshesWhenExpired(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	t.Setenv("CODEX_SESSION_FILE", path)
+	var srvURL string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/refresh":
+			var req refreshRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.RefreshToken != "rt-old" {
+				t.Errorf("refresh token = %q", req.RefreshToken)
+			}
+			json.NewEncoder(w).Encode(sessionWire{AccessToken: "at-new", ExpiresIn: 3600})
+		default:
+			if got := r.Header.Get("Authorization"); got != "Bearer at-new" {
+				t.Errorf("authorization = %q", got)
+			}
+			w.Write([]byte("{}"))
+		}
+	}))
+	srvURL = c.BaseURL()
+	stale := &Session{
+		AccessToken:  "at-old",
+		RefreshToken: "rt-old",
+		ExpiresAt:    time.Now().Add(-time.Minute),
+		Server:       srvURL,
+	}
+	if err := SaveSession(path, stale); err != nil {
+		t.Fatal(err)
+	}
+	c, err := New(WithBaseURL(srvURL), WithStoredSession())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListTiers(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := LoadSession(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.AccessToken != "at-new" || saved.RefreshToken != "rt-old" {
+		t.Fatalf("saved session: %+v", saved)
+	}
+}
+
+func TestStoredSessionSkipsOtherServer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	t.Setenv("CODEX_SESSION_FILE", path)
+	if err := SaveSession(path, &Session{AccessToken: "at-1", Server: "https://elsewhere:9"}); err != nil {
+		t.Fatal(err)
+	}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("authorization = %q", got)
+		}
+		w.Write([]byte("{}"))
+	}), WithStoredSession())
+	if _, err := c.ListTiers(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
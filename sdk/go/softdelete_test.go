@@ -0,0 +1,71 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSoftDeleteAndRestoreWireShape(t *testing.T) {
+	var paths []string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		var req deleteRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.IDs) != 2 || req.IDs[0] != "a" || req.IDs[1] != "b" {
+			t.Errorf("%s ids: %v", r.URL.Path, req.IDs)
+		}
+		w.Write([]byte(`{}`))
+	}))
+
+	ids := []string{"a", "b"}
+	if err := c.SoftDelete(context.Background(), "kb", ids); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Restore(context.Background(), "kb", ids); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/v1/collections/kb/soft-delete", "/v1/collections/kb/restore"}
+	if len(paths) != 2 || paths[0] != want[0] || paths[1] != want[1] {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+}
+
+func TestPurgeSerializesOlderThan(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/collections/kb/purge" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		var req purgeRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.OlderThanMS != 90000 {
+			t.Errorf("older_than_ms = %d", req.OlderThanMS)
+		}
+		json.NewEncoder(w).Encode(purgeWire{Purged: 7})
+	}))
+
+	purged, err := c.Purge(context.Background(), "kb", 90*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if purged != 7 {
+		t.Fatalf("purged = %d", purged)
+	}
+}
+
+func TestPurgeZeroOmitsThreshold(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if _, ok := body["older_than_ms"]; ok {
+			t.Errorf("older_than_ms sent for zero duration: %v", body)
+		}
+		json.NewEncoder(w).Encode(purgeWire{})
+	}))
+
+	if _, err := c.Purge(context.Background(), "kb", 0); err != nil {
+		t.Fatal(err)
+	}
+}
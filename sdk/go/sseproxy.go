@@ -0,0 +1,159 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// SSEProxy is an http.Handler that bridges browser chat requests to a
+// streaming backend call. It accepts a POST body in the /v1/chat request
+// shape ({"messages": [...], "tier": ..., ...}), opens a ChatStream, and
+// relays the events downstream as server-sent events — flushing per
+// event, emitting comment heartbeats so intermediaries keep the
+// connection open, and cancelling the upstream call the moment the
+// browser disconnects (via the request context).
+type SSEProxy struct {
+	Client *Client
+	// Heartbeat is the interval between ": ping" comments while the
+	// model is silent. Zero means 15 seconds.
+	Heartbeat time.Duration
+}
+
+func (p *SSEProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POST required", http.StatusMethodNotAllowed)
+		return
+	}
+	var req chatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "bad request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	stream, err := p.Client.ChatStream(ctx, req.Messages, func(o *ChatOptions) {
+		*o = req.ChatOptions
+	})
+	if err != nil {
+		cancel()
+		status := http.StatusBadGateway
+		var ae *APIError
+		if errors.As(err, &ae) {
+			status = ae.StatusCode
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // disable proxy buffering
+	flusher, _ := w.(http.Flusher)
+	flush := func() {
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	heartbeat := p.Heartbeat
+	if heartbeat <= 0 {
+		heartbeat = 15 * time.Second
+	}
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+
+	// A reader goroutine feeds events so the relay loop stays free to
+	// emit heartbeats while the model thinks. It owns the stream: only
+	// it calls RecvEvent and Close, so the relay loop returning (or the
+	// browser disconnecting) never races with a read in flight.
+	type received struct {
+		ev  StreamEvent
+		err error
+	}
+	events := make(chan received)
+	go func() {
+		defer close(events)
+		defer stream.Close()
+		for {
+			ev, err := stream.RecvEvent()
+			select {
+			case events <- received{ev, err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+			if _, done := ev.(DoneEvent); done {
+				return
+			}
+		}
+	}()
+	defer func() {
+		cancel()
+		for range events { // wait for the reader to finish with the stream
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return // browser went away; the deferred cancel stops upstream
+		case <-ticker.C:
+			io.WriteString(w, ": ping\n\n")
+			flush()
+		case rec, ok := <-events:
+			if !ok {
+				return
+			}
+			if rec.err != nil {
+				if rec.err != io.EOF {
+					writeSSE(w, "error", map[string]string{"message": rec.err.Error()})
+					flush()
+				}
+				return
+			}
+			name, payload := encodeStreamEvent(rec.ev)
+			writeSSE(w, name, payload)
+			flush()
+			if _, done := rec.ev.(DoneEvent); done {
+				return
+			}
+		}
+	}
+}
+
+// encodeStreamEvent maps a typed event back onto its wire SSE form,
+// inverting decodeStreamEvent.
+func encodeStreamEvent(ev StreamEvent) (name string, payload any) {
+	switch ev := ev.(type) {
+	case TokenEvent:
+		return "token", ev
+	case ToolCallEvent:
+		return "tool_call", ev
+	case CitationEvent:
+		return "citation", ev
+	case UsageEvent:
+		return "usage", ev
+	case DoneEvent:
+		return "done", ev
+	default:
+		return "", ev
+	}
+}
+
+func writeSSE(w io.Writer, event string, payload any) {
+	if event != "" {
+		fmt.Fprintf(w, "event: %s\n", event)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		data = []byte("{}")
+	}
+	fmt.Fprintf(w, "data: %s\n\n", data)
+}
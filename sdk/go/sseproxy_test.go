@@ -0,0 +1,75 @@
+package codex
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSSEProxyRelaysStream(t *testing.T) {
+	upstream := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"token\":\"hi\"}\n\nevent: usage\ndata: {\"usage\":{\"total_tokens\":3}}\n\nevent: done\ndata: {\"finish_reason\":\"stop\"}\n\n")
+	}))
+	proxy := httptest.NewServer(&SSEProxy{Client: upstream})
+	defer proxy.Close()
+
+	resp, err := http.Post(proxy.URL, "application/json",
+		strings.NewReader(`{"messages":[{"role":"user","content":"hi"}],"tier":"fast"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("content type: %q", ct)
+	}
+	buf := make([]byte, 4096)
+	var body strings.Builder
+	for {
+		n, err := resp.Body.Read(buf)
+		body.Write(buf[:n])
+		if err != nil {
+			break
+		}
+	}
+	out := body.String()
+	for _, want := range []string{
+		"event: token\ndata: {\"token\":\"hi\"}",
+		"event: usage",
+		"event: done\ndata: {\"finish_reason\":\"stop\"}",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in relayed stream:\n%s", want, out)
+		}
+	}
+}
+
+func TestSSEProxyHeartbeats(t *testing.T) {
+	release := make(chan struct{})
+	upstream := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.(http.Flusher).Flush()
+		<-release
+		fmt.Fprint(w, "event: done\ndata: {}\n\n")
+	}))
+	proxy := httptest.NewServer(&SSEProxy{Client: upstream, Heartbeat: 20 * time.Millisecond})
+	defer proxy.Close()
+	defer close(release)
+
+	resp, err := http.Post(proxy.URL, "application/json", strings.NewReader(`{"messages":[]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	buf := make([]byte, 64)
+	n, err := resp.Body.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(buf[:n]), ": ping") {
+		t.Fatalf("expected heartbeat while upstream silent, got %q", buf[:n])
+	}
+}
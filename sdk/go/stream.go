@@ -0,0 +1,347 @@
+package codex
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Stream delivers tokens from a streaming endpoint as they are generated.
+// Dropped connections are resumed transparently: the client reconnects
+// with the Last-Event-ID of the last delivered event so the server can
+// continue the generation instead of restarting it, until the configured
+// retry budget is exhausted.
+type Stream struct {
+	c      *Client
+	ctx    context.Context
+	path   string
+	body   []byte
+	reqID  string
+	budget time.Duration
+
+	resp        io.ReadCloser
+	rd          *bufio.Reader
+	lastEventID string
+	retryStart  time.Time
+	closed      bool
+	done        bool // the server finished the generation normally
+}
+
+// StreamResult is the accumulated output of a drained stream. When the
+// caller's context is cancelled mid-generation, Collect returns the
+// tokens received so far with Cancelled set instead of discarding them.
+type StreamResult struct {
+	Text      string
+	Cancelled bool
+}
+
+// sseEvent is one parsed server-sent event.
+type sseEvent struct {
+	id    string
+	event string
+	data  string
+}
+
+// WithStreamRetryBudget bounds the total time a dropped stream may spend
+// reconnecting before the error is surfaced. The default is 30 seconds;
+// zero disables resumption entirely.
+func WithStreamRetryBudget(d time.Duration) Option {
+	return func(c *clientConfig) { c.streamRetryBudget = &d }
+}
+
+type chatStreamRequest struct {
+	Messages []Message `json:"messages"`
+	Stream   bool      `json:"stream"`
+	ChatOptions
+}
+
+// ChatStream starts a streaming chat. The caller must drain the stream
+// with Recv until io.EOF (or call Close).
+func (c *Client) ChatStream(ctx context.Context, messages []Message, opts ...ChatOption) (*Stream, error) {
+	req := chatStreamRequest{Messages: messages, Stream: true, ChatOptions: c.chatOptions(opts)}
+	return c.openStream(ctx, "/v1/chat", req)
+}
+
+type ragStreamRequest struct {
+	Question string `json:"question"`
+	Stream   bool   `json:"stream"`
+	RAGOptions
+}
+
+// RAGAnswerStream starts a streaming retrieval-augmented answer.
+func (c *Client) RAGAnswerStream(ctx context.Context, question string, opts ...RAGOption) (*Stream, error) {
+	req := ragStreamRequest{Question: question, Stream: true, RAGOptions: c.ragOptions(opts)}
+	return c.openStream(ctx, "/v1/rag/answer", req)
+}
+
+func (c *Client) openStream(ctx context.Context, path string, in any) (*Stream, error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("codex: encode request: %w", err)
+	}
+	budget := 30 * time.Second
+	if c.cfg.streamRetryBudget != nil {
+		budget = *c.cfg.streamRetryBudget
+	}
+	s := &Stream{
+		c:      c,
+		ctx:    ctx,
+		path:   path,
+		body:   body,
+		reqID:  requestID(ctx),
+		budget: budget,
+	}
+	if err := s.connect(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// connect opens (or reopens) the SSE connection, resuming from the last
+// delivered event when one is known.
+func (s *Stream) connect() error {
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodPost, s.c.cfg.baseURL+s.path, strings.NewReader(string(s.body)))
+	if err != nil {
+		return fmt.Errorf("codex: build request: %w", err)
+	}
+	s.c.setHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("X-Request-ID", s.reqID)
+	if s.lastEventID != "" {
+		req.Header.Set("Last-Event-ID", s.lastEventID)
+	}
+	resp, err := s.c.httpc.Do(req)
+	if err != nil {
+		return fmt.Errorf("codex: %s (request %s): %w", s.path, s.reqID, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errorFromResponse(resp)
+	}
+	s.resp = resp.Body
+	s.rd = bufio.NewReader(resp.Body)
+	return nil
+}
+
+// Recv returns the next token, skipping non-token events. It returns
+// io.EOF when the generation completed normally. Consumers that care
+// about tool calls, citations, or usage should use RecvEvent instead.
+func (s *Stream) Recv() (string, error) {
+	for {
+		ev, err := s.RecvEvent()
+		if err != nil {
+			return "", err
+		}
+		switch ev := ev.(type) {
+		case TokenEvent:
+			return ev.Token, nil
+		case DoneEvent:
+			return "", io.EOF
+		}
+	}
+}
+
+// RecvEvent returns the next typed event from the stream. The final
+// event is a DoneEvent; subsequent calls return io.EOF.
+func (s *Stream) RecvEvent() (StreamEvent, error) {
+	for {
+		ev, err := s.nextEvent()
+		if err != nil {
+			if !s.resumable(err) {
+				return nil, err
+			}
+			if rerr := s.resume(); rerr != nil {
+				return nil, fmt.Errorf("codex: stream dropped and resume failed: %w", errors.Join(err, rerr))
+			}
+			continue
+		}
+		if ev.id != "" {
+			s.lastEventID = ev.id
+		}
+		s.retryStart = time.Time{} // healthy again; reset the budget
+		typed, ok := decodeStreamEvent(ev)
+		if !ok {
+			continue // unknown event types are skipped
+		}
+		if done, isDone := typed.(DoneEvent); isDone {
+			s.done = true
+			s.Close()
+			return done, nil
+		}
+		return typed, nil
+	}
+}
+
+// decodeStreamEvent maps a wire SSE event onto its typed representation.
+func decodeStreamEvent(ev sseEvent) (StreamEvent, bool) {
+	if ev.event == "done" || ev.data == "[DONE]" {
+		var done DoneEvent
+		json.Unmarshal([]byte(ev.data), &done)
+		return done, true
+	}
+	switch ev.event {
+	case "", "token":
+		var tok TokenEvent
+		if err := json.Unmarshal([]byte(ev.data), &tok); err != nil {
+			// Plain-text data lines are passed through as-is.
+			return TokenEvent{Token: ev.data}, true
+		}
+		return tok, true
+	case "tool_call":
+		var tc ToolCallEvent
+		if err := json.Unmarshal([]byte(ev.data), &tc); err != nil {
+			return nil, false
+		}
+		return tc, true
+	case "citation":
+		var ce CitationEvent
+		if err := json.Unmarshal([]byte(ev.data), &ce); err != nil || ce.Reference.ID == "" {
+			// Some servers send the reference object directly.
+			if err := json.Unmarshal([]byte(ev.data), &ce.Reference); err != nil {
+				return nil, false
+			}
+		}
+		return ce, true
+	case "usage":
+		var ue UsageEvent
+		if err := json.Unmarshal([]byte(ev.data), &ue); err != nil {
+			return nil, false
+		}
+		return ue, true
+	default:
+		return nil, false
+	}
+}
+
+// nextEvent reads one SSE event (terminated by a blank line).
+func (s *Stream) nextEvent() (sseEvent, error) {
+	if s.closed {
+		return sseEvent{}, io.EOF
+	}
+	var ev sseEvent
+	var data []string
+	for {
+		line, err := s.rd.ReadString('\n')
+		if err != nil {
+			return sseEvent{}, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if len(data) == 0 && ev.id == "" && ev.event == "" {
+				continue // heartbeat
+			}
+			ev.data = strings.Join(data, "\n")
+			return ev, nil
+		}
+		if strings.HasPrefix(line, ":") {
+			continue // comment/heartbeat
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			ev.id = value
+		case "event":
+			ev.event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+}
+
+// resumable reports whether the stream should attempt to reconnect after
+// err: only transport-level drops, with resumption enabled, within the
+// retry budget, and with the parent context still alive.
+func (s *Stream) resumable(err error) bool {
+	if s.budget <= 0 || s.closed || s.ctx.Err() != nil {
+		return false
+	}
+	var ae *APIError
+	if errors.As(err, &ae) {
+		return false // the server answered; don't mask real errors
+	}
+	if s.retryStart.IsZero() {
+		s.retryStart = time.Now()
+	}
+	return time.Since(s.retryStart) < s.budget
+}
+
+func (s *Stream) resume() error {
+	if s.resp != nil {
+		s.resp.Close()
+	}
+	var lastErr error
+	for attempt := 0; time.Since(s.retryStart) < s.budget; attempt++ {
+		if err := sleepBackoff(s.ctx, 250*time.Millisecond, attempt); err != nil {
+			return err
+		}
+		if lastErr = s.connect(); lastErr == nil {
+			return nil
+		}
+	}
+	if lastErr == nil {
+		lastErr = context.DeadlineExceeded
+	}
+	return lastErr
+}
+
+// Collect drains the stream into a single result. When the caller's
+// context is cancelled mid-generation, it returns the partial text with
+// Cancelled set (and a nil error) after telling the server to stop
+// generating; other errors are returned as-is alongside the partial text.
+func (s *Stream) Collect() (*StreamResult, error) {
+	var b strings.Builder
+	for {
+		tok, err := s.Recv()
+		if err == io.EOF {
+			return &StreamResult{Text: b.String()}, nil
+		}
+		if err != nil {
+			res := &StreamResult{Text: b.String()}
+			if errors.Is(err, context.Canceled) || s.ctx.Err() != nil {
+				res.Cancelled = true
+				s.Close()
+				return res, nil
+			}
+			return res, err
+		}
+		b.WriteString(tok)
+	}
+}
+
+// Close releases the stream's connection. If the generation had not
+// finished, the server is told to cancel it so it stops generating (and
+// billing) the abandoned request. It is safe to call twice.
+func (s *Stream) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if !s.done {
+		s.cancelServer()
+	}
+	if s.resp != nil {
+		return s.resp.Close()
+	}
+	return nil
+}
+
+// cancelServer notifies the server that this generation was abandoned.
+// Best effort: the caller's context is typically already dead, so a short
+// detached context is used and failures are ignored.
+func (s *Stream) cancelServer() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	body, _ := json.Marshal(map[string]string{"request_id": s.reqID})
+	resp, err := s.c.send(ctx, http.MethodPost, "/v1/cancel", body, s.reqID)
+	if err == nil {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+}
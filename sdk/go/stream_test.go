@@ -0,0 +1,228 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestChatStreamResumesAfterDrop(t *testing.T) {
+	var calls atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fl := w.(http.Flusher)
+		call := calls.Add(1)
+		if call == 1 {
+			fmt.Fprint(w, "id: 1\ndata: {\"token\":\"hel\"}\n\n")
+			fl.Flush()
+			// Drop the connection mid-generation.
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		if got := r.Header.Get("Last-Event-ID"); got != "1" {
+			t.Errorf("resume request missing Last-Event-ID, got %q", got)
+		}
+		fmt.Fprint(w, "id: 2\ndata: {\"token\":\"lo\"}\n\nevent: done\ndata: {}\n\n")
+		fl.Flush()
+	})
+	c := newTestClient(t, handler)
+
+	stream, err := c.ChatStream(context.Background(), []Message{{Role: RoleUser, Content: "hi"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+	var out string
+	for {
+		tok, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		out += tok
+	}
+	if out != "hello" {
+		t.Fatalf("got %q", out)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("expected 2 connections, got %d", calls.Load())
+	}
+}
+
+// flushCountingWriter records how often the stream flushes through it.
+type flushCountingWriter struct {
+	strings.Builder
+	flushes int
+}
+
+func (w *flushCountingWriter) Flush() { w.flushes++ }
+
+func TestChatStreamTo(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"token\":\"hel\"}\n\ndata: {\"token\":\"lo\"}\n\nevent: done\ndata: {}\n\n")
+	}))
+	var out flushCountingWriter
+	err := c.ChatStreamTo(context.Background(), &out, []Message{{Role: RoleUser, Content: "hi"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "hello" {
+		t.Fatalf("got %q", out.String())
+	}
+	if out.flushes != 2 {
+		t.Fatalf("expected a flush per token, got %d", out.flushes)
+	}
+}
+
+func TestRecvEventTypedEvents(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: tool_call\ndata: {\"id\":\"t1\",\"name\":\"search\",\"arguments\":{\"q\":\"go\"}}\n\n")
+		fmt.Fprint(w, "event: citation\ndata: {\"reference\":{\"id\":\"doc#1\",\"score\":0.9}}\n\n")
+		// Some servers send the reference object directly.
+		fmt.Fprint(w, "event: citation\ndata: {\"id\":\"doc#2\",\"score\":0.5}\n\n")
+		// Unknown event types must be skipped, not surfaced or fatal.
+		fmt.Fprint(w, "event: wibble\ndata: {}\n\n")
+		// Non-JSON token data passes through as plain text.
+		fmt.Fprint(w, "data: plain text\n\n")
+		fmt.Fprint(w, "event: usage\ndata: {\"usage\":{\"prompt_tokens\":3,\"completion_tokens\":7,\"total_tokens\":10}}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+
+	stream, err := c.ChatStream(context.Background(), []Message{{Role: RoleUser, Content: "hi"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+
+	next := func() StreamEvent {
+		t.Helper()
+		ev, err := stream.RecvEvent()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ev
+	}
+
+	tc, ok := next().(ToolCallEvent)
+	if !ok || tc.ID != "t1" || tc.Name != "search" || string(tc.Arguments) != `{"q":"go"}` {
+		t.Fatalf("tool call: %+v", tc)
+	}
+	ce, ok := next().(CitationEvent)
+	if !ok || ce.Reference.ID != "doc#1" || ce.Reference.Score != 0.9 {
+		t.Fatalf("citation: %+v", ce)
+	}
+	ce, ok = next().(CitationEvent)
+	if !ok || ce.Reference.ID != "doc#2" || ce.Reference.Score != 0.5 {
+		t.Fatalf("bare-reference citation: %+v", ce)
+	}
+	tok, ok := next().(TokenEvent)
+	if !ok || tok.Token != "plain text" {
+		t.Fatalf("plain token: %+v", tok)
+	}
+	ue, ok := next().(UsageEvent)
+	if !ok || ue.Usage.TotalTokens != 10 {
+		t.Fatalf("usage: %+v", ue)
+	}
+	if _, ok := next().(DoneEvent); !ok {
+		t.Fatal("expected DoneEvent for [DONE] sentinel")
+	}
+	if _, err := stream.RecvEvent(); err != io.EOF {
+		t.Fatalf("after done: %v, want io.EOF", err)
+	}
+}
+
+func TestRecvEventDoneCarriesFinishReason(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: done\ndata: {\"finish_reason\":\"stop\"}\n\n")
+	}))
+
+	stream, err := c.ChatStream(context.Background(), []Message{{Role: RoleUser, Content: "hi"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+	ev, err := stream.RecvEvent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	done, ok := ev.(DoneEvent)
+	if !ok || done.FinishReason != "stop" {
+		t.Fatalf("done: %+v", ev)
+	}
+}
+
+func TestCollectReturnsPartialTextOnCancel(t *testing.T) {
+	tokensSent := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	cancelled := make(chan string, 1)
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/chat":
+			w.Header().Set("Content-Type", "text/event-stream")
+			fl := w.(http.Flusher)
+			fmt.Fprint(w, "data: {\"token\":\"hel\"}\n\ndata: {\"token\":\"lo\"}\n\n")
+			fl.Flush()
+			close(tokensSent)
+			// Keep generating (from the client's view) until the test ends.
+			<-release
+		case "/v1/cancel":
+			var req map[string]string
+			json.NewDecoder(r.Body).Decode(&req)
+			cancelled <- req["request_id"]
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("path = %s", r.URL.Path)
+		}
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := c.ChatStream(ctx, []Message{{Role: RoleUser, Content: "hi"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type collected struct {
+		res *StreamResult
+		err error
+	}
+	done := make(chan collected, 1)
+	go func() {
+		res, err := stream.Collect()
+		done <- collected{res, err}
+	}()
+
+	<-tokensSent
+	cancel()
+
+	got := <-done
+	if got.err != nil {
+		t.Fatal(got.err)
+	}
+	if got.res.Text != "hello" || !got.res.Cancelled {
+		t.Fatalf("result = %+v, want partial text with Cancelled set", got.res)
+	}
+
+	// Closing an unfinished stream must tell the server to stop generating.
+	select {
+	case reqID := <-cancelled:
+		if reqID == "" {
+			t.Error("cancel request carried no request_id")
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("server never received the cancel")
+	}
+}
@@ -0,0 +1,51 @@
+package codex
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// ChatStreamTo streams a chat reply's tokens straight into w as they
+// arrive. If w implements http.Flusher (an http.ResponseWriter, say)
+// each token is flushed immediately, which makes proxying a stream to a
+// browser a one-liner. It blocks until the generation finishes.
+func (c *Client) ChatStreamTo(ctx context.Context, w io.Writer, messages []Message, opts ...ChatOption) error {
+	s, err := c.ChatStream(ctx, messages, opts...)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	return streamTo(s, w)
+}
+
+// RAGAnswerStreamTo streams a retrieval-augmented answer's tokens
+// straight into w; see ChatStreamTo.
+func (c *Client) RAGAnswerStreamTo(ctx context.Context, w io.Writer, question string, opts ...RAGOption) error {
+	s, err := c.RAGAnswerStream(ctx, question, opts...)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	return streamTo(s, w)
+}
+
+func streamTo(s *Stream, w io.Writer) error {
+	flusher, _ := w.(http.Flusher)
+	for {
+		token, err := s.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if _, err := io.WriteString(w, token); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
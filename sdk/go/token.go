@@ -0,0 +1,52 @@
+package codex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// TokenScope bounds what a minted token may do.
+type TokenScope struct {
+	// Collections the token may read. Empty is not allowed — scoped
+	// tokens must name their collections.
+	Collections []string `json:"collections"`
+	// MaxTopK caps retrieval depth per query; zero leaves the server
+	// default.
+	MaxTopK int `json:"max_top_k,omitempty"`
+	// TTL is how long the token lives. Zero lets the server choose
+	// (and servers keep it short).
+	TTL time.Duration `json:"-"`
+}
+
+// ScopedToken is a short-lived credential minted for a frontend.
+type ScopedToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type mintTokenRequest struct {
+	TokenScope
+	TTLSeconds int `json:"ttl_seconds,omitempty"`
+}
+
+// MintScopedToken issues a short-lived token restricted to the given
+// scope, for browsers talking to the proxy handler directly. The
+// primary API key stays on the backend; hand the frontend only the
+// minted token. Tokens cannot be broadened or renewed — mint a fresh
+// one instead.
+func (c *Client) MintScopedToken(ctx context.Context, scope TokenScope) (*ScopedToken, error) {
+	if len(scope.Collections) == 0 {
+		return nil, errors.New("codex: scoped tokens must name at least one collection")
+	}
+	req := mintTokenRequest{TokenScope: scope}
+	if scope.TTL > 0 {
+		req.TTLSeconds = int(scope.TTL / time.Second)
+	}
+	var resp ScopedToken
+	if err := c.do(ctx, http.MethodPost, "/v1/tokens", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
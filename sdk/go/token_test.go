@@ -0,0 +1,47 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestMintScopedToken(t *testing.T) {
+	expires := time.Now().Add(10 * time.Minute).UTC().Truncate(time.Second)
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/tokens" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		var req mintTokenRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Collections) != 1 || req.Collections[0] != "support" {
+			t.Errorf("collections: %v", req.Collections)
+		}
+		if req.MaxTopK != 10 || req.TTLSeconds != 600 {
+			t.Errorf("request: %+v", req)
+		}
+		json.NewEncoder(w).Encode(ScopedToken{Token: "st-abc", ExpiresAt: expires})
+	}))
+	tok, err := c.MintScopedToken(context.Background(), TokenScope{
+		Collections: []string{"support"},
+		MaxTopK:     10,
+		TTL:         10 * time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.Token != "st-abc" || !tok.ExpiresAt.Equal(expires) {
+		t.Fatalf("token: %+v", tok)
+	}
+}
+
+func TestMintScopedTokenRequiresCollections(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected")
+	}))
+	if _, err := c.MintScopedToken(context.Background(), TokenScope{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
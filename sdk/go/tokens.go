@@ -0,0 +1,28 @@
+package codex
+
+import "strings"
+
+// EstimateTokens approximates the token count of text without a model
+// tokenizer: roughly one token per four characters, bounded below by the
+// word count. Good enough for budgeting and cost previews; not exact.
+func EstimateTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+	byChars := (len(text) + 3) / 4
+	byWords := len(strings.Fields(text))
+	if byWords > byChars {
+		return byWords
+	}
+	return byChars
+}
+
+// EstimateMessageTokens approximates the prompt tokens of a conversation,
+// including a small per-message framing overhead.
+func EstimateMessageTokens(messages []Message) int {
+	total := 0
+	for _, m := range messages {
+		total += EstimateTokens(m.Content) + 4
+	}
+	return total
+}
@@ -0,0 +1,41 @@
+package codex
+
+// Trace is the full story of how a RAG answer was assembled: what was
+// retrieved and at which scores, what the reranker did, the prompt that
+// went to the model, and the token spend per stage. It is only populated
+// when the question is asked WithTrace, since collecting it costs the
+// server work.
+type Trace struct {
+	// Retrieved lists the chunks as they came out of the vector search,
+	// before reranking.
+	Retrieved []Match `json:"retrieved,omitempty"`
+	// Reranking records what the reranker did with each candidate.
+	Reranking []RerankDecision `json:"reranking,omitempty"`
+	// Prompt is the final rendered prompt sent to the model.
+	Prompt string `json:"prompt,omitempty"`
+	// StageTokens is the token count per pipeline stage, e.g.
+	// "retrieval", "prompt", "completion".
+	StageTokens map[string]int `json:"stage_tokens,omitempty"`
+	// Hops lists the intermediate sub-queries of a multi-hop answer,
+	// in execution order; see WithMultiHop.
+	Hops []Hop `json:"hops,omitempty"`
+}
+
+// RerankDecision is one candidate's fate during reranking.
+type RerankDecision struct {
+	ID string `json:"id"`
+	// From and To are the candidate's rank before and after reranking.
+	From int `json:"from"`
+	To   int `json:"to"`
+	// Score is the reranker's relevance score.
+	Score float32 `json:"score"`
+	// Dropped is set when the candidate was cut from the prompt.
+	Dropped bool `json:"dropped,omitempty"`
+}
+
+// WithTrace asks the server to attach a full retrieval trace to the RAG
+// result; see Result.Trace. Leave it off in production paths — building
+// the trace is not free.
+func WithTrace() RAGOption {
+	return func(o *RAGOptions) { o.Trace = true }
+}
@@ -0,0 +1,62 @@
+//go:build !js
+
+package codex
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// WithHighThroughputTransport configures a transport tuned for bulk
+// workloads (ingestion, batch embedding, exports): a large connection
+// pool, generous buffers, and long idle timeouts so connections are
+// reused across sustained request streams.
+func WithHighThroughputTransport() Option {
+	return func(c *clientConfig) {
+		c.httpClient = &http.Client{
+			Timeout: 10 * time.Minute,
+			Transport: &http.Transport{
+				DialContext: (&net.Dialer{
+					Timeout:   10 * time.Second,
+					KeepAlive: 30 * time.Second,
+				}).DialContext,
+				ForceAttemptHTTP2:   true,
+				MaxIdleConns:        256,
+				MaxIdleConnsPerHost: 64,
+				MaxConnsPerHost:     0, // unlimited; the pool bounds concurrency
+				IdleConnTimeout:     5 * time.Minute,
+				WriteBufferSize:     256 << 10,
+				ReadBufferSize:      256 << 10,
+				TLSHandshakeTimeout: 10 * time.Second,
+			},
+		}
+	}
+}
+
+// WithLowLatencyTransport configures a transport tuned for interactive
+// workloads (chat, single queries): aggressive keepalives and a small
+// warm pool so requests rarely pay connection setup, and a short overall
+// timeout so hung requests fail fast.
+func WithLowLatencyTransport() Option {
+	return func(c *clientConfig) {
+		c.httpClient = &http.Client{
+			Timeout: 60 * time.Second,
+			Transport: &http.Transport{
+				DialContext: (&net.Dialer{
+					Timeout:   3 * time.Second,
+					KeepAlive: 15 * time.Second,
+				}).DialContext,
+				ForceAttemptHTTP2:     true,
+				MaxIdleConns:          16,
+				MaxIdleConnsPerHost:   8,
+				IdleConnTimeout:       90 * time.Second,
+				WriteBufferSize:       32 << 10,
+				ReadBufferSize:        32 << 10,
+				TLSHandshakeTimeout:   3 * time.Second,
+				ExpectContinueTimeout: 500 * time.Millisecond,
+				ResponseHeaderTimeout: 30 * time.Second,
+			},
+		}
+	}
+}
@@ -0,0 +1,31 @@
+//go:build js
+
+package codex
+
+import (
+	"net/http"
+	"time"
+)
+
+// Under js/wasm the browser's fetch implementation owns connection
+// management, so the transport presets cannot tune dialing or pooling.
+// They still exist with the same names so code compiles unchanged for
+// the browser; only the overall timeout carries over.
+
+// WithHighThroughputTransport configures the client for bulk workloads.
+// On js/wasm this only extends the request timeout; connection pooling
+// is left to the browser.
+func WithHighThroughputTransport() Option {
+	return func(c *clientConfig) {
+		c.httpClient = &http.Client{Timeout: 10 * time.Minute}
+	}
+}
+
+// WithLowLatencyTransport configures the client for interactive
+// workloads. On js/wasm this only shortens the request timeout;
+// keepalives are left to the browser.
+func WithLowLatencyTransport() Option {
+	return func(c *clientConfig) {
+		c.httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+}
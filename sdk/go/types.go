@@ -0,0 +1,68 @@
+package codex
+
+// Role identifies the author of a chat message.
+type Role string
+
+const (
+	RoleSystem    Role = "system"
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+	RoleTool      Role = "tool"
+)
+
+// Message is a single turn in a chat conversation.
+type Message struct {
+	Role    Role   `json:"role"`
+	Content string `json:"content"`
+}
+
+// Usage reports token consumption for a request.
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// Tier describes a model tier advertised by the server. Tiers group models
+// by capability and price; most calls select a tier rather than a model.
+type Tier struct {
+	Name        string `json:"name"`
+	Model       string `json:"model"`
+	Description string `json:"description,omitempty"`
+	// Pricing per million tokens, in USD.
+	InputPricePerMTok  float64 `json:"input_price_per_mtok,omitempty"`
+	OutputPricePerMTok float64 `json:"output_price_per_mtok,omitempty"`
+	ContextWindow      int     `json:"context_window,omitempty"`
+}
+
+// Model describes a concrete model served by the backend.
+type Model struct {
+	ID         string `json:"id"`
+	Family     string `json:"family,omitempty"`
+	Dimensions int    `json:"dimensions,omitempty"` // embedding models only
+}
+
+// VectorRecord is one entry in a vector collection: the embedded vector,
+// the source document text it was derived from, and free-form metadata.
+type VectorRecord struct {
+	ID        string           `json:"id"`
+	Vector    []float32        `json:"vector,omitempty"`
+	Quantized *QuantizedVector `json:"quantized,omitempty"`
+	Document  string           `json:"document,omitempty"`
+	Metadata  map[string]any   `json:"metadata,omitempty"`
+}
+
+// Match is a query hit: a record plus its similarity score.
+type Match struct {
+	VectorRecord
+	Score float32 `json:"score"`
+}
+
+// Collection describes a vector collection on the server.
+type Collection struct {
+	Name         string         `json:"name"`
+	Dimensions   int            `json:"dimensions"`
+	Count        int64          `json:"count,omitempty"`
+	Schema       MetadataSchema `json:"schema,omitempty"`
+	Quantization []Quantization `json:"quantization,omitempty"`
+}
@@ -0,0 +1,90 @@
+package codex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+// UploadOptions configures UploadAndIngest.
+type UploadOptions struct {
+	// Collection the extracted chunks land in. Empty uses the client's
+	// default collection.
+	Collection string
+	// ContentType of the file; empty lets the server sniff it.
+	ContentType string
+	// Metadata is attached to every chunk extracted from the file.
+	Metadata map[string]any
+}
+
+// IngestReport is what a finished server-side ingestion job reports.
+type IngestReport struct {
+	Documents int `json:"documents"`
+	Chunks    int `json:"chunks"`
+	// Skipped counts documents the server could not extract text from.
+	Skipped int `json:"skipped,omitempty"`
+}
+
+// UploadAndIngest streams a source file to the server, which parses,
+// chunks, embeds, and upserts it entirely server-side — the thin-client
+// counterpart to the ingest package. It returns immediately with a job
+// handle; Wait on it for the ingestion report.
+func (c *Client) UploadAndIngest(ctx context.Context, filename string, r io.Reader, opts UploadOptions) (*Operation[IngestReport], error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	if col := c.collection(opts.Collection); col != "" {
+		if err := mw.WriteField("collection", col); err != nil {
+			return nil, fmt.Errorf("codex: build upload: %w", err)
+		}
+	}
+	if len(opts.Metadata) > 0 {
+		js, err := json.Marshal(opts.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("codex: encode upload metadata: %w", err)
+		}
+		if err := mw.WriteField("metadata", string(js)); err != nil {
+			return nil, fmt.Errorf("codex: build upload: %w", err)
+		}
+	}
+	if opts.ContentType != "" {
+		if err := mw.WriteField("content_type", opts.ContentType); err != nil {
+			return nil, fmt.Errorf("codex: build upload: %w", err)
+		}
+	}
+	fw, err := mw.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, fmt.Errorf("codex: build upload: %w", err)
+	}
+	if _, err := io.Copy(fw, r); err != nil {
+		return nil, fmt.Errorf("codex: read %s: %w", filename, err)
+	}
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("codex: build upload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.baseURL+"/v1/ingest/files", &body)
+	if err != nil {
+		return nil, fmt.Errorf("codex: build request: %w", err)
+	}
+	c.setHeaders(req)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	resp, err := c.httpc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("codex: POST /v1/ingest/files: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, errorFromResponse(resp)
+	}
+	var wire struct {
+		OperationID string `json:"operation_id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&wire); err != nil {
+		return nil, fmt.Errorf("codex: decode response: %w", err)
+	}
+	return NewOperation[IngestReport](c, wire.OperationID, 0), nil
+}
@@ -0,0 +1,60 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestUploadAndIngest(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/ingest/files":
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatal(err)
+			}
+			if got := r.FormValue("collection"); got != "kb" {
+				t.Errorf("collection = %q", got)
+			}
+			if !strings.Contains(r.FormValue("metadata"), `"source"`) {
+				t.Errorf("metadata = %q", r.FormValue("metadata"))
+			}
+			file, header, err := r.FormFile("file")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			if header.Filename != "handbook.pdf" {
+				t.Errorf("filename = %q", header.Filename)
+			}
+			body, _ := io.ReadAll(file)
+			if string(body) != "%PDF-fake" {
+				t.Errorf("file body = %q", body)
+			}
+			json.NewEncoder(w).Encode(map[string]string{"operation_id": "ing-1"})
+		case "/v1/operations/ing-1":
+			json.NewEncoder(w).Encode(map[string]any{
+				"id": "ing-1", "status": "succeeded",
+				"result": IngestReport{Documents: 1, Chunks: 42},
+			})
+		default:
+			t.Errorf("path = %s", r.URL.Path)
+		}
+	}), WithDefaultCollection("kb"))
+
+	job, err := c.UploadAndIngest(context.Background(), "handbook.pdf", strings.NewReader("%PDF-fake"),
+		UploadOptions{Metadata: map[string]any{"source": "hr"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := job.Wait(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Chunks != 42 {
+		t.Fatalf("report: %+v", report)
+	}
+}
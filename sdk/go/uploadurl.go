@@ -0,0 +1,46 @@
+package codex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// UploadURL is a pre-signed browser upload issued by CreateUploadURL.
+type UploadURL struct {
+	// URL accepts one PUT of the file within the TTL, with no
+	// Authorization header — hand it to the frontend as-is.
+	URL string `json:"url"`
+	// Token identifies the upload in the ingestion callback: the
+	// frontend (or the server's webhook) presents it so the backend can
+	// correlate the finished upload with who requested it.
+	Token string `json:"token"`
+	// ExpiresAt is when the URL stops accepting the upload.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type uploadURLRequest struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type,omitempty"`
+	TTLSeconds  int    `json:"ttl_seconds,omitempty"`
+}
+
+// CreateUploadURL asks the server for a pre-signed URL so a web
+// frontend can upload a document straight to the server, without the
+// bytes passing through this backend. The URL is good for one upload of
+// the named file until ttl elapses (zero lets the server choose).
+func (c *Client) CreateUploadURL(ctx context.Context, filename, contentType string, ttl time.Duration) (*UploadURL, error) {
+	if filename == "" {
+		return nil, errors.New("codex: upload URL needs a filename")
+	}
+	req := uploadURLRequest{Filename: filename, ContentType: contentType}
+	if ttl > 0 {
+		req.TTLSeconds = int(ttl / time.Second)
+	}
+	var resp UploadURL
+	if err := c.do(ctx, http.MethodPost, "/v1/uploads/sign", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,44 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCreateUploadURL(t *testing.T) {
+	expires := time.Now().Add(15 * time.Minute).UTC().Truncate(time.Second)
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/uploads/sign" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		var req uploadURLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Filename != "report.pdf" || req.ContentType != "application/pdf" || req.TTLSeconds != 900 {
+			t.Errorf("request: %+v", req)
+		}
+		json.NewEncoder(w).Encode(UploadURL{
+			URL:       "https://uploads.example.com/signed?sig=abc",
+			Token:     "cb-token",
+			ExpiresAt: expires,
+		})
+	}))
+	u, err := c.CreateUploadURL(context.Background(), "report.pdf", "application/pdf", 15*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Token != "cb-token" || !u.ExpiresAt.Equal(expires) {
+		t.Fatalf("url: %+v", u)
+	}
+}
+
+func TestCreateUploadURLNeedsFilename(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected")
+	}))
+	if _, err := c.CreateUploadURL(context.Background(), "", "", 0); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,287 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Filter restricts vector queries to records whose metadata matches every
+// key/value pair. It serializes to the server's filter JSON.
+type Filter map[string]any
+
+// collection resolves an explicit collection name against the client default.
+func (c *Client) collection(name string) string {
+	if name != "" {
+		return name
+	}
+	return c.cfg.defaultCollection
+}
+
+type upsertRequest struct {
+	Records []VectorRecord `json:"records"`
+}
+
+// Upsert inserts or replaces a single record in collection.
+func (c *Client) Upsert(ctx context.Context, collection string, rec VectorRecord) error {
+	return c.UpsertBatch(ctx, collection, []VectorRecord{rec})
+}
+
+// UpsertBatch inserts or replaces records in collection in one request.
+func (c *Client) UpsertBatch(ctx context.Context, collection string, recs []VectorRecord) error {
+	if len(recs) == 0 {
+		return nil
+	}
+	if schema, ok := c.schema(c.collection(collection)); ok {
+		for _, rec := range recs {
+			if err := schema.Validate(rec); err != nil {
+				return err
+			}
+		}
+	}
+	if c.cfg.keyring != nil {
+		sealed := make([]VectorRecord, len(recs))
+		for i, rec := range recs {
+			var err error
+			if sealed[i], err = c.encryptRecord(rec); err != nil {
+				return err
+			}
+		}
+		recs = sealed
+	}
+	started := time.Now()
+	path := "/v1/collections/" + url.PathEscape(c.collection(collection)) + "/vectors"
+	// The response body is optional: servers reply with per-item
+	// failures only when something went wrong.
+	var resp struct {
+		Failures []wireItemFailure `json:"failures,omitempty"`
+	}
+	decode := func(dec *json.Decoder) error {
+		if err := dec.Decode(&resp); err != nil && err != io.EOF {
+			return err
+		}
+		return nil
+	}
+	if err := c.doDecode(ctx, http.MethodPut, path, upsertRequest{Records: recs}, decode); err != nil {
+		c.report(Progress{Operation: "upsert", Total: len(recs), Failed: len(recs), Started: started})
+		return err
+	}
+	var bytes int64
+	for i := range recs {
+		bytes += int64(len(recs[i].Document) + 4*len(recs[i].Vector))
+	}
+	failed := len(resp.Failures)
+	c.report(Progress{Operation: "upsert", Done: len(recs) - failed, Total: len(recs), Failed: failed, Bytes: bytes, Started: started})
+	return batchError("upsert", resp.Failures)
+}
+
+type queryRequest struct {
+	Vector  []float32 `json:"vector"`
+	TopK    int       `json:"top_k"`
+	Offset  int       `json:"offset,omitempty"`
+	Filter  Filter    `json:"filter,omitempty"`
+	Explain bool      `json:"explain,omitempty"`
+}
+
+type queryWire struct {
+	Matches []Match  `json:"matches"`
+	Explain *Explain `json:"explain,omitempty"`
+}
+
+// Query returns the topK records in collection most similar to vector,
+// optionally restricted by filter (may be nil).
+func (c *Client) Query(ctx context.Context, collection string, vector []float32, topK int, filter Filter) ([]Match, error) {
+	return c.queryPage(ctx, collection, vector, topK, 0, filter)
+}
+
+// queryPage is Query with an explicit result offset, used by QueryIter
+// to page through large result sets.
+func (c *Client) queryPage(ctx context.Context, collection string, vector []float32, topK, offset int, filter Filter) ([]Match, error) {
+	path := "/v1/collections/" + url.PathEscape(c.collection(collection)) + "/query"
+	req := queryRequest{Vector: vector, TopK: topK, Offset: offset, Filter: filter}
+	if pool := c.cfg.vectorPool; pool != nil {
+		var matches []Match
+		err := c.doDecode(ctx, http.MethodPost, path, req, func(dec *json.Decoder) error {
+			matches = matches[:0]
+			return pool.decodeRecordList(dec, "matches", func(dec *json.Decoder) error {
+				var m Match
+				err := pool.decodeRecord(dec, &m.VectorRecord, func(key string, dec *json.Decoder) error {
+					if key == "score" {
+						return dec.Decode(&m.Score)
+					}
+					return skipValue(dec)
+				})
+				if err != nil {
+					return err
+				}
+				matches = append(matches, m)
+				return nil
+			}, nil)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := c.decryptMatches(matches); err != nil {
+			return nil, err
+		}
+		return matches, nil
+	}
+	var resp queryWire
+	if err := c.do(ctx, http.MethodPost, path, req, &resp); err != nil {
+		return nil, err
+	}
+	if err := c.decryptMatches(resp.Matches); err != nil {
+		return nil, err
+	}
+	return resp.Matches, nil
+}
+
+type fetchRequest struct {
+	IDs []string `json:"ids"`
+}
+
+type fetchWire struct {
+	Records []VectorRecord `json:"records"`
+}
+
+// Fetch returns the records with the given IDs. Missing IDs are omitted
+// from the result rather than reported as errors.
+func (c *Client) Fetch(ctx context.Context, collection string, ids []string) ([]VectorRecord, error) {
+	path := "/v1/collections/" + url.PathEscape(c.collection(collection)) + "/fetch"
+	if pool := c.cfg.vectorPool; pool != nil {
+		var recs []VectorRecord
+		err := c.doDecode(ctx, http.MethodPost, path, fetchRequest{IDs: ids}, func(dec *json.Decoder) error {
+			recs = recs[:0]
+			return pool.decodeRecordList(dec, "records", func(dec *json.Decoder) error {
+				var rec VectorRecord
+				if err := pool.decodeRecord(dec, &rec, nil); err != nil {
+					return err
+				}
+				recs = append(recs, rec)
+				return nil
+			}, nil)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := c.decryptRecords(recs); err != nil {
+			return nil, err
+		}
+		return recs, nil
+	}
+	var resp fetchWire
+	if err := c.do(ctx, http.MethodPost, path, fetchRequest{IDs: ids}, &resp); err != nil {
+		return nil, err
+	}
+	if err := c.decryptRecords(resp.Records); err != nil {
+		return nil, err
+	}
+	return resp.Records, nil
+}
+
+type deleteRequest struct {
+	IDs    []string `json:"ids,omitempty"`
+	Filter Filter   `json:"filter,omitempty"`
+}
+
+// Delete removes the records with the given IDs from collection.
+func (c *Client) Delete(ctx context.Context, collection string, ids []string) error {
+	path := "/v1/collections/" + url.PathEscape(c.collection(collection)) + "/delete"
+	return c.do(ctx, http.MethodPost, path, deleteRequest{IDs: ids}, nil)
+}
+
+// SoftDelete marks the records with the given IDs as deleted. They stop
+// appearing in queries, fetches, and scrolls but remain on the server
+// until purged, so a mistaken deletion can be undone with Restore.
+func (c *Client) SoftDelete(ctx context.Context, collection string, ids []string) error {
+	path := "/v1/collections/" + url.PathEscape(c.collection(collection)) + "/soft-delete"
+	return c.do(ctx, http.MethodPost, path, deleteRequest{IDs: ids}, nil)
+}
+
+// Restore brings soft-deleted records back. Restoring a record that was
+// never soft-deleted is a no-op.
+func (c *Client) Restore(ctx context.Context, collection string, ids []string) error {
+	path := "/v1/collections/" + url.PathEscape(c.collection(collection)) + "/restore"
+	return c.do(ctx, http.MethodPost, path, deleteRequest{IDs: ids}, nil)
+}
+
+type purgeRequest struct {
+	OlderThanMS int64 `json:"older_than_ms,omitempty"`
+}
+
+type purgeWire struct {
+	Purged int64 `json:"purged"`
+}
+
+// Purge permanently removes records that have been soft-deleted for at
+// least olderThan (zero purges all soft-deleted records) and returns how
+// many were destroyed. This is irreversible.
+func (c *Client) Purge(ctx context.Context, collection string, olderThan time.Duration) (int64, error) {
+	path := "/v1/collections/" + url.PathEscape(c.collection(collection)) + "/purge"
+	var resp purgeWire
+	if err := c.do(ctx, http.MethodPost, path, purgeRequest{OlderThanMS: olderThan.Milliseconds()}, &resp); err != nil {
+		return 0, err
+	}
+	return resp.Purged, nil
+}
+
+type scrollRequest struct {
+	Cursor string `json:"cursor,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+	Filter Filter `json:"filter,omitempty"`
+}
+
+type scrollWire struct {
+	Records    []VectorRecord `json:"records"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// Scroll pages through every record in collection. Pass the returned
+// cursor to the next call; an empty next cursor means the end was reached.
+func (c *Client) Scroll(ctx context.Context, collection, cursor string, limit int) ([]VectorRecord, string, error) {
+	return c.scrollPage(ctx, collection, cursor, limit, nil)
+}
+
+func (c *Client) scrollPage(ctx context.Context, collection, cursor string, limit int, filter Filter) ([]VectorRecord, string, error) {
+	path := "/v1/collections/" + url.PathEscape(c.collection(collection)) + "/scroll"
+	req := scrollRequest{Cursor: cursor, Limit: limit, Filter: filter}
+	if pool := c.cfg.vectorPool; pool != nil {
+		var recs []VectorRecord
+		var next string
+		err := c.doDecode(ctx, http.MethodPost, path, req, func(dec *json.Decoder) error {
+			recs, next = recs[:0], ""
+			return pool.decodeRecordList(dec, "records", func(dec *json.Decoder) error {
+				var rec VectorRecord
+				if err := pool.decodeRecord(dec, &rec, nil); err != nil {
+					return err
+				}
+				recs = append(recs, rec)
+				return nil
+			}, func(key string, dec *json.Decoder) error {
+				if key == "next_cursor" {
+					return dec.Decode(&next)
+				}
+				return skipValue(dec)
+			})
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if err := c.decryptRecords(recs); err != nil {
+			return nil, "", err
+		}
+		return recs, next, nil
+	}
+	var resp scrollWire
+	err := c.do(ctx, http.MethodPost, path, req, &resp)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := c.decryptRecords(resp.Records); err != nil {
+		return nil, "", err
+	}
+	return resp.Records, resp.NextCursor, nil
+}
@@ -0,0 +1,193 @@
+package codex
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// VectorPool recycles []float32 slices between vector-heavy responses.
+// Services issuing thousands of queries per second pass one pool via
+// WithVectorPool and return result vectors with Put once consumed, so
+// steady-state query traffic stops allocating fresh vector storage.
+type VectorPool struct {
+	pool sync.Pool
+}
+
+// Get returns an empty slice from the pool (or a fresh one).
+func (p *VectorPool) Get() []float32 {
+	if v, ok := p.pool.Get().(*[]float32); ok {
+		return (*v)[:0]
+	}
+	return nil
+}
+
+// Put returns vectors to the pool for reuse. Callers must not retain the
+// slices afterwards.
+func (p *VectorPool) Put(vectors ...[]float32) {
+	for _, v := range vectors {
+		if cap(v) == 0 {
+			continue
+		}
+		v := v
+		p.pool.Put(&v)
+	}
+}
+
+// WithVectorPool makes Query, Fetch, and Scroll decode response vectors
+// into pooled storage via a streaming decoder instead of buffering whole
+// responses, cutting allocation and GC pressure under heavy query load.
+// Vectors in returned records are owned by the caller until handed back
+// with Put.
+func WithVectorPool(p *VectorPool) Option {
+	return func(c *clientConfig) { c.vectorPool = p }
+}
+
+// decodeRecordList walks `{"<arrayKey>": [ {record}, ... ], ...}` token by
+// token, decoding each record's vector into pooled storage. Keys other
+// than arrayKey are reported to other (which may be nil to skip them).
+func (p *VectorPool) decodeRecordList(dec *json.Decoder, arrayKey string, record func(*json.Decoder) error, other func(key string, dec *json.Decoder) error) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("expected object key, got %v", tok)
+		}
+		if key != arrayKey {
+			if other != nil {
+				if err := other(key, dec); err != nil {
+					return err
+				}
+			} else if err := skipValue(dec); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := expectDelim(dec, '['); err != nil {
+			return err
+		}
+		for dec.More() {
+			if err := record(dec); err != nil {
+				return err
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+	return expectDelim(dec, '}')
+}
+
+// decodeRecord decodes one vector record object, pulling the vector field
+// through the pool. extra is called for fields the record struct does not
+// cover (e.g. score); it may be nil.
+func (p *VectorPool) decodeRecord(dec *json.Decoder, rec *VectorRecord, extra func(key string, dec *json.Decoder) error) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+		switch key {
+		case "id":
+			if err := dec.Decode(&rec.ID); err != nil {
+				return err
+			}
+		case "document":
+			if err := dec.Decode(&rec.Document); err != nil {
+				return err
+			}
+		case "metadata":
+			if err := dec.Decode(&rec.Metadata); err != nil {
+				return err
+			}
+		case "vector":
+			v, err := p.decodeFloats(dec)
+			if err != nil {
+				return err
+			}
+			rec.Vector = v
+		default:
+			if extra != nil {
+				if err := extra(key, dec); err != nil {
+					return err
+				}
+			} else if err := skipValue(dec); err != nil {
+				return err
+			}
+		}
+	}
+	return expectDelim(dec, '}')
+}
+
+// decodeFloats reads a JSON number array into a pooled slice.
+func (p *VectorPool) decodeFloats(dec *json.Decoder) ([]float32, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok == nil {
+		return nil, nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return nil, fmt.Errorf("expected vector array, got %v", tok)
+	}
+	v := p.Get()
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		f, ok := tok.(float64)
+		if !ok {
+			return nil, fmt.Errorf("expected vector element, got %v", tok)
+		}
+		v = append(v, float32(f))
+	}
+	if err := expectDelim(dec, ']'); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("expected %q, got %v", want, tok)
+	}
+	return nil
+}
+
+// skipValue consumes exactly one JSON value from dec.
+func skipValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if d, ok := tok.(json.Delim); ok {
+			switch d {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
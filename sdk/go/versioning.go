@@ -0,0 +1,47 @@
+package codex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// VersionKey is the metadata key that carries a chunk's document
+// version. Documents sharing a doc_id but differing in VersionKey are
+// revisions of the same logical document.
+const VersionKey = "doc_version"
+
+// WithLatestOnly restricts retrieval to the newest version of each
+// logical document, so answers can never come from a superseded
+// revision — the safe default for policy corpora.
+func WithLatestOnly() RAGOption {
+	return func(o *RAGOptions) { o.LatestOnly = true }
+}
+
+// WithPinnedVersions retrieves exactly the given document versions,
+// keyed by logical doc ID, regardless of newer revisions — e.g. to
+// answer from the policy set that was in force on a past date.
+// Documents not in pins retrieve as usual.
+func WithPinnedVersions(pins map[string]int) RAGOption {
+	return func(o *RAGOptions) { o.PinnedVersions = pins }
+}
+
+// DeleteWhere removes every record in collection matching filter,
+// the bulk counterpart to Delete.
+func (c *Client) DeleteWhere(ctx context.Context, collection string, filter Filter) error {
+	if len(filter) == 0 {
+		return fmt.Errorf("codex: DeleteWhere needs a filter; use DeleteCollection to drop everything")
+	}
+	path := "/v1/collections/" + url.PathEscape(c.collection(collection)) + "/delete"
+	return c.do(ctx, http.MethodPost, path, deleteRequest{Filter: filter}, nil)
+}
+
+// SupersedeDocument removes all chunks of docID older than keepVersion,
+// leaving the current revision as the only retrievable one. Ingestion
+// pipelines call it after a new version lands (see the ingest package's
+// Superseder hook); it is exported for corpora maintained by hand.
+func (c *Client) SupersedeDocument(ctx context.Context, collection, docID string, keepVersion int) error {
+	filter := F("doc_id").Eq(docID).And(F(VersionKey).Lt(keepVersion)).Filter()
+	return c.DeleteWhere(ctx, collection, filter)
+}
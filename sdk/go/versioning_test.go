@@ -0,0 +1,57 @@
+package codex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestSupersedeDocumentDeletesOlderVersions(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/collections/policies/delete" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		var req deleteRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.IDs) != 0 {
+			t.Errorf("ids: %v", req.IDs)
+		}
+		js, _ := json.Marshal(req.Filter)
+		want := `{"$and":[{"doc_id":"leave-policy"},{"doc_version":{"$lt":3}}]}`
+		if string(js) != want {
+			t.Errorf("filter = %s", js)
+		}
+	}))
+	if err := c.SupersedeDocument(context.Background(), "policies", "leave-policy", 3); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeleteWhereRefusesEmptyFilter(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected")
+	}))
+	if err := c.DeleteWhere(context.Background(), "kb", nil); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestVersionPinningOnWire(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ragRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if !req.LatestOnly {
+			t.Error("latest_only not set")
+		}
+		if req.PinnedVersions["leave-policy"] != 2 {
+			t.Errorf("pins: %v", req.PinnedVersions)
+		}
+		json.NewEncoder(w).Encode(Result{Answer: "ok"})
+	}))
+	_, err := c.RAGAnswer(context.Background(), "q",
+		WithLatestOnly(), WithPinnedVersions(map[string]int{"leave-policy": 2}))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,150 @@
+package codex
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// WebhookEventType names the async events the server can deliver.
+type WebhookEventType string
+
+const (
+	// EventJobFinished fires when a background job completes.
+	EventJobFinished WebhookEventType = "job.finished"
+	// EventIngestComplete fires when an ingestion run finishes.
+	EventIngestComplete WebhookEventType = "ingest.complete"
+	// EventQuotaExceeded fires when a tenant crosses a quota.
+	EventQuotaExceeded WebhookEventType = "quota.exceeded"
+)
+
+// WebhookSignatureHeader carries the hex HMAC-SHA256 of the request body
+// keyed with the webhook's secret.
+const WebhookSignatureHeader = "X-Codex-Signature"
+
+type registerWebhookRequest struct {
+	URL    string             `json:"url"`
+	Events []WebhookEventType `json:"events"`
+	Secret string             `json:"secret"`
+}
+
+type registerWebhookWire struct {
+	ID string `json:"id"`
+}
+
+// RegisterWebhook subscribes url to the given events. Deliveries are
+// signed with secret; verify them with WebhookHandler. The returned ID
+// identifies the subscription.
+func (c *Client) RegisterWebhook(ctx context.Context, url string, events []WebhookEventType, secret string) (string, error) {
+	if url == "" || len(events) == 0 {
+		return "", errors.New("codex: webhook needs a URL and at least one event")
+	}
+	req := registerWebhookRequest{URL: url, Events: events, Secret: secret}
+	var resp registerWebhookWire
+	if err := c.do(ctx, http.MethodPost, "/v1/webhooks", req, &resp); err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// WebhookEvent is one delivered event. Use the typed accessors to decode
+// the payload for the event's type.
+type WebhookEvent struct {
+	Type      WebhookEventType `json:"type"`
+	CreatedAt time.Time        `json:"created_at"`
+	Data      json.RawMessage  `json:"data"`
+}
+
+// JobFinishedEvent is the payload of EventJobFinished.
+type JobFinishedEvent struct {
+	JobID  string `json:"job_id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// IngestCompleteEvent is the payload of EventIngestComplete.
+type IngestCompleteEvent struct {
+	Source    string `json:"source"`
+	Documents int    `json:"documents"`
+	Failed    int    `json:"failed,omitempty"`
+}
+
+// QuotaExceededEvent is the payload of EventQuotaExceeded.
+type QuotaExceededEvent struct {
+	Tenant string `json:"tenant,omitempty"`
+	Quota  string `json:"quota"`
+	Used   int64  `json:"used"`
+	Limit  int64  `json:"limit"`
+}
+
+// JobFinished decodes the payload of an EventJobFinished event.
+func (e WebhookEvent) JobFinished() (*JobFinishedEvent, error) {
+	return decodeEventData[JobFinishedEvent](e, EventJobFinished)
+}
+
+// IngestComplete decodes the payload of an EventIngestComplete event.
+func (e WebhookEvent) IngestComplete() (*IngestCompleteEvent, error) {
+	return decodeEventData[IngestCompleteEvent](e, EventIngestComplete)
+}
+
+// QuotaExceeded decodes the payload of an EventQuotaExceeded event.
+func (e WebhookEvent) QuotaExceeded() (*QuotaExceededEvent, error) {
+	return decodeEventData[QuotaExceededEvent](e, EventQuotaExceeded)
+}
+
+func decodeEventData[T any](e WebhookEvent, want WebhookEventType) (*T, error) {
+	if e.Type != want {
+		return nil, fmt.Errorf("codex: event is %q, not %q", e.Type, want)
+	}
+	var v T
+	if err := json.Unmarshal(e.Data, &v); err != nil {
+		return nil, fmt.Errorf("codex: decode %s payload: %w", e.Type, err)
+	}
+	return &v, nil
+}
+
+// SignWebhookBody computes the signature the server attaches to a
+// delivery, exposed so tests and local delivery tools can forge valid
+// requests.
+func SignWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookHandler returns an http.Handler that verifies each delivery's
+// signature against secret, decodes the event, and hands it to fn.
+// Deliveries with a bad signature get a 401 and never reach fn.
+func WebhookHandler(secret string, fn func(context.Context, WebhookEvent)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "read body", http.StatusBadRequest)
+			return
+		}
+		want := SignWebhookBody(secret, body)
+		got := r.Header.Get(WebhookSignatureHeader)
+		if got == "" || !hmac.Equal([]byte(want), []byte(got)) {
+			http.Error(w, "bad signature", http.StatusUnauthorized)
+			return
+		}
+		var event WebhookEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			http.Error(w, "bad payload", http.StatusBadRequest)
+			return
+		}
+		fn(r.Context(), event)
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,64 @@
+package codex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRegisterWebhook(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req registerWebhookRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.URL != "https://example.com/hook" || len(req.Events) != 2 || req.Secret != "s3cret" {
+			t.Errorf("request: %+v", req)
+		}
+		json.NewEncoder(w).Encode(registerWebhookWire{ID: "wh-1"})
+	}))
+	id, err := c.RegisterWebhook(context.Background(), "https://example.com/hook",
+		[]WebhookEventType{EventJobFinished, EventQuotaExceeded}, "s3cret")
+	if err != nil || id != "wh-1" {
+		t.Fatalf("id %q, err %v", id, err)
+	}
+}
+
+func TestWebhookHandlerVerifiesAndDecodes(t *testing.T) {
+	var got WebhookEvent
+	h := WebhookHandler("s3cret", func(_ context.Context, e WebhookEvent) { got = e })
+
+	body, _ := json.Marshal(WebhookEvent{
+		Type: EventJobFinished,
+		Data: json.RawMessage(`{"job_id":"j1","status":"done"}`),
+	})
+	req := httptest.NewRequest(http.MethodPost, "/hook", bytes.NewReader(body))
+	req.Header.Set(WebhookSignatureHeader, SignWebhookBody("s3cret", body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status %d", rec.Code)
+	}
+	job, err := got.JobFinished()
+	if err != nil || job.JobID != "j1" || job.Status != "done" {
+		t.Fatalf("payload: %+v, err %v", job, err)
+	}
+	if _, err := got.QuotaExceeded(); err == nil {
+		t.Fatal("decoding the wrong event type should fail")
+	}
+}
+
+func TestWebhookHandlerRejectsBadSignature(t *testing.T) {
+	h := WebhookHandler("s3cret", func(context.Context, WebhookEvent) {
+		t.Error("handler ran on a forged delivery")
+	})
+	body := []byte(`{"type":"job.finished","data":{}}`)
+	req := httptest.NewRequest(http.MethodPost, "/hook", bytes.NewReader(body))
+	req.Header.Set(WebhookSignatureHeader, SignWebhookBody("wrong", body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status %d", rec.Code)
+	}
+}